@@ -273,6 +273,29 @@ const (
 
 	// ClusterRemoteConnectionProbeSucceededV1Beta2Reason is used to report a working connection with the workload cluster.
 	ClusterRemoteConnectionProbeSucceededV1Beta2Reason = "ProbeSucceeded"
+
+	// ClusterRemoteConnectionProbeSlowV1Beta2Reason surfaces a working connection with the workload cluster
+	// where the health probe takes more than 1s.
+	ClusterRemoteConnectionProbeSlowV1Beta2Reason = "ProbeSlow"
+)
+
+// Cluster's VersionDrift condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// ClusterVersionDriftV1Beta2Condition is true if the version of the API server discovered via the remote connection
+	// to the workload cluster, reported in status.apiServerVersion, differs from the declared version, i.e. from
+	// spec.topology.version or from the control plane's spec.version; only major, minor and patch are compared.
+	// Note: a drift is expected while the control plane is being upgraded.
+	ClusterVersionDriftV1Beta2Condition = "VersionDrift"
+
+	// ClusterVersionDriftV1Beta2Reason surfaces when the API server is running a version different from the declared one.
+	ClusterVersionDriftV1Beta2Reason = "VersionDrift"
+
+	// ClusterNoVersionDriftV1Beta2Reason surfaces when the API server is running the declared version, or when
+	// the Cluster does not declare a version.
+	ClusterNoVersionDriftV1Beta2Reason = "NoVersionDrift"
+
+	// ClusterVersionDriftAPIServerVersionUnknownV1Beta2Reason surfaces when the version of the API server is not known yet.
+	ClusterVersionDriftAPIServerVersionUnknownV1Beta2Reason = "APIServerVersionUnknown"
 )
 
 // Cluster's ScalingUp condition and corresponding reasons that will be used in v1Beta2 API version.
@@ -813,6 +836,12 @@ type ClusterStatus struct {
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// apiServerVersion is the version of the API server of the workload cluster, as periodically discovered
+	// via the remote connection to the workload cluster.
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	APIServerVersion string `json:"apiServerVersion,omitempty"`
+
 	// v1beta2 groups all the fields that will be added or modified in Cluster's status with the V1Beta2 version.
 	// +optional
 	V1Beta2 *ClusterV1Beta2Status `json:"v1beta2,omitempty"`
@@ -944,6 +973,7 @@ func (v APIEndpoint) String() string {
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="ClusterClass",type="string",JSONPath=".spec.topology.class",description="ClusterClass of this Cluster, empty if the Cluster is not using a ClusterClass"
+// +kubebuilder:printcolumn:name="Available",type="string",JSONPath=`.status.v1beta2.conditions[?(@.type=="Available")].status`,description="Cluster pass all availability checks"
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Cluster status such as Pending/Provisioning/Provisioned/Deleting/Failed"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of Cluster"
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.topology.version",description="Kubernetes version associated with this Cluster"
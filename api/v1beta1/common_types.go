@@ -92,6 +92,16 @@ const (
 	// LabelsFromMachineAnnotation is the annotation set on nodes to track the labels originated from machines.
 	LabelsFromMachineAnnotation = "cluster.x-k8s.io/labels-from-machine"
 
+	// AnnotationsFromMachineAnnotation is the annotation set on nodes to track the annotations originated from machines.
+	AnnotationsFromMachineAnnotation = "cluster.x-k8s.io/annotations-from-machine"
+
+	// TaintsFromMachineAnnotation is the annotation set on nodes to track the taints originated from machines.
+	TaintsFromMachineAnnotation = "cluster.x-k8s.io/taints-from-machine"
+
+	// CordonedByMachineAnnotation is the annotation set on nodes which have been cordoned because the machine has the
+	// machine.cluster.x-k8s.io/cordon-node annotation; it is used to only uncordon nodes which have been cordoned this way.
+	CordonedByMachineAnnotation = "cluster.x-k8s.io/cordoned-by-machine"
+
 	// OwnerNameAnnotation is the annotation set on nodes identifying the owner name.
 	OwnerNameAnnotation = "cluster.x-k8s.io/owner-name"
 
@@ -115,6 +125,7 @@ const (
 
 	// DeleteMachineAnnotation marks control plane and worker nodes that will be given priority for deletion
 	// when KCP or a machineset scales down. This annotation is given top priority on all delete policies.
+	// The annotation can also be set on the Node of a Machine, in which case it is propagated to the Machine.
 	DeleteMachineAnnotation = "cluster.x-k8s.io/delete-machine"
 
 	// TemplateClonedFromNameAnnotation is the infrastructure machine annotation that stores the name of the infrastructure template resource
@@ -131,6 +142,35 @@ const (
 	// RemediateMachineAnnotation is the annotation used to mark machines that should be remediated by MachineHealthCheck reconciler.
 	RemediateMachineAnnotation = "cluster.x-k8s.io/remediate-machine"
 
+	// MachineBootstrapDataSecretMissingPolicyAnnotation can be set on a Machine to control what happens when the
+	// bootstrap data secret referenced by spec.bootstrap.dataSecretName is deleted before the infrastructure provider
+	// consumed it. Supported values are:
+	// - Report (default: marks the BootstrapReady condition as false with the BootstrapDataSecretNotFound reason)
+	// - Ignore (keeps reporting bootstrap as ready)
+	// Once the infrastructure is provisioned, a missing bootstrap data secret is always ignored.
+	// Note: The annotation can also be set on a MachineDeployment or MachineSet Machine template.
+	MachineBootstrapDataSecretMissingPolicyAnnotation = "machine.cluster.x-k8s.io/bootstrap-data-secret-missing-policy"
+
+	// MachineBootstrapDataSecretMissingPolicyReport is the default value of MachineBootstrapDataSecretMissingPolicyAnnotation.
+	MachineBootstrapDataSecretMissingPolicyReport = "Report"
+
+	// MachineBootstrapDataSecretMissingPolicyIgnore is the value of MachineBootstrapDataSecretMissingPolicyAnnotation
+	// which disables checking the bootstrap data secret.
+	MachineBootstrapDataSecretMissingPolicyIgnore = "Ignore"
+
+	// ClusterSerializeMachineSetMutationsAnnotation can be set on a Cluster to make the MachineSet controller create
+	// and delete the Machines of the Cluster, including their BootstrapConfig and InfraMachine, one MachineSet at a time
+	// instead of concurrently; this is useful to smooth out bursts of requests to providers with strict API rate limits.
+	// Note: This slows down scale up and scale down of Clusters with many MachineSets.
+	ClusterSerializeMachineSetMutationsAnnotation = "cluster.x-k8s.io/serialize-machineset-mutations"
+
+	// ClusterPropagateLabelsToSecretsAnnotation can be set on a Cluster to provide a comma-separated list of label keys
+	// which the Cluster controller copies from the Cluster to the kubeconfig and certificate Secrets of the Cluster,
+	// so label selectors, e.g. used by backup tools, match all the artifacts of the Cluster.
+	// Note: Labels removed from the Cluster or from the list are not removed from the Secrets, and Secrets provided by
+	// the user, i.e. without owner references, are not changed.
+	ClusterPropagateLabelsToSecretsAnnotation = "cluster.x-k8s.io/propagate-labels-to-secrets"
+
 	// MachineSetSkipPreflightChecksAnnotation is the annotation used to provide a comma-separated list of
 	// preflight checks that should be skipped during the MachineSet reconciliation.
 	// Supported items are:
@@ -143,19 +183,35 @@ const (
 	// the MachineSet.
 	MachineSetSkipPreflightChecksAnnotation = "machineset.cluster.x-k8s.io/skip-preflight-checks"
 
+	// MachineSetMachineNamePoolAnnotation can be set on a MachineSet to make it name new Machines using names from a
+	// user-provided pool instead of generating them; this is useful e.g. for bare metal, where hostnames are mapped to
+	// physical hosts and DNS records ahead of time.
+	// The value is the name of a ConfigMap in the MachineSet namespace; the MachineSetMachineNamePoolConfigMapKey key of
+	// the ConfigMap must contain the list of names, one per line. Names used by existing Machines in the namespace are
+	// skipped; if there are not enough free names, the MachineSet creates as many Machines as possible.
+	// Note: The annotation can also be set on a MachineDeployment as MachineDeployment annotations are synced to
+	// the MachineSet.
+	MachineSetMachineNamePoolAnnotation = "machineset.cluster.x-k8s.io/machine-name-pool"
+
+	// MachineSetMachineNamePoolConfigMapKey is the key of the ConfigMap referenced by MachineSetMachineNamePoolAnnotation
+	// which contains the list of names.
+	MachineSetMachineNamePoolConfigMapKey = "names"
+
 	// ClusterSecretType defines the type of secret created by core components.
 	// Note: This is used by core CAPI, CAPBK, and KCP to determine whether a secret is created by the controllers
 	// themselves or supplied by the user (e.g. bring your own certificates).
 	ClusterSecretType corev1.SecretType = "cluster.x-k8s.io/secret" //nolint:gosec
 
 	// InterruptibleLabel is the label used to mark the nodes that run on interruptible instances.
+	// The Machine controller sets it on the Node and on the Machine when the InfraMachine reports status.interruptible: true,
+	// so e.g. MachineHealthChecks can select Machines running on interruptible instances.
 	InterruptibleLabel = "cluster.x-k8s.io/interruptible"
 
-	// ManagedByAnnotation is an annotation that can be applied to InfraCluster resources to signify that
-	// some external system is managing the cluster infrastructure.
+	// ManagedByAnnotation is an annotation that can be applied to InfraCluster and InfraMachine resources to signify that
+	// some external system is managing the cluster or machine infrastructure.
 	//
-	// Provider InfraCluster controllers will ignore resources with this annotation.
-	// An external controller must fulfill the contract of the InfraCluster resource.
+	// Provider InfraCluster and InfraMachine controllers will ignore resources with this annotation.
+	// An external controller must fulfill the contract of the InfraCluster or InfraMachine resource.
 	// External infrastructure providers should ensure that the annotation, once set, cannot be removed.
 	ManagedByAnnotation = "cluster.x-k8s.io/managed-by"
 
@@ -194,6 +250,38 @@ const (
 	// Note: It can be used by setting as top level annotation on MachineDeployment and MachineSets.
 	AutoscalerMaxSizeAnnotation = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size"
 
+	// AutoscalerCPUCapacityAnnotation defines the CPU capacity of the Nodes of a node group, e.g. "4".
+	// The capacity annotations are used by the autoscaler to scale a node group from zero, when there are no Nodes
+	// to read the capacity from. They are not required if the InfrastructureMachineTemplate surfaces the capacity
+	// in status.capacity.
+	// The annotation definitions are copied from kubernetes/autoscaler.
+	// Ref:https://github.com/kubernetes/autoscaler/blob/master/cluster-autoscaler/cloudprovider/clusterapi/README.md#scale-from-zero-support
+	// Note: They can be used by setting as top level annotation on MachineDeployment and MachineSets.
+	AutoscalerCPUCapacityAnnotation = "capacity.cluster-autoscaler.kubernetes.io/cpu"
+
+	// AutoscalerMemoryCapacityAnnotation defines the memory capacity of the Nodes of a node group, e.g. "16G".
+	AutoscalerMemoryCapacityAnnotation = "capacity.cluster-autoscaler.kubernetes.io/memory"
+
+	// AutoscalerEphemeralDiskCapacityAnnotation defines the ephemeral storage capacity of the Nodes of a node group, e.g. "100Gi".
+	AutoscalerEphemeralDiskCapacityAnnotation = "capacity.cluster-autoscaler.kubernetes.io/ephemeral-disk"
+
+	// AutoscalerMaxPodsCapacityAnnotation defines the maximum number of Pods on the Nodes of a node group, e.g. "110".
+	AutoscalerMaxPodsCapacityAnnotation = "capacity.cluster-autoscaler.kubernetes.io/maxPods"
+
+	// AutoscalerGPUTypeCapacityAnnotation defines the resource name of the GPUs of the Nodes of a node group, e.g. "nvidia.com/gpu".
+	AutoscalerGPUTypeCapacityAnnotation = "capacity.cluster-autoscaler.kubernetes.io/gpu-type"
+
+	// AutoscalerGPUCountCapacityAnnotation defines the number of GPUs of the Nodes of a node group, e.g. "2".
+	AutoscalerGPUCountCapacityAnnotation = "capacity.cluster-autoscaler.kubernetes.io/gpu-count"
+
+	// AutoscalerLabelsCapacityAnnotation defines the labels of the Nodes of a node group as a comma-separated
+	// list of key=value pairs, e.g. "key1=value1,key2=value2".
+	AutoscalerLabelsCapacityAnnotation = "capacity.cluster-autoscaler.kubernetes.io/labels"
+
+	// AutoscalerTaintsCapacityAnnotation defines the taints of the Nodes of a node group as a comma-separated
+	// list of key=value:Effect or key:Effect entries, e.g. "key1=value1:NoSchedule,key2=value2:NoExecute".
+	AutoscalerTaintsCapacityAnnotation = "capacity.cluster-autoscaler.kubernetes.io/taints"
+
 	// VariableDefinitionFromInline indicates a patch or variable was defined in the `.spec` of a ClusterClass
 	// rather than from an external patch extension.
 	VariableDefinitionFromInline = "inline"
@@ -219,11 +307,20 @@ const (
 
 	// MachineSetPreflightCheckKubernetesVersionSkew is the name of the preflight check that verifies
 	// if the machines being created or remediated for the MachineSet conform to the Kubernetes version skew policy
-	// that requires the machines to be at a version that is not more than 2 minor lower than the ControlPlane version.
+	// that requires the machines to be at a version that is not more than 3 minor lower than the ControlPlane version
+	// (2 minor lower if the ControlPlane version is lower than v1.28).
 	// The preflight check is only run if a ControlPlane is used (controlPlaneRef must exist in the Cluster),
 	// the ControlPlane has a version and the MachineSet has a version.
 	MachineSetPreflightCheckKubernetesVersionSkew MachineSetPreflightCheck = "KubernetesVersionSkew"
 
+	// MachineSetPreflightCheckKubernetesVersionDowngrade is the name of the preflight check that verifies
+	// if the machines being created or remediated for the MachineSet are not at a lower minor version than the machines
+	// of other MachineSets of the same MachineDeployment, e.g. because the MachineDeployment was accidentally
+	// rolled back to an older Kubernetes version.
+	// The preflight check is only run if a ControlPlane is used (controlPlaneRef must exist in the Cluster),
+	// the ControlPlane has a version, the MachineSet has a version and the MachineSet belongs to a MachineDeployment.
+	MachineSetPreflightCheckKubernetesVersionDowngrade MachineSetPreflightCheck = "KubernetesVersionDowngrade"
+
 	// MachineSetPreflightCheckControlPlaneIsStable is the name of the preflight check
 	// that verifies if the control plane is not provisioning and not upgrading.
 	// The preflight check is only run if a ControlPlane is used (controlPlaneRef must exist in the Cluster)
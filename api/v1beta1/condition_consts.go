@@ -55,6 +55,16 @@ const (
 
 // ANCHOR_END: CommonConditions
 
+const (
+	// ReconcileStuckCondition is set to True on an object for which reconcile failed too many times in a row;
+	// reconciliation of the object is then slowed down until the next successful reconcile, which removes the condition.
+	ReconcileStuckCondition ConditionType = "ReconcileStuck"
+
+	// ReconcileFailureThresholdExceededReason (Severity=Warning) documents an object for which the number of consecutive
+	// reconcile errors or panics exceeded the configured threshold.
+	ReconcileFailureThresholdExceededReason = "ReconcileFailureThresholdExceeded"
+)
+
 // Conditions and condition Reasons for the ClusterClass object.
 const (
 	// ClusterClassVariablesReconciledCondition reports if the ClusterClass variables, including both inline and external
@@ -118,6 +128,10 @@ const (
 	// NOTE: This reason is used only as a fallback when the bootstrap object is not reporting its own ready condition.
 	WaitingForDataSecretFallbackReason = "WaitingForDataSecret"
 
+	// BootstrapDataSecretNotFoundReason (Severity=Warning) documents a machine for which the bootstrap data secret
+	// has been deleted before the infrastructure provider consumed it.
+	BootstrapDataSecretNotFoundReason = "BootstrapDataSecretNotFound"
+
 	// DrainingSucceededCondition provide evidence of the status of the node drain operation which happens during the machine
 	// deletion process.
 	DrainingSucceededCondition ConditionType = "DrainingSucceeded"
@@ -205,6 +219,10 @@ const (
 	// NB. provisioning --> NodeRef == "".
 	NodeProvisioningReason = "NodeProvisioning"
 
+	// NodeAlreadyAssociatedReason (Severity=Warning) documents the node with a matching ProviderID is associated
+	// to another machine which still exists.
+	NodeAlreadyAssociatedReason = "NodeAlreadyAssociated"
+
 	// NodeNotFoundReason (Severity=Error) documents a machine's node has previously been observed but is now gone.
 	// NB. provisioned --> NodeRef != "".
 	NodeNotFoundReason = "NodeNotFound"
@@ -276,6 +294,14 @@ const (
 	// generate a machine object.
 	MachineCreationFailedReason = "MachineCreationFailed"
 
+	// MachineNamePoolUnavailableReason (Severity=Error) documents a MachineSet failing to read the
+	// Machine name pool configured with the MachineSetMachineNamePoolAnnotation.
+	MachineNamePoolUnavailableReason = "MachineNamePoolUnavailable"
+
+	// MachineNamePoolExhaustedReason (Severity=Warning) documents a MachineSet not having enough free
+	// names in its Machine name pool to create all the required machines.
+	MachineNamePoolExhaustedReason = "MachineNamePoolExhausted"
+
 	// ResizedCondition documents a MachineSet is resizing the set of controlled machines.
 	ResizedCondition ConditionType = "Resized"
 
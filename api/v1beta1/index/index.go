@@ -35,6 +35,10 @@ func AddDefaultIndexes(ctx context.Context, mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := ByMachineClusterName(ctx, mgr); err != nil {
+		return err
+	}
+
 	if feature.Gates.Enabled(feature.ClusterTopology) {
 		if err := ByClusterClassName(ctx, mgr); err != nil {
 			return err
@@ -35,6 +35,10 @@ const (
 	// MachineProviderIDField is used to index Machines by ProviderID. It's useful to find Machines
 	// in a management cluster from Nodes in a workload cluster.
 	MachineProviderIDField = "spec.providerID"
+
+	// MachineClusterNameField is used to index Machines by the name of the Cluster they belong to. It's useful to
+	// narrow down lookups by Node name or ProviderID to the Machines of a single Cluster.
+	MachineClusterNameField = "spec.clusterName"
 )
 
 // ByMachineNode adds the machine node name index to the
@@ -89,3 +93,28 @@ func machineByProviderID(o client.Object) []string {
 
 	return []string{providerID}
 }
+
+// ByMachineClusterName adds the machine cluster name index to the
+// managers cache.
+func ByMachineClusterName(ctx context.Context, mgr ctrl.Manager) error {
+	if err := mgr.GetCache().IndexField(ctx, &clusterv1.Machine{},
+		MachineClusterNameField,
+		MachineByClusterName,
+	); err != nil {
+		return errors.Wrap(err, "error setting index field")
+	}
+
+	return nil
+}
+
+// MachineByClusterName contains the logic to index Machines by Cluster name.
+func MachineByClusterName(o client.Object) []string {
+	machine, ok := o.(*clusterv1.Machine)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Machine but got a %T", o))
+	}
+	if machine.Spec.ClusterName == "" {
+		return nil
+	}
+	return []string{machine.Spec.ClusterName}
+}
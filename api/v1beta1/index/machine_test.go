@@ -101,3 +101,34 @@ func TestIndexMachineByProviderID(t *testing.T) {
 		})
 	}
 }
+
+func TestIndexMachineByClusterName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		object   client.Object
+		expected []string
+	}{
+		{
+			name:     "Machine has no clusterName",
+			object:   &clusterv1.Machine{},
+			expected: nil,
+		},
+		{
+			name: "Machine has a clusterName",
+			object: &clusterv1.Machine{
+				Spec: clusterv1.MachineSpec{
+					ClusterName: "cluster1",
+				},
+			},
+			expected: []string{"cluster1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got := MachineByClusterName(tc.object)
+			g.Expect(got).To(BeEquivalentTo(tc.expected))
+		})
+	}
+}
@@ -33,6 +33,18 @@ const (
 	// ExcludeNodeDrainingAnnotation annotation explicitly skips node draining if set.
 	ExcludeNodeDrainingAnnotation = "machine.cluster.x-k8s.io/exclude-node-draining"
 
+	// CordonNodeAnnotation annotation requests the Node of the Machine to be cordoned, without deleting the Machine.
+	// The Node is uncordoned once the annotation is removed.
+	// Setting this annotation in spec.template.metadata.annotations of a MachineDeployment or MachineSet cordons all
+	// its Nodes, because template annotations are propagated in-place to the Machines.
+	CordonNodeAnnotation = "machine.cluster.x-k8s.io/cordon-node"
+
+	// WaitForNodeDeregistrationAnnotation annotation, whose value is a duration like "30s", requests the Node of the
+	// Machine to be excluded from external load balancers when starting the drain, and the eviction of Pods to be
+	// delayed by the given duration, so that load balancer health checks can fail over before Pods are evicted.
+	// Setting this annotation in spec.template.metadata.annotations of a MachineDeployment or MachineSet applies it to all its Machines.
+	WaitForNodeDeregistrationAnnotation = "machine.cluster.x-k8s.io/wait-for-node-deregistration"
+
 	// ExcludeWaitForNodeVolumeDetachAnnotation annotation explicitly skips the waiting for node volume detaching if set.
 	ExcludeWaitForNodeVolumeDetachAnnotation = "machine.cluster.x-k8s.io/exclude-wait-for-node-volume-detach"
 
@@ -80,6 +92,8 @@ const (
 
 	// NodeRoleLabelPrefix is one of the CAPI managed Node label prefixes.
 	NodeRoleLabelPrefix = "node-role.kubernetes.io"
+	// NodeRoleControlPlaneLabel is the standard role label the Machine controller sets on the Nodes of control plane Machines.
+	NodeRoleControlPlaneLabel = NodeRoleLabelPrefix + "/control-plane"
 	// NodeRestrictionLabelDomain is one of the CAPI managed Node label domains.
 	NodeRestrictionLabelDomain = "node-restriction.kubernetes.io"
 	// ManagedNodeLabelDomain is one of the CAPI managed Node label domains.
@@ -152,6 +166,10 @@ const (
 	// from a BoostrapConfig object referenced from the machine).
 	MachineBootstrapDataSecretProvidedV1Beta2Reason = "DataSecretProvided"
 
+	// MachineBootstrapDataSecretNotFoundV1Beta2Reason surfaces when the bootstrap data secret provided by the user
+	// does not exist (yet) or has been deleted before the infrastructure was provisioned.
+	MachineBootstrapDataSecretNotFoundV1Beta2Reason = "DataSecretNotFound"
+
 	// MachineBootstrapConfigInvalidConditionReportedV1Beta2Reason surfaces a BootstrapConfig Ready condition (read from a bootstrap config object) which is invalid.
 	// (e.g. its status is missing).
 	MachineBootstrapConfigInvalidConditionReportedV1Beta2Reason = InvalidConditionReportedV1Beta2Reason
@@ -272,6 +290,43 @@ const (
 	MachineHealthCheckHasRemediateAnnotationV1Beta2Reason = "HasRemediateAnnotation"
 )
 
+// Machine's NodeClockSkewed condition and corresponding reasons that will be used in v1Beta2 API version.
+// Note: NodeClockSkewed condition is set by the MachineHealthCheck controller.
+const (
+	// MachineNodeClockSkewedV1Beta2Condition is true if a MachineHealthCheck detected that the clock of the node hosted
+	// on the machine is ahead of or behind the clock of the management cluster by more than 1m; in this case the timeouts
+	// of unhealthy node conditions are adjusted accordingly. The condition is set back to false once the skew is
+	// below 30s.
+	MachineNodeClockSkewedV1Beta2Condition = "NodeClockSkewed"
+
+	// MachineNodeClockSkewedV1Beta2Reason surfaces when the clock of the node hosted on the machine is ahead of
+	// or behind the clock of the management cluster.
+	MachineNodeClockSkewedV1Beta2Reason = "NodeClockSkewed"
+
+	// MachineNodeNotClockSkewedV1Beta2Reason surfaces when the clock of the node hosted on the machine is in sync
+	// with the clock of the management cluster.
+	MachineNodeNotClockSkewedV1Beta2Reason = "NodeNotClockSkewed"
+)
+
+// Machine's VersionDrift condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// MachineVersionDriftV1Beta2Condition is true if the kubelet version reported by the Node hosted on the machine
+	// differs from the declared spec.version; only major, minor and patch are compared.
+	MachineVersionDriftV1Beta2Condition = "VersionDrift"
+
+	// MachineVersionDriftV1Beta2Reason surfaces when the Node hosted on the machine is running a kubelet version
+	// different from the declared spec.version.
+	MachineVersionDriftV1Beta2Reason = "VersionDrift"
+
+	// MachineNoVersionDriftV1Beta2Reason surfaces when the Node hosted on the machine is running the declared
+	// kubelet version, or when the machine does not declare a version.
+	MachineNoVersionDriftV1Beta2Reason = "NoVersionDrift"
+
+	// MachineVersionDriftNodeVersionUnknownV1Beta2Reason surfaces when the kubelet version of the Node hosted on the
+	// machine is not known yet.
+	MachineVersionDriftNodeVersionUnknownV1Beta2Reason = "NodeVersionUnknown"
+)
+
 // Machine's OwnerRemediated conditions and corresponding reasons that will be used in v1Beta2 API version.
 // Note: OwnerRemediated condition is initially set by the MachineHealthCheck controller; then it is up to the Machine's
 // owner controller to update or delete this condition.
@@ -442,6 +497,16 @@ type MachineSpec struct {
 	// Defaults to 10 seconds.
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
+
+	// taints are the taints to be set on the Node hosted by the Machine.
+	// Taints are continuously reconciled: changes made directly on the Node are reverted, and taints removed
+	// from this list are removed from the Node. All other taints of the Node are preserved.
+	// NOTE: This field is in-place mutable, changing it does not trigger a rollout of the Machines of a MachineDeployment.
+	// Taints are not supported in the template of MachinePools.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=64
+	Taints []corev1.Taint `json:"taints,omitempty"`
 }
 
 // MachineReadinessGate contains the type of a Machine condition to be used as a readiness gate.
@@ -555,6 +620,16 @@ type MachineStatus struct {
 	// +optional
 	Deletion *MachineDeletionStatus `json:"deletion,omitempty"`
 
+	// timeline contains the times when the Machine reached the milestones of its provisioning.
+	// +optional
+	Timeline *MachineTimeline `json:"timeline,omitempty"`
+
+	// image is the identifier of the image (or of the operating system version) the Machine is running, e.g. an AMI ID,
+	// as reported by the infrastructure provider in the InfrastructureMachine's status.image field.
+	// +optional
+	// +kubebuilder:validation:MaxLength=512
+	Image string `json:"image,omitempty"`
+
 	// v1beta2 groups all the fields that will be added or modified in Machine's status with the V1Beta2 version.
 	// +optional
 	V1Beta2 *MachineV1Beta2Status `json:"v1beta2,omitempty"`
@@ -595,6 +670,23 @@ type MachineDeletionStatus struct {
 	WaitForNodeVolumeDetachStartTime *metav1.Time `json:"waitForNodeVolumeDetachStartTime,omitempty"`
 }
 
+// MachineTimeline contains the times when the Machine reached the milestones of its provisioning.
+// NOTE: The time when the deletion of the Machine started is recorded in metadata.deletionTimestamp, and the time
+// when the drain of the node started in status.deletion.nodeDrainStartTime.
+type MachineTimeline struct {
+	// bootstrapDataGeneratedAt is the time when the bootstrap data secret of the Machine was first reported as ready.
+	// +optional
+	BootstrapDataGeneratedAt *metav1.Time `json:"bootstrapDataGeneratedAt,omitempty"`
+
+	// infrastructureProvisionedAt is the time when the infrastructure of the Machine was first reported as ready.
+	// +optional
+	InfrastructureProvisionedAt *metav1.Time `json:"infrastructureProvisionedAt,omitempty"`
+
+	// nodeJoinedAt is the time when the Node of the Machine was first found in the workload cluster.
+	// +optional
+	NodeJoinedAt *metav1.Time `json:"nodeJoinedAt,omitempty"`
+}
+
 // SetTypedPhase sets the Phase field to the string representation of MachinePhase.
 func (m *MachineStatus) SetTypedPhase(p MachinePhase) {
 	m.Phase = string(p)
@@ -643,6 +735,7 @@ type Bootstrap struct {
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster"
 // +kubebuilder:printcolumn:name="NodeName",type="string",JSONPath=".status.nodeRef.name",description="Node name associated with this machine"
+// +kubebuilder:printcolumn:name="ExternalIP",type="string",JSONPath=".status.addresses[?(@.type==\"ExternalIP\")].address",description="External IP addresses of this machine"
 // +kubebuilder:printcolumn:name="ProviderID",type="string",JSONPath=".spec.providerID",description="Provider ID"
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Machine status such as Terminating/Pending/Running/Failed etc"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of Machine"
@@ -148,6 +148,78 @@ const (
 	MachineDeploymentMachinesUpToDateInternalErrorV1Beta2Reason = InternalErrorV1Beta2Reason
 )
 
+// MachineDeployment's NodeVersionsUpToDate condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// MachineDeploymentNodeVersionsUpToDateV1Beta2Condition surfaces details of controlled machines whose Node is running
+	// a kubelet version different from the one declared in the Machine's spec.version, if any; this usually happens
+	// when the image used by the infrastructure provider does not match the declared version.
+	MachineDeploymentNodeVersionsUpToDateV1Beta2Condition = "NodeVersionsUpToDate"
+
+	// MachineDeploymentNodeVersionsUpToDateV1Beta2Reason surfaces when the Nodes of all the controlled machines are running
+	// the declared kubelet version.
+	MachineDeploymentNodeVersionsUpToDateV1Beta2Reason = "NodeVersionsUpToDate"
+
+	// MachineDeploymentNodeVersionsNotUpToDateV1Beta2Reason surfaces when the Node of at least one of the controlled machines
+	// is running a kubelet version different from the declared one.
+	MachineDeploymentNodeVersionsNotUpToDateV1Beta2Reason = "NodeVersionsNotUpToDate"
+
+	// MachineDeploymentNodeVersionsUpToDateNoReplicasV1Beta2Reason surfaces when no machines exist for the MachineDeployment.
+	MachineDeploymentNodeVersionsUpToDateNoReplicasV1Beta2Reason = NoReplicasV1Beta2Reason
+
+	// MachineDeploymentNodeVersionsUpToDateInternalErrorV1Beta2Reason surfaces unexpected failures when listing machines.
+	MachineDeploymentNodeVersionsUpToDateInternalErrorV1Beta2Reason = InternalErrorV1Beta2Reason
+)
+
+// MachineDeployment's ImagesUpToDate condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// MachineDeploymentImagesUpToDateV1Beta2Condition surfaces details of controlled machines running an image different
+	// from the one declared by the InfrastructureMachineTemplate in its status.image field, if any; usually a rollout,
+	// e.g. using spec.rolloutAfter, is required to replace those machines.
+	MachineDeploymentImagesUpToDateV1Beta2Condition = "ImagesUpToDate"
+
+	// MachineDeploymentImagesUpToDateV1Beta2Reason surfaces when all the controlled machines are running the image
+	// declared by the InfrastructureMachineTemplate.
+	MachineDeploymentImagesUpToDateV1Beta2Reason = "ImagesUpToDate"
+
+	// MachineDeploymentImagesNotUpToDateV1Beta2Reason surfaces when at least one of the controlled machines is running
+	// an image different from the one declared by the InfrastructureMachineTemplate.
+	MachineDeploymentImagesNotUpToDateV1Beta2Reason = "ImagesNotUpToDate"
+
+	// MachineDeploymentImagesUpToDateNotReportedV1Beta2Reason surfaces when the InfrastructureMachineTemplate does not
+	// report the image in its status.image field, and thus it is not possible to detect machines running a different image.
+	MachineDeploymentImagesUpToDateNotReportedV1Beta2Reason = "ImageNotReported"
+
+	// MachineDeploymentImagesUpToDateNoReplicasV1Beta2Reason surfaces when no machines exist for the MachineDeployment.
+	MachineDeploymentImagesUpToDateNoReplicasV1Beta2Reason = NoReplicasV1Beta2Reason
+
+	// MachineDeploymentImagesUpToDateInternalErrorV1Beta2Reason surfaces unexpected failures when listing machines.
+	MachineDeploymentImagesUpToDateInternalErrorV1Beta2Reason = InternalErrorV1Beta2Reason
+)
+
+// MachineDeployment's RollingOut condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// MachineDeploymentRollingOutV1Beta2Condition is true if there are replicas on MachineSets not matching the
+	// MachineDeployment's spec.template, i.e. a rollout is in progress.
+	// Note: This condition takes the role of the Progressing condition of Deployments; a stuck rollout is surfaced
+	// with the ProgressDeadlineExceeded reason.
+	MachineDeploymentRollingOutV1Beta2Condition = "RollingOut"
+
+	// MachineDeploymentRollingOutV1Beta2Reason surfaces when there are replicas on MachineSets not matching the
+	// MachineDeployment's spec.template.
+	MachineDeploymentRollingOutV1Beta2Reason = "RollingOut"
+
+	// MachineDeploymentRollingOutProgressDeadlineExceededV1Beta2Reason surfaces when a rollout is in progress but
+	// it did not make progress for more than spec.progressDeadlineSeconds.
+	MachineDeploymentRollingOutProgressDeadlineExceededV1Beta2Reason = "ProgressDeadlineExceeded"
+
+	// MachineDeploymentNotRollingOutV1Beta2Reason surfaces when all the replicas are on MachineSets matching the
+	// MachineDeployment's spec.template.
+	MachineDeploymentNotRollingOutV1Beta2Reason = "NotRollingOut"
+
+	// MachineDeploymentRollingOutInternalErrorV1Beta2Reason surfaces unexpected failures when listing machine sets.
+	MachineDeploymentRollingOutInternalErrorV1Beta2Reason = InternalErrorV1Beta2Reason
+)
+
 // MachineDeployment's ScalingUp condition and corresponding reasons that will be used in v1Beta2 API version.
 const (
 	// MachineDeploymentScalingUpV1Beta2Condition is true if actual replicas < desired replicas.
@@ -291,8 +363,10 @@ type MachineDeploymentSpec struct {
 	// The maximum time in seconds for a deployment to make progress before it
 	// is considered to be failed. The deployment controller will continue to
 	// process failed deployments and a condition with a ProgressDeadlineExceeded
-	// reason will be surfaced in the deployment status. Note that progress will
-	// not be estimated during the time a deployment is paused. Defaults to 600s.
+	// reason will be surfaced in the deployment status. Progress is the start of
+	// a rollout, or the creation, deletion or availability of one of its Machines.
+	// Note that progress will not be estimated during the time a deployment is paused.
+	// Defaults to 600s.
 	// +optional
 	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
 }
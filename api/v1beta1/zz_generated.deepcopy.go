@@ -2319,6 +2319,13 @@ func (in *MachineSpec) DeepCopyInto(out *MachineSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineSpec.
@@ -2379,6 +2386,11 @@ func (in *MachineStatus) DeepCopyInto(out *MachineStatus) {
 		*out = new(MachineDeletionStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Timeline != nil {
+		in, out := &in.Timeline, &out.Timeline
+		*out = new(MachineTimeline)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.V1Beta2 != nil {
 		in, out := &in.V1Beta2, &out.V1Beta2
 		*out = new(MachineV1Beta2Status)
@@ -2413,6 +2425,33 @@ func (in *MachineTemplateSpec) DeepCopy() *MachineTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineTimeline) DeepCopyInto(out *MachineTimeline) {
+	*out = *in
+	if in.BootstrapDataGeneratedAt != nil {
+		in, out := &in.BootstrapDataGeneratedAt, &out.BootstrapDataGeneratedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.InfrastructureProvisionedAt != nil {
+		in, out := &in.InfrastructureProvisionedAt, &out.InfrastructureProvisionedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.NodeJoinedAt != nil {
+		in, out := &in.NodeJoinedAt, &out.NodeJoinedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineTimeline.
+func (in *MachineTimeline) DeepCopy() *MachineTimeline {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineTimeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineV1Beta2Status) DeepCopyInto(out *MachineV1Beta2Status) {
 	*out = *in
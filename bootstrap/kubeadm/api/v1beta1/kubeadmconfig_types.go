@@ -38,6 +38,40 @@ const (
 	Ignition Format = "ignition"
 )
 
+const (
+	// ProxyConfigAnnotation can be set on a Cluster to provide the name of a ConfigMap in the Cluster namespace with
+	// the proxy configuration and the additional trusted CA bundle which the kubeadm bootstrap provider injects in the
+	// bootstrap data of the Machines of the Cluster; this is useful for Clusters running behind a proxy or an
+	// intercepting TLS gateway.
+	// The proxy configuration is read from the ProxyConfigHTTPProxyKey, ProxyConfigHTTPSProxyKey and ProxyConfigNoProxyKey
+	// keys of the ConfigMap and set in the environment of containerd and kubelet; the PEM encoded CA bundle is read from
+	// the ProxyConfigTrustedCABundleKey key and added to the trust store of the operating system. All keys are optional.
+	// Note: Changes to the ConfigMap only apply to the bootstrap data generated afterwards, i.e. to new Machines.
+	ProxyConfigAnnotation = "bootstrap.cluster.x-k8s.io/proxy-config"
+
+	// ProxyConfigHTTPProxyKey is the key of the ConfigMap referenced by ProxyConfigAnnotation which contains the
+	// value of HTTP_PROXY.
+	ProxyConfigHTTPProxyKey = "httpProxy"
+
+	// ProxyConfigHTTPSProxyKey is the key of the ConfigMap referenced by ProxyConfigAnnotation which contains the
+	// value of HTTPS_PROXY.
+	ProxyConfigHTTPSProxyKey = "httpsProxy"
+
+	// ProxyConfigNoProxyKey is the key of the ConfigMap referenced by ProxyConfigAnnotation which contains the
+	// value of NO_PROXY.
+	ProxyConfigNoProxyKey = "noProxy"
+
+	// ProxyConfigTrustedCABundleKey is the key of the ConfigMap referenced by ProxyConfigAnnotation which contains
+	// the PEM encoded additional trusted CA bundle.
+	ProxyConfigTrustedCABundleKey = "trustedCABundle"
+
+	// BootstrapTokenExpirationAnnotation is set by the kubeadm bootstrap provider on KubeadmConfigs whose owner has not
+	// joined the cluster yet to record the expiration of the bootstrap token in the bootstrap data. It is used to
+	// distinguish a token Secret deleted by the token cleaner after expiration, which is re-created with the same token,
+	// from a token Secret deleted on purpose to revoke the token, which triggers the creation of a new token.
+	BootstrapTokenExpirationAnnotation = "bootstrap.cluster.x-k8s.io/token-expiration"
+)
+
 var (
 	cannotUseWithIgnition                            = fmt.Sprintf("not supported when spec.format is set to: %q", Ignition)
 	conflictingFileSourceMsg                         = "only one of content or contentFrom may be specified for a single file"
@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/client-go/tools/record"
 	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
 	bootstrapsecretutil "k8s.io/cluster-bootstrap/util/secrets"
 	"k8s.io/klog/v2"
@@ -55,6 +56,7 @@ import (
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/util/taints"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	clog "sigs.k8s.io/cluster-api/util/log"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -91,6 +93,8 @@ type KubeadmConfigReconciler struct {
 
 	// TokenTTL is the amount of time a bootstrap token (and therefore a KubeadmConfig) will be valid.
 	TokenTTL time.Duration
+
+	recorder record.EventRecorder
 }
 
 // Scope is a scoped struct used during reconciliation.
@@ -113,6 +117,7 @@ func (r *KubeadmConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 	if r.TokenTTL == 0 {
 		r.TokenTTL = DefaultTokenTTL
 	}
+	r.recorder = mgr.GetEventRecorderFor("kubeadmconfig-controller")
 
 	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "kubeadmconfig")
 	b := ctrl.NewControllerManagedBy(mgr).
@@ -279,7 +284,7 @@ func (r *KubeadmConfigReconciler) reconcile(ctx context.Context, scope *Scope, c
 				// If the BootstrapToken has been generated for a join but the config owner has no nodeRefs,
 				// this indicates that the node has not yet joined and the token in the join config has not
 				// been consumed and it may need a refresh.
-				return r.refreshBootstrapTokenIfNeeded(ctx, config, cluster)
+				return r.refreshBootstrapTokenIfNeeded(ctx, config, cluster, scope)
 			}
 			if configOwner.IsMachinePool() {
 				// If the BootstrapToken has been generated and infrastructure is ready but the configOwner is a MachinePool,
@@ -317,7 +322,7 @@ func (r *KubeadmConfigReconciler) reconcile(ctx context.Context, scope *Scope, c
 	return r.joinWorker(ctx, scope)
 }
 
-func (r *KubeadmConfigReconciler) refreshBootstrapTokenIfNeeded(ctx context.Context, config *bootstrapv1.KubeadmConfig, cluster *clusterv1.Cluster) (ctrl.Result, error) {
+func (r *KubeadmConfigReconciler) refreshBootstrapTokenIfNeeded(ctx context.Context, config *bootstrapv1.KubeadmConfig, cluster *clusterv1.Cluster, scope *Scope) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	token := config.Spec.JoinConfiguration.Discovery.BootstrapToken.Token
 
@@ -328,6 +333,9 @@ func (r *KubeadmConfigReconciler) refreshBootstrapTokenIfNeeded(ctx context.Cont
 
 	secret, err := getToken(ctx, remoteClient, token)
 	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.recreateBootstrapToken(ctx, scope, remoteClient)
+		}
 		return ctrl.Result{}, errors.Wrapf(err, "failed to get bootstrap token secret in order to refresh it")
 	}
 	log = log.WithValues("Secret", klog.KObj(secret))
@@ -346,6 +354,7 @@ func (r *KubeadmConfigReconciler) refreshBootstrapTokenIfNeeded(ctx context.Cont
 		skipTokenRefreshIfExpiringAfter := now.Add(r.skipTokenRefreshIfExpiringAfter())
 		if expiration.After(skipTokenRefreshIfExpiringAfter) {
 			log.V(3).Info("Token needs no refresh", "tokenExpiresInSeconds", expiration.Sub(now).Seconds())
+			annotations.AddAnnotations(config, map[string]string{bootstrapv1.BootstrapTokenExpirationAnnotation: secretExpiration})
 			return ctrl.Result{
 				RequeueAfter: r.tokenCheckRefreshOrRotationInterval(),
 			}, nil
@@ -360,11 +369,54 @@ func (r *KubeadmConfigReconciler) refreshBootstrapTokenIfNeeded(ctx context.Cont
 	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to refresh bootstrap token")
 	}
+	annotations.AddAnnotations(config, map[string]string{bootstrapv1.BootstrapTokenExpirationAnnotation: newExpiration})
 	return ctrl.Result{
 		RequeueAfter: r.tokenCheckRefreshOrRotationInterval(),
 	}, nil
 }
 
+// recreateBootstrapToken handles the Secret of the bootstrap token being deleted before the config owner joined.
+// If the expiration of the token recorded in the BootstrapTokenExpirationAnnotation has passed, the Secret has been
+// deleted by the token cleaner while the infrastructure was still provisioning, so it is re-created with the same
+// token, which is already part of the bootstrap data. Otherwise the token might have been deleted on purpose to revoke it,
+// so a new token is created and the bootstrap data are generated again, as it is done when rotating the token of a MachinePool.
+func (r *KubeadmConfigReconciler) recreateBootstrapToken(ctx context.Context, scope *Scope, remoteClient client.Client) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	config := scope.Config
+	token := config.Spec.JoinConfiguration.Discovery.BootstrapToken.Token
+
+	if expiration, err := time.Parse(time.RFC3339, config.GetAnnotations()[bootstrapv1.BootstrapTokenExpirationAnnotation]); err == nil && expiration.Before(time.Now()) {
+		log.Info("Re-creating expired bootstrap token secret until the infrastructure has a chance to consume it")
+		newExpiration, err := createTokenSecret(ctx, remoteClient, token, r.TokenTTL)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to re-create bootstrap token secret")
+		}
+		annotations.AddAnnotations(config, map[string]string{bootstrapv1.BootstrapTokenExpirationAnnotation: newExpiration})
+		r.recorder.Event(config, corev1.EventTypeNormal, "BootstrapTokenRecreated", "Bootstrap token expired before the node joined the cluster, re-created it")
+		return ctrl.Result{
+			RequeueAfter: r.tokenCheckRefreshOrRotationInterval(),
+		}, nil
+	}
+
+	log.Info("Bootstrap token secret has been deleted before the token expired, creating a new bootstrap token")
+	token, err := createToken(ctx, remoteClient, r.TokenTTL)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to create new bootstrap token")
+	}
+
+	config.Spec.JoinConfiguration.Discovery.BootstrapToken.Token = token
+	log.V(3).Info("Altering JoinConfiguration.Discovery.BootstrapToken.Token")
+	// The expiration of the new token is recorded when it is checked for refresh next time.
+	delete(config.Annotations, bootstrapv1.BootstrapTokenExpirationAnnotation)
+	r.recorder.Event(config, corev1.EventTypeWarning, "BootstrapTokenRotated", "Bootstrap token has been deleted before the node joined the cluster, rotated it and generated the bootstrap data again")
+
+	// update the bootstrap data
+	if scope.ConfigOwner.IsControlPlaneMachine() {
+		return r.joinControlplane(ctx, scope)
+	}
+	return r.joinWorker(ctx, scope)
+}
+
 func (r *KubeadmConfigReconciler) rotateMachinePoolBootstrapToken(ctx context.Context, config *bootstrapv1.KubeadmConfig, cluster *clusterv1.Cluster, scope *Scope) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	log.V(2).Info("Config is owned by a MachinePool, checking if token should be rotated")
@@ -521,6 +573,13 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 		return ctrl.Result{}, err
 	}
 
+	proxyFiles, proxyCommands, err := r.resolveProxyConfig(ctx, scope.Cluster)
+	if err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+	files = append(proxyFiles, files...)
+
 	users, err := r.resolveUsers(ctx, scope.Config)
 	if err != nil {
 		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
@@ -531,7 +590,7 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 		BaseUserData: cloudinit.BaseUserData{
 			AdditionalFiles:     files,
 			NTP:                 scope.Config.Spec.NTP,
-			PreKubeadmCommands:  scope.Config.Spec.PreKubeadmCommands,
+			PreKubeadmCommands:  append(proxyCommands, scope.Config.Spec.PreKubeadmCommands...),
 			PostKubeadmCommands: scope.Config.Spec.PostKubeadmCommands,
 			Users:               users,
 			Mounts:              scope.Config.Spec.Mounts,
@@ -571,6 +630,13 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope) (ctrl.Result, error) {
 	scope.Info("Creating BootstrapData for the worker node")
 
+	// Fail fast if the JoinConfiguration is invalid for a worker, before looking up certificates or reconciling discovery.
+	if scope.Config.Spec.JoinConfiguration.ControlPlane != nil {
+		err := errors.New("Machine is a Worker, but JoinConfiguration.ControlPlane is set in the KubeadmConfig object")
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+
 	certificates := secret.NewCertificatesForWorker(scope.Config.Spec.JoinConfiguration.CACertPath)
 	err := certificates.LookupCached(
 		ctx,
@@ -586,6 +652,10 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 		conditions.MarkFalse(scope.Config, bootstrapv1.CertificatesAvailableCondition, bootstrapv1.CertificatesCorruptedReason, clusterv1.ConditionSeverityError, err.Error())
 		return ctrl.Result{}, err
 	}
+	if err := certificates.Validate(); err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.CertificatesAvailableCondition, bootstrapv1.CertificatesCorruptedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
 	conditions.MarkTrue(scope.Config, bootstrapv1.CertificatesAvailableCondition)
 
 	// Ensure that joinConfiguration.Discovery is properly set for joining node on the current cluster.
@@ -618,10 +688,6 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 		return ctrl.Result{}, err
 	}
 
-	if scope.Config.Spec.JoinConfiguration.ControlPlane != nil {
-		return ctrl.Result{}, errors.New("Machine is a Worker, but JoinConfiguration.ControlPlane is set in the KubeadmConfig object")
-	}
-
 	verbosityFlag := ""
 	if scope.Config.Spec.Verbosity != nil {
 		verbosityFlag = fmt.Sprintf("--v %s", strconv.Itoa(int(*scope.Config.Spec.Verbosity)))
@@ -633,6 +699,13 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 		return ctrl.Result{}, err
 	}
 
+	proxyFiles, proxyCommands, err := r.resolveProxyConfig(ctx, scope.Cluster)
+	if err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+	files = append(proxyFiles, files...)
+
 	users, err := r.resolveUsers(ctx, scope.Config)
 	if err != nil {
 		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
@@ -652,7 +725,7 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 		BaseUserData: cloudinit.BaseUserData{
 			AdditionalFiles:      files,
 			NTP:                  scope.Config.Spec.NTP,
-			PreKubeadmCommands:   scope.Config.Spec.PreKubeadmCommands,
+			PreKubeadmCommands:   append(proxyCommands, scope.Config.Spec.PreKubeadmCommands...),
 			PostKubeadmCommands:  scope.Config.Spec.PostKubeadmCommands,
 			Users:                users,
 			Mounts:               scope.Config.Spec.Mounts,
@@ -715,6 +788,10 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		conditions.MarkFalse(scope.Config, bootstrapv1.CertificatesAvailableCondition, bootstrapv1.CertificatesCorruptedReason, clusterv1.ConditionSeverityError, err.Error())
 		return ctrl.Result{}, err
 	}
+	if err := certificates.Validate(); err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.CertificatesAvailableCondition, bootstrapv1.CertificatesCorruptedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
 
 	conditions.MarkTrue(scope.Config, bootstrapv1.CertificatesAvailableCondition)
 
@@ -750,6 +827,13 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		return ctrl.Result{}, err
 	}
 
+	proxyFiles, proxyCommands, err := r.resolveProxyConfig(ctx, scope.Cluster)
+	if err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+	files = append(proxyFiles, files...)
+
 	users, err := r.resolveUsers(ctx, scope.Config)
 	if err != nil {
 		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
@@ -771,7 +855,7 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		BaseUserData: cloudinit.BaseUserData{
 			AdditionalFiles:      files,
 			NTP:                  scope.Config.Spec.NTP,
-			PreKubeadmCommands:   scope.Config.Spec.PreKubeadmCommands,
+			PreKubeadmCommands:   append(proxyCommands, scope.Config.Spec.PreKubeadmCommands...),
 			PostKubeadmCommands:  scope.Config.Spec.PostKubeadmCommands,
 			Users:                users,
 			Mounts:               scope.Config.Spec.Mounts,
@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -590,6 +591,43 @@ func TestKubeadmConfigReconciler_Reconcile_ErrorIfJoiningControlPlaneHasInvalidC
 	assertHasTrueCondition(g, myclient, request, bootstrapv1.CertificatesAvailableCondition)
 }
 
+func TestKubeadmConfigReconciler_Reconcile_ErrorIfJoiningWorkerHasControlPlaneConfiguration(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+	cluster.Status.InfrastructureReady = true
+	conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+	cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "100.105.150.1", Port: 6443}
+
+	workerMachine := newWorkerMachineForCluster(cluster)
+	workerJoinConfig := newControlPlaneJoinKubeadmConfig(workerMachine.Namespace, "worker-join-cfg") // Makes workerJoinConfig invalid for a worker machine
+	addKubeadmConfigToMachine(workerJoinConfig, workerMachine)
+
+	objects := []client.Object{
+		cluster,
+		workerMachine,
+		workerJoinConfig,
+	}
+	myclient := fake.NewClientBuilder().WithObjects(objects...).WithStatusSubresource(&bootstrapv1.KubeadmConfig{}).Build()
+
+	k := &KubeadmConfigReconciler{
+		Client:              myclient,
+		SecretCachingClient: myclient,
+		ClusterCache:        clustercache.NewFakeClusterCache(myclient, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}),
+		KubeadmInitLock:     &myInitLocker{},
+	}
+
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: metav1.NamespaceDefault,
+			Name:      workerJoinConfig.Name,
+		},
+	}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).To(MatchError(ContainSubstring("JoinConfiguration.ControlPlane is set")))
+	assertHasFalseCondition(g, myclient, request, bootstrapv1.DataSecretAvailableCondition, clusterv1.ConditionSeverityWarning, bootstrapv1.DataSecretGenerationFailedReason)
+}
+
 // If there is no APIEndpoint but everything is ready then requeue in hopes of a new APIEndpoint showing up eventually.
 func TestKubeadmConfigReconciler_Reconcile_RequeueIfControlPlaneIsMissingAPIEndpoints(t *testing.T) {
 	g := NewWithT(t)
@@ -1029,12 +1067,14 @@ func TestBootstrapTokenTTLExtension(t *testing.T) {
 	objects = append(objects, createSecrets(t, cluster, initConfig)...)
 	myclient := fake.NewClientBuilder().WithObjects(objects...).WithStatusSubresource(&bootstrapv1.KubeadmConfig{}, &clusterv1.Machine{}).Build()
 	remoteClient := fake.NewClientBuilder().Build()
+	recorder := record.NewFakeRecorder(10)
 	k := &KubeadmConfigReconciler{
 		Client:              myclient,
 		SecretCachingClient: myclient,
 		KubeadmInitLock:     &myInitLocker{},
 		TokenTTL:            DefaultTokenTTL,
 		ClusterCache:        clustercache.NewFakeClusterCache(remoteClient, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}),
+		recorder:            recorder,
 	}
 	request := ctrl.Request{
 		NamespacedName: client.ObjectKey{
@@ -1193,6 +1233,100 @@ func TestBootstrapTokenTTLExtension(t *testing.T) {
 		tokenExpires[i] = item.Data[bootstrapapi.BootstrapTokenExpirationKey]
 	}
 
+	t.Log("If the token secret has been deleted before it expired and before the Nodes joined, a new token should be created")
+
+	joinRequests := []ctrl.Request{
+		{
+			NamespacedName: client.ObjectKey{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "worker-join-cfg",
+			},
+		},
+		{
+			NamespacedName: client.ObjectKey{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "control-plane-join-cfg",
+			},
+		},
+	}
+
+	secretNames := []string{}
+	for i := range l.Items {
+		secretNames = append(secretNames, l.Items[i].Name)
+		g.Expect(remoteClient.Delete(ctx, &l.Items[i])).To(Succeed())
+	}
+
+	for _, req := range joinRequests {
+		result, err := k.Reconcile(ctx, req)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(k.TokenTTL / 3))
+
+		cfg, err := getKubeadmConfig(myclient, req.Name, req.Namespace)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(cfg.Annotations).ToNot(HaveKey(bootstrapv1.BootstrapTokenExpirationAnnotation))
+
+		// The bootstrap data should have been generated again with the new token.
+		dataSecret := &corev1.Secret{}
+		g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: *cfg.Status.DataSecretName}, dataSecret)).To(Succeed())
+		g.Expect(string(dataSecret.Data["value"])).To(ContainSubstring(cfg.Spec.JoinConfiguration.Discovery.BootstrapToken.Token))
+	}
+	g.Expect(recorder.Events).To(HaveLen(2))
+	for range 2 {
+		g.Expect(<-recorder.Events).To(ContainSubstring("BootstrapTokenRotated"))
+	}
+
+	l = &corev1.SecretList{}
+	g.Expect(remoteClient.List(ctx, l, client.ListOption(client.InNamespace(metav1.NamespaceSystem)))).To(Succeed())
+	g.Expect(l.Items).To(HaveLen(2))
+
+	for _, item := range l.Items {
+		// The secrets should have been created for new tokens
+		g.Expect(secretNames).ToNot(ContainElement(item.Name))
+	}
+
+	// Reconcile once more, so the expiration of the new tokens gets recorded.
+	for _, req := range joinRequests {
+		_, err := k.Reconcile(ctx, req)
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+	g.Expect(recorder.Events).To(BeEmpty())
+
+	t.Log("If the token secret has been deleted after it expired and before the Nodes joined, it should be re-created")
+
+	secretNames = []string{}
+	for i := range l.Items {
+		secretNames = append(secretNames, l.Items[i].Name)
+		g.Expect(remoteClient.Delete(ctx, &l.Items[i])).To(Succeed())
+	}
+
+	for _, req := range joinRequests {
+		// Simulate that the recorded expiration of the token has passed.
+		cfg, err := getKubeadmConfig(myclient, req.Name, req.Namespace)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(cfg.Annotations).To(HaveKey(bootstrapv1.BootstrapTokenExpirationAnnotation))
+		patchHelper, err := patch.NewHelper(cfg, myclient)
+		g.Expect(err).ToNot(HaveOccurred())
+		cfg.Annotations[bootstrapv1.BootstrapTokenExpirationAnnotation] = time.Now().UTC().Add(-time.Minute).Format(time.RFC3339)
+		g.Expect(patchHelper.Patch(ctx, cfg)).To(Succeed())
+
+		result, err := k.Reconcile(ctx, req)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(k.TokenTTL / 3))
+	}
+	g.Expect(recorder.Events).To(HaveLen(2))
+	for range 2 {
+		g.Expect(<-recorder.Events).To(ContainSubstring("BootstrapTokenRecreated"))
+	}
+
+	l = &corev1.SecretList{}
+	g.Expect(remoteClient.List(ctx, l, client.ListOption(client.InNamespace(metav1.NamespaceSystem)))).To(Succeed())
+	g.Expect(l.Items).To(HaveLen(2))
+
+	for _, item := range l.Items {
+		// The secrets should have been re-created for the same tokens
+		g.Expect(secretNames).To(ContainElement(item.Name))
+	}
+
 	t.Log("When the Nodes have actually joined the cluster and we get a nodeRef, no more refresh should happen")
 
 	for i, item := range l.Items {
@@ -2189,6 +2323,89 @@ func TestKubeadmConfigReconciler_ResolveFiles(t *testing.T) {
 	}
 }
 
+func TestKubeadmConfigReconciler_ResolveProxyConfig(t *testing.T) {
+	proxyConfigMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "proxy",
+				Namespace: metav1.NamespaceDefault,
+			},
+			Data: data,
+		}
+	}
+	proxyDropIn := "[Service]\nEnvironment=\"HTTP_PROXY=http://proxy:3128\"\nEnvironment=\"NO_PROXY=10.0.0.0/8\"\n"
+
+	cases := map[string]struct {
+		annotations    map[string]string
+		objects        []client.Object
+		expectErr      bool
+		expectFiles    []bootstrapv1.File
+		expectCommands []string
+	}{
+		"no annotation": {},
+		"missing ConfigMap": {
+			annotations: map[string]string{bootstrapv1.ProxyConfigAnnotation: "proxy"},
+			expectErr:   true,
+		},
+		"proxy configuration": {
+			annotations: map[string]string{bootstrapv1.ProxyConfigAnnotation: "proxy"},
+			objects: []client.Object{proxyConfigMap(map[string]string{
+				bootstrapv1.ProxyConfigHTTPProxyKey: "http://proxy:3128",
+				bootstrapv1.ProxyConfigNoProxyKey:   "10.0.0.0/8",
+			})},
+			expectFiles: []bootstrapv1.File{
+				{Path: containerdProxyDropInPath, Owner: "root:root", Permissions: "0644", Content: proxyDropIn},
+				{Path: kubeletProxyDropInPath, Owner: "root:root", Permissions: "0644", Content: proxyDropIn},
+			},
+			expectCommands: []string{"systemctl daemon-reload", "systemctl restart containerd"},
+		},
+		"trusted CA bundle": {
+			annotations: map[string]string{bootstrapv1.ProxyConfigAnnotation: "proxy"},
+			objects: []client.Object{proxyConfigMap(map[string]string{
+				bootstrapv1.ProxyConfigTrustedCABundleKey: "ca",
+			})},
+			expectFiles: []bootstrapv1.File{
+				{Path: trustedCABundlePath, Owner: "root:root", Permissions: "0644", Content: "ca"},
+			},
+			expectCommands: []string{
+				"if command -v update-ca-trust >/dev/null 2>&1; then cp " + trustedCABundlePath + " /etc/pki/ca-trust/source/anchors/ && update-ca-trust extract; else update-ca-certificates; fi",
+				"systemctl daemon-reload",
+				"systemctl restart containerd",
+			},
+		},
+		"invalid proxy value": {
+			annotations: map[string]string{bootstrapv1.ProxyConfigAnnotation: "proxy"},
+			objects: []client.Object{proxyConfigMap(map[string]string{
+				bootstrapv1.ProxyConfigHTTPSProxyKey: "http://proxy:3128\"\nExecStart=/bin/true",
+			})},
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").WithAnnotations(tc.annotations).Build()
+			myclient := fake.NewClientBuilder().WithObjects(tc.objects...).Build()
+			k := &KubeadmConfigReconciler{
+				Client:              myclient,
+				SecretCachingClient: myclient,
+				KubeadmInitLock:     &myInitLocker{},
+			}
+
+			files, commands, err := k.resolveProxyConfig(ctx, cluster)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(files).To(BeComparableTo(tc.expectFiles))
+			g.Expect(commands).To(BeComparableTo(tc.expectCommands))
+		})
+	}
+}
+
 func TestKubeadmConfigReconciler_ResolveDiscoveryFileKubeConfig(t *testing.T) {
 	cases := map[string]struct {
 		cfg    *bootstrapv1.KubeadmConfig
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+)
+
+const (
+	containerdProxyDropInPath = "/etc/systemd/system/containerd.service.d/http-proxy.conf"
+	kubeletProxyDropInPath    = "/etc/systemd/system/kubelet.service.d/http-proxy.conf"
+	trustedCABundlePath       = "/usr/local/share/ca-certificates/cluster-api-trusted-ca-bundle.crt"
+)
+
+// proxyEnvVars maps the keys of the proxy configuration ConfigMap to the corresponding environment variables.
+var proxyEnvVars = []struct {
+	key    string
+	envVar string
+}{
+	{key: bootstrapv1.ProxyConfigHTTPProxyKey, envVar: "HTTP_PROXY"},
+	{key: bootstrapv1.ProxyConfigHTTPSProxyKey, envVar: "HTTPS_PROXY"},
+	{key: bootstrapv1.ProxyConfigNoProxyKey, envVar: "NO_PROXY"},
+}
+
+// resolveProxyConfig returns the files and the commands which configure the proxy and the additional trusted CA bundle
+// from the ConfigMap referenced by the ProxyConfigAnnotation of the Cluster, if any.
+// NOTE: The files must be added before the files of the KubeadmConfig so users can still override them, and the
+// commands must run before the PreKubeadmCommands of the KubeadmConfig.
+func (r *KubeadmConfigReconciler) resolveProxyConfig(ctx context.Context, cluster *clusterv1.Cluster) ([]bootstrapv1.File, []string, error) {
+	name, ok := cluster.GetAnnotations()[bootstrapv1.ProxyConfigAnnotation]
+	if !ok {
+		return nil, nil, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: name}
+	if err := r.Client.Get(ctx, key, configMap); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to retrieve proxy configuration ConfigMap %q", key)
+	}
+
+	var files []bootstrapv1.File
+	var commands []string
+
+	var dropIn strings.Builder
+	for _, v := range proxyEnvVars {
+		value := configMap.Data[v.key]
+		if value == "" {
+			continue
+		}
+		if strings.ContainsAny(value, "\"\\\r\n") {
+			return nil, nil, errors.Errorf("invalid proxy configuration ConfigMap %q: %s must not contain quotes, backslashes or line breaks", key, v.key)
+		}
+		fmt.Fprintf(&dropIn, "Environment=\"%s=%s\"\n", v.envVar, value)
+	}
+	if dropIn.Len() > 0 {
+		content := "[Service]\n" + dropIn.String()
+		for _, path := range []string{containerdProxyDropInPath, kubeletProxyDropInPath} {
+			files = append(files, bootstrapv1.File{
+				Path:        path,
+				Owner:       "root:root",
+				Permissions: "0644",
+				Content:     content,
+			})
+		}
+	}
+
+	if caBundle := configMap.Data[bootstrapv1.ProxyConfigTrustedCABundleKey]; caBundle != "" {
+		files = append(files, bootstrapv1.File{
+			Path:        trustedCABundlePath,
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     caBundle,
+		})
+		// NOTE: update-ca-trust is used on Red Hat based distributions, update-ca-certificates on Debian based ones.
+		commands = append(commands, fmt.Sprintf("if command -v update-ca-trust >/dev/null 2>&1; then cp %s /etc/pki/ca-trust/source/anchors/ && update-ca-trust extract; else update-ca-certificates; fi", trustedCABundlePath))
+	}
+
+	if len(files) > 0 {
+		// Restart containerd so it picks up both the proxy configuration and the trusted CA bundle; kubelet is
+		// started by kubeadm afterwards.
+		commands = append(commands, "systemctl daemon-reload", "systemctl restart containerd")
+	}
+
+	return files, commands, nil
+}
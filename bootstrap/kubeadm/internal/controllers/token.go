@@ -36,12 +36,21 @@ func createToken(ctx context.Context, c client.Client, ttl time.Duration) (strin
 		return "", errors.Wrap(err, "unable to generate bootstrap token")
 	}
 
+	if _, err := createTokenSecret(ctx, c, token, ttl); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// createTokenSecret creates the Secret for the given token and returns its expiration.
+func createTokenSecret(ctx context.Context, c client.Client, token string, ttl time.Duration) (string, error) {
 	substrs := bootstraputil.BootstrapTokenRegexp.FindStringSubmatch(token)
 	if len(substrs) != 3 {
 		return "", errors.Errorf("the bootstrap token %q was not of the form %q", token, bootstrapapi.BootstrapTokenPattern)
 	}
 	tokenID := substrs[1]
 	tokenSecret := substrs[2]
+	expiration := time.Now().UTC().Add(ttl).Format(time.RFC3339)
 
 	secretName := bootstraputil.BootstrapTokenSecretName(tokenID)
 	secretToken := &corev1.Secret{
@@ -53,7 +62,7 @@ func createToken(ctx context.Context, c client.Client, ttl time.Duration) (strin
 		Data: map[string][]byte{
 			bootstrapapi.BootstrapTokenIDKey:               []byte(tokenID),
 			bootstrapapi.BootstrapTokenSecretKey:           []byte(tokenSecret),
-			bootstrapapi.BootstrapTokenExpirationKey:       []byte(time.Now().UTC().Add(ttl).Format(time.RFC3339)),
+			bootstrapapi.BootstrapTokenExpirationKey:       []byte(expiration),
 			bootstrapapi.BootstrapTokenUsageSigningKey:     []byte("true"),
 			bootstrapapi.BootstrapTokenUsageAuthentication: []byte("true"),
 			bootstrapapi.BootstrapTokenExtraGroupsKey:      []byte("system:bootstrappers:kubeadm:default-node-token"),
@@ -64,7 +73,7 @@ func createToken(ctx context.Context, c client.Client, ttl time.Duration) (strin
 	if err := c.Create(ctx, secretToken); err != nil {
 		return "", err
 	}
-	return token, nil
+	return expiration, nil
 }
 
 // getToken fetches the token Secret and returns an error if it is invalid.
@@ -41,6 +41,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -48,6 +49,7 @@ import (
 	kubeadmbootstrapcontrollers "sigs.k8s.io/cluster-api/bootstrap/kubeadm/controllers"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/webhooks"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
@@ -65,11 +67,12 @@ var (
 
 	// flags.
 	enableLeaderElection        bool
+	leaderElectionNamespace     string
 	leaderElectionLeaseDuration time.Duration
 	leaderElectionRenewDeadline time.Duration
 	leaderElectionRetryPeriod   time.Duration
 	watchFilterValue            string
-	watchNamespace              string
+	watchNamespace              []string
 	profilerAddress             string
 	enableContentionProfiling   bool
 	syncPeriod                  time.Duration
@@ -107,6 +110,9 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 
+	fs.StringVar(&leaderElectionNamespace, "leader-elect-namespace", "",
+		"Namespace where the leader election resource lock is created. If unspecified, the namespace the controller manager is running in is used.")
+
 	fs.DurationVar(&leaderElectionLeaseDuration, "leader-elect-lease-duration", 15*time.Second,
 		"Interval at which non-leader candidates will wait to force acquire leadership (duration string)")
 
@@ -116,8 +122,8 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&leaderElectionRetryPeriod, "leader-elect-retry-period", 2*time.Second,
 		"Duration the LeaderElector clients should wait between tries of actions (duration string)")
 
-	fs.StringVar(&watchNamespace, "namespace", "",
-		"Namespace that the controller watches to reconcile cluster-api objects. If unspecified, the controller watches for cluster-api objects across all namespaces.")
+	fs.StringSliceVar(&watchNamespace, "namespace", nil,
+		"Comma-separated list of namespaces that the controller watches to reconcile cluster-api objects. If unspecified, the controller watches for cluster-api objects across all namespaces.")
 
 	fs.StringVar(&watchFilterValue, "watch-filter", "",
 		fmt.Sprintf("Label value that the controller watches to reconcile cluster-api objects. Label key is always %s. If unspecified, the controller watches for all cluster-api objects.", clusterv1.WatchLabel))
@@ -212,9 +218,10 @@ func main() {
 	}
 
 	var watchNamespaces map[string]cache.Config
-	if watchNamespace != "" {
-		watchNamespaces = map[string]cache.Config{
-			watchNamespace: {},
+	if len(watchNamespace) > 0 {
+		watchNamespaces = map[string]cache.Config{}
+		for _, namespace := range watchNamespace {
+			watchNamespaces[namespace] = cache.Config{}
 		}
 	}
 
@@ -229,6 +236,7 @@ func main() {
 		Scheme:                     scheme,
 		LeaderElection:             enableLeaderElection,
 		LeaderElectionID:           "kubeadm-bootstrap-manager-leader-election-capi",
+		LeaderElectionNamespace:    leaderElectionNamespace,
 		LeaseDuration:              &leaderElectionLeaseDuration,
 		RenewDeadline:              &leaderElectionRenewDeadline,
 		RetryPeriod:                &leaderElectionRetryPeriod,
@@ -279,6 +287,7 @@ func main() {
 	ctx := ctrl.SetupSignalHandler()
 
 	setupChecks(mgr)
+	setupMetrics()
 	setupWebhooks(mgr)
 	setupReconcilers(ctx, mgr)
 
@@ -301,6 +310,17 @@ func setupChecks(mgr ctrl.Manager) {
 	}
 }
 
+func setupMetrics() {
+	if err := external.RegisterMetrics(ctrlmetrics.Registry); err != nil {
+		setupLog.Error(err, "unable to register metrics")
+		os.Exit(1)
+	}
+	if err := clustercache.RegisterMetrics(ctrlmetrics.Registry); err != nil {
+		setupLog.Error(err, "unable to register metrics")
+		os.Exit(1)
+	}
+}
+
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	secretCachingClient, err := client.New(mgr.GetConfig(), client.Options{
 		HTTPClient: mgr.GetHTTPClient(),
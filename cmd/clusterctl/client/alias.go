@@ -17,6 +17,7 @@ limitations under the License.
 package client
 
 import (
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/alpha"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
@@ -51,3 +52,6 @@ type Kubeconfig cluster.Kubeconfig
 // Processor defines the methods necessary for creating a specific yaml
 // processor.
 type Processor yaml.Processor
+
+// RolloutRevision describes a revision of a cluster-api resource.
+type RolloutRevision alpha.RolloutRevision
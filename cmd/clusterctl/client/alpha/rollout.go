@@ -46,6 +46,7 @@ type Rollout interface {
 	ObjectPauser(context.Context, cluster.Proxy, corev1.ObjectReference) error
 	ObjectResumer(context.Context, cluster.Proxy, corev1.ObjectReference) error
 	ObjectRollbacker(context.Context, cluster.Proxy, corev1.ObjectReference, int64) error
+	ObjectHistory(context.Context, cluster.Proxy, corev1.ObjectReference) ([]RolloutRevision, error)
 }
 
 var _ Rollout = &rollout{}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+// ChangeCauseAnnotation is the annotation used to record the cause of a change; like for Deployments,
+// it is copied from the MachineDeployment to the MachineSet created for each revision.
+const ChangeCauseAnnotation = "kubernetes.io/change-cause"
+
+// RolloutRevision describes a revision of a cluster-api resource.
+type RolloutRevision struct {
+	// Revision is the revision number.
+	Revision int64
+
+	// MachineSet is the name of the MachineSet for this revision.
+	MachineSet string
+
+	// Version is the Kubernetes version of the Machines for this revision.
+	Version string
+
+	// ChangeCause is the value of the kubernetes.io/change-cause annotation for this revision.
+	ChangeCause string
+}
+
+// ObjectHistory returns the revisions of the specified cluster-api resource, sorted by revision number.
+func (r *rollout) ObjectHistory(ctx context.Context, proxy cluster.Proxy, ref corev1.ObjectReference) ([]RolloutRevision, error) {
+	switch ref.Kind {
+	case MachineDeployment:
+		deployment, err := getMachineDeployment(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || deployment == nil {
+			return nil, errors.Wrapf(err, "failed to get %v/%v", ref.Kind, ref.Name)
+		}
+		msList, err := getMachineSetsForDeployment(ctx, proxy, deployment)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions := make([]RolloutRevision, 0, len(msList))
+		for _, ms := range msList {
+			v, err := revision(ms)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get revision of MachineSet %s/%s", ms.Namespace, ms.Name)
+			}
+			revisions = append(revisions, RolloutRevision{
+				Revision:    v,
+				MachineSet:  ms.Name,
+				Version:     ptr.Deref(ms.Spec.Template.Spec.Version, ""),
+				ChangeCause: ms.Annotations[ChangeCauseAnnotation],
+			})
+		}
+		sort.Slice(revisions, func(i, j int) bool {
+			return revisions[i].Revision < revisions[j].Revision
+		})
+		return revisions, nil
+	default:
+		return nil, errors.Errorf("invalid resource type %q, valid values are %v", ref.Kind, validRollbackResourceTypes)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_ObjectHistory(t *testing.T) {
+	deployment := &clusterv1.MachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "MachineDeployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-md-0",
+			Namespace: "default",
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: "test",
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					clusterv1.ClusterNameLabel: "test",
+				},
+			},
+		},
+	}
+	newMachineSet := func(name, revision, version, changeCause string) *clusterv1.MachineSet {
+		ms := &clusterv1.MachineSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "MachineSet",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(deployment, clusterv1.GroupVersion.WithKind("MachineDeployment")),
+				},
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel: "test",
+				},
+				Annotations: map[string]string{
+					clusterv1.RevisionAnnotation: revision,
+				},
+			},
+			Spec: clusterv1.MachineSetSpec{
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						Version: ptr.To(version),
+					},
+				},
+			},
+		}
+		if changeCause != "" {
+			ms.Annotations[ChangeCauseAnnotation] = changeCause
+		}
+		return ms
+	}
+
+	tests := []struct {
+		name    string
+		objs    []client.Object
+		ref     corev1.ObjectReference
+		want    []RolloutRevision
+		wantErr bool
+	}{
+		{
+			name: "machinedeployment history is sorted by revision",
+			objs: []client.Object{
+				deployment,
+				newMachineSet("ms-rev-10", "10", "v1.31.1", "upgrade to v1.31.1"),
+				newMachineSet("ms-rev-2", "2", "v1.31.0", ""),
+				// MachineSet not owned by the MachineDeployment.
+				&clusterv1.MachineSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "other",
+						Namespace: "default",
+						Labels: map[string]string{
+							clusterv1.ClusterNameLabel: "test",
+						},
+					},
+				},
+			},
+			ref: corev1.ObjectReference{
+				Kind:      MachineDeployment,
+				Name:      "test-md-0",
+				Namespace: "default",
+			},
+			want: []RolloutRevision{
+				{Revision: 2, MachineSet: "ms-rev-2", Version: "v1.31.0"},
+				{Revision: 10, MachineSet: "ms-rev-10", Version: "v1.31.1", ChangeCause: "upgrade to v1.31.1"},
+			},
+		},
+		{
+			name: "machinedeployment does not exist",
+			objs: []client.Object{},
+			ref: corev1.ObjectReference{
+				Kind:      MachineDeployment,
+				Name:      "test-md-0",
+				Namespace: "default",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid resource type",
+			objs: []client.Object{},
+			ref: corev1.ObjectReference{
+				Kind:      KubeadmControlPlane,
+				Name:      "test-kcp",
+				Namespace: "default",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := newRolloutClient()
+			proxy := test.NewFakeProxy().WithObjs(tt.objs...)
+			got, err := r.ObjectHistory(context.Background(), proxy, tt.ref)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -39,6 +39,11 @@ func (r *rollout) ObjectRollbacker(ctx context.Context, proxy cluster.Proxy, ref
 		if deployment.Spec.Paused {
 			return errors.Errorf("can't rollback a paused MachineDeployment: please run 'clusterctl rollout resume %v/%v' first", ref.Kind, ref.Name)
 		}
+		// The template of MachineDeployments managed by a Cluster topology is continuously reconciled by the topology
+		// controller, so a rollback would immediately be reverted.
+		if _, ok := deployment.Labels[clusterv1.ClusterTopologyOwnedLabel]; ok {
+			return errors.Errorf("can't rollback a MachineDeployment managed by a Cluster topology: please change the topology of Cluster %v instead", deployment.Spec.ClusterName)
+		}
 		if err := rollbackMachineDeployment(ctx, proxy, deployment, toRevision); err != nil {
 			return err
 		}
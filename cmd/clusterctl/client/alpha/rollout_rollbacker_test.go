@@ -235,6 +235,43 @@ func Test_ObjectRollbacker(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "machinedeployment should not rollback because it is managed by a Cluster topology",
+			fields: fields{
+				objs: []client.Object{
+					func() *clusterv1.MachineDeployment {
+						md := deployment.DeepCopy()
+						md.Labels[clusterv1.ClusterTopologyOwnedLabel] = ""
+						return md
+					}(),
+					&clusterv1.MachineSet{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "MachineSet",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "ms-rev-1",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								*metav1.NewControllerRef(deployment, clusterv1.GroupVersion.WithKind("MachineDeployment")),
+							},
+							Labels: map[string]string{
+								clusterv1.ClusterNameLabel: "test",
+							},
+							Annotations: map[string]string{
+								clusterv1.RevisionAnnotation: "1",
+							},
+						},
+					},
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachineDeployment,
+					Name:      "test-md-0",
+					Namespace: "default",
+				},
+				toRevision: int64(1),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -19,6 +19,8 @@ package client
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/alpha"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
@@ -50,6 +52,12 @@ type Client interface {
 	// GetKubeconfig returns the kubeconfig of the workload cluster.
 	GetKubeconfig(ctx context.Context, options GetKubeconfigOptions) (string, error)
 
+	// GetMachines returns a flat inventory of the Machines existing in a management cluster.
+	GetMachines(ctx context.Context, options GetMachinesOptions) ([]MachineInfo, error)
+
+	// PreviewMachineDeployment returns the objects the controllers would create when scaling up a MachineDeployment.
+	PreviewMachineDeployment(ctx context.Context, options PreviewMachineDeploymentOptions) ([]unstructured.Unstructured, error)
+
 	// Delete deletes providers from a management cluster.
 	Delete(ctx context.Context, options DeleteOptions) error
 
@@ -88,6 +96,8 @@ type AlphaClient interface {
 	//
 	// Deprecated: RolloutUndo is deprecated and will be removed in one of the upcoming releases.
 	RolloutUndo(ctx context.Context, options RolloutUndoOptions) error
+	// RolloutHistory returns the revisions of a cluster-api resource
+	RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]RolloutRevision, error)
 	// TopologyPlan dry runs the topology reconciler
 	//
 	// Deprecated: TopologyPlan is deprecated and will be removed in one of the upcoming releases.
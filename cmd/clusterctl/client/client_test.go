@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -95,6 +96,14 @@ func (f fakeClient) GetKubeconfig(ctx context.Context, options GetKubeconfigOpti
 	return f.internalClient.GetKubeconfig(ctx, options)
 }
 
+func (f fakeClient) GetMachines(ctx context.Context, options GetMachinesOptions) ([]MachineInfo, error) {
+	return f.internalClient.GetMachines(ctx, options)
+}
+
+func (f fakeClient) PreviewMachineDeployment(ctx context.Context, options PreviewMachineDeploymentOptions) ([]unstructured.Unstructured, error) {
+	return f.internalClient.PreviewMachineDeployment(ctx, options)
+}
+
 func (f fakeClient) Init(ctx context.Context, options InitOptions) ([]Components, error) {
 	return f.internalClient.Init(ctx, options)
 }
@@ -147,6 +156,10 @@ func (f fakeClient) RolloutUndo(ctx context.Context, options RolloutUndoOptions)
 	return f.internalClient.RolloutUndo(ctx, options)
 }
 
+func (f fakeClient) RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]RolloutRevision, error) {
+	return f.internalClient.RolloutHistory(ctx, options)
+}
+
 func (f fakeClient) TopologyPlan(ctx context.Context, options TopologyPlanOptions) (*cluster.TopologyPlanOutput, error) {
 	return f.internalClient.TopologyPlan(ctx, options)
 }
@@ -130,18 +130,22 @@ func (m *crdMigrator) run(ctx context.Context, newCRD *apiextensionsv1.CustomRes
 	storedVersionsToDelete := currentStatusStoredVersions.Delete(currentStorageVersion)
 	log.Info("CR migration required", "kind", newCRD.Spec.Names.Kind, "storedVersionsToDelete", strings.Join(sets.List(storedVersionsToDelete), ","), "storedVersionToPreserve", currentStorageVersion)
 
-	if err := m.migrateResourcesForCRD(ctx, currentCRD, currentStorageVersion); err != nil {
+	migrated, err := m.migrateResourcesForCRD(ctx, currentCRD, currentStorageVersion)
+	if err != nil {
 		return false, err
 	}
 
 	if err := m.patchCRDStoredVersions(ctx, currentCRD, currentStorageVersion); err != nil {
-		return false, err
+		return false, errors.Wrapf(err, "failed to complete CR migration after migrating %d objects", migrated)
 	}
+	log.Info("CR migration completed", "kind", newCRD.Spec.Names.Kind, "migratedObjects", migrated, "storedVersion", currentStorageVersion)
 
 	return true, nil
 }
 
-func (m *crdMigrator) migrateResourcesForCRD(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, currentStorageVersion string) error {
+// migrateResourcesForCRD rewrites all the CRs for the given CRD in the current storage version and returns
+// the number of objects that have been migrated.
+func (m *crdMigrator) migrateResourcesForCRD(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, currentStorageVersion string) (int, error) {
 	log := logf.Log.WithValues("CustomResourceDefinition", klog.KObj(crd))
 	log.Info("Migrating CRs, this operation may take a while...")
 
@@ -152,12 +156,12 @@ func (m *crdMigrator) migrateResourcesForCRD(ctx context.Context, crd *apiextens
 		Kind:    crd.Spec.Names.ListKind,
 	})
 
-	var i int
+	var migrated int
 	for {
 		if err := retryWithExponentialBackoff(ctx, newCRDMigrationBackoff(), func(ctx context.Context) error {
 			return m.Client.List(ctx, list, client.Continue(list.GetContinue()))
 		}); err != nil {
-			return errors.Wrapf(err, "failed to list %q", list.GetKind())
+			return migrated, errors.Wrapf(err, "failed to list %q", list.GetKind())
 		}
 
 		for i := range list.Items {
@@ -167,13 +171,13 @@ func (m *crdMigrator) migrateResourcesForCRD(ctx context.Context, crd *apiextens
 			if err := retryWithExponentialBackoff(ctx, newCRDMigrationBackoff(), func(ctx context.Context) error {
 				return handleMigrateErr(m.Client.Update(ctx, &obj))
 			}); err != nil {
-				return errors.Wrapf(err, "failed to migrate %s/%s", obj.GetNamespace(), obj.GetName())
+				return migrated, errors.Wrapf(err, "failed to migrate %s/%s after migrating %d objects", obj.GetNamespace(), obj.GetName(), migrated)
 			}
 
 			// Add some random delays to avoid pressure on the API server.
-			i++
-			if i%10 == 0 {
-				log.V(2).Info(fmt.Sprintf("%d objects migrated", i))
+			migrated++
+			if migrated%10 == 0 {
+				log.V(2).Info(fmt.Sprintf("%d objects migrated", migrated))
 				time.Sleep(time.Duration(rand.IntnRange(50*int(time.Millisecond), 250*int(time.Millisecond))))
 			}
 		}
@@ -183,8 +187,7 @@ func (m *crdMigrator) migrateResourcesForCRD(ctx context.Context, crd *apiextens
 		}
 	}
 
-	log.V(2).Info(fmt.Sprintf("CR migration completed: migrated %d objects", i))
-	return nil
+	return migrated, nil
 }
 
 func (m *crdMigrator) patchCRDStoredVersions(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, currentStorageVersion string) error {
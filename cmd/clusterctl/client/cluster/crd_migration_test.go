@@ -236,6 +236,48 @@ func Test_CRDMigrator(t *testing.T) {
 	}
 }
 
+func Test_CRDMigrator_migrateResourcesForCRD(t *testing.T) {
+	g := NewWithT(t)
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "foo",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Foo", ListKind: "FooList"},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1beta1", Storage: true, Served: true},
+			},
+		},
+	}
+
+	objs := []client.Object{crd}
+	for i := range 12 {
+		objs = append(objs, &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "foo/v1beta1",
+				"kind":       "Foo",
+				"metadata": map[string]interface{}{
+					"name":      fmt.Sprintf("cr%d", i),
+					"namespace": metav1.NamespaceDefault,
+				},
+			},
+		})
+	}
+
+	c, err := test.NewFakeProxy().WithObjs(objs...).NewClient(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	countingClient := newUpgradeCountingClient(c)
+
+	m := crdMigrator{
+		Client: countingClient,
+	}
+
+	migrated, err := m.migrateResourcesForCRD(context.Background(), crd, "v1beta1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(migrated).To(Equal(12))
+	g.Expect(countingClient.count).To(HaveKeyWithValue("foo/v1beta1, Kind=Foo", 12))
+}
+
 type UpgradeCountingClient struct {
 	count map[string]int
 	client.Client
@@ -563,7 +563,7 @@ func setClusterPause(ctx context.Context, proxy Proxy, clusters []*node, value b
 	patchValue := "true"
 	if !value {
 		// If the `value` is false lets drop the field.
-		// This makes sure that clusterctl does now own the field and would avoid any ownership conflicts.
+		// This makes sure that clusterctl does not own the field and would avoid any ownership conflicts.
 		patchValue = "null"
 	}
 	patch := client.RawPatch(types.MergePatchType, []byte(fmt.Sprintf("{\"spec\":{\"paused\":%s}}", patchValue)))
@@ -993,28 +993,34 @@ func (o *objectMover) createTargetObject(ctx context.Context, nodeToCreate *node
 				obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 		}
 
+		// Retrieve the UID and the resource version of the existing object.
+		existingTargetObj := &unstructured.Unstructured{}
+		existingTargetObj.SetAPIVersion(obj.GetAPIVersion())
+		existingTargetObj.SetKind(obj.GetKind())
+		if err := cTo.Get(ctx, client.ObjectKeyFromObject(obj), existingTargetObj); err != nil {
+			return errors.Wrapf(err, "error reading resource for %q %s/%s",
+				existingTargetObj.GroupVersionKind(), existingTargetObj.GetNamespace(), existingTargetObj.GetName())
+		}
+
 		// If the object already exists, try to update it if it is node a global object / something belonging to a global object hierarchy (e.g. a secrets owned by a global identity object).
 		if nodeToCreate.isGlobal || nodeToCreate.isGlobalHierarchy {
 			log.V(5).Info("Object already exists, skipping upgrade because it is global/it is owned by a global object", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
-		} else {
-			// Nb. This should not happen, but it is supported to make move more resilient to unexpected interrupt/restarts of the move process.
-			log.V(5).Info("Object already exists, updating", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
-
-			// Retrieve the UID and the resource version for the update.
-			existingTargetObj := &unstructured.Unstructured{}
-			existingTargetObj.SetAPIVersion(obj.GetAPIVersion())
-			existingTargetObj.SetKind(obj.GetKind())
-			if err := cTo.Get(ctx, client.ObjectKeyFromObject(obj), existingTargetObj); err != nil {
-				return errors.Wrapf(err, "error reading resource for %q %s/%s",
-					existingTargetObj.GroupVersionKind(), existingTargetObj.GetNamespace(), existingTargetObj.GetName())
-			}
 
-			obj.SetUID(existingTargetObj.GetUID())
-			obj.SetResourceVersion(existingTargetObj.GetResourceVersion())
-			if err := cTo.Update(ctx, obj); err != nil {
-				return errors.Wrapf(err, "error updating %q %s/%s",
-					obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
-			}
+			// Stores the UID of the existing object, so the owner references of the objects it owns point to it.
+			// Nb. The managed fields of the existing object are left untouched, given that the object has not been modified.
+			nodeToCreate.newUID = existingTargetObj.GetUID()
+			return nil
+		}
+
+		// Nb. This should not happen, but it is supported to make move more resilient to unexpected interrupt/restarts of the move process,
+		// so a move can be re-run into namespaces already containing part of the objects.
+		log.V(5).Info("Object already exists, updating", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
+
+		obj.SetUID(existingTargetObj.GetUID())
+		obj.SetResourceVersion(existingTargetObj.GetResourceVersion())
+		if err := cTo.Update(ctx, obj); err != nil {
+			return errors.Wrapf(err, "error updating %q %s/%s",
+				obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 		}
 	}
 
@@ -1128,6 +1134,15 @@ func (o *objectMover) restoreTargetObject(ctx context.Context, nodeToCreate *nod
 			return errors.Wrapf(err, "error creating %q %s/%s",
 				obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 		}
+
+		// The object has been created after the check above, e.g. by a controller; retrieve its UID,
+		// otherwise the owner references of the objects it owns would point to the UID stored in the backup.
+		if err := cTo.Get(ctx, objKey, existingTargetObj); err != nil {
+			return errors.Wrapf(err, "error reading resource for %q %s/%s",
+				existingTargetObj.GroupVersionKind(), existingTargetObj.GetNamespace(), existingTargetObj.GetName())
+		}
+		nodeToCreate.newUID = existingTargetObj.GetUID()
+		return nil
 	}
 
 	// Stores the newUID assigned to the newly created object.
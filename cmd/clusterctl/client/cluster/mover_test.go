@@ -36,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
@@ -923,6 +924,75 @@ func Test_objectMover_restoreTargetObject(t *testing.T) {
 	}
 }
 
+func Test_objectMover_restoreTargetObject_createdConcurrently(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	existing := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "foo",
+			UID:       "existing-uid",
+		},
+	}
+	toProxy := test.NewFakeProxy().WithObjs(existing)
+	cTo, err := toProxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Simulate the object being created in the target cluster after restoreTargetObject checked if it exists,
+	// e.g. by a controller, so the following create fails with AlreadyExists.
+	gets := 0
+	interceptedClient := interceptor.NewClient(cTo.(client.WithWatch), interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			gets++
+			if gets == 1 {
+				return apierrors.NewNotFound(clusterv1.GroupVersion.WithResource("clusters").GroupResource(), key.Name)
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	})
+
+	restoreObject := &unstructured.Unstructured{}
+	restoreObject.SetAPIVersion(clusterv1.GroupVersion.String())
+	restoreObject.SetKind("Cluster")
+	restoreObject.SetNamespace("ns1")
+	restoreObject.SetName("foo")
+	restoreObject.SetUID("backup-uid")
+	n := &node{
+		identity: corev1.ObjectReference{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Cluster",
+			Namespace:  "ns1",
+			Name:       "foo",
+			UID:        "backup-uid",
+		},
+		restoreObject: restoreObject,
+	}
+
+	mover := objectMover{}
+	g.Expect(mover.restoreTargetObject(ctx, n, &clientProxy{Proxy: toProxy, client: interceptedClient})).To(Succeed())
+
+	// The new UID must be the one of the object in the target cluster, so the owner chain of the objects it
+	// owns is rebuilt correctly.
+	g.Expect(gets).To(Equal(2))
+	g.Expect(n.newUID).To(Equal(types.UID("existing-uid")))
+}
+
+// clientProxy is a Proxy returning the given client.
+type clientProxy struct {
+	Proxy
+	client client.Client
+}
+
+func (p *clientProxy) NewClient(_ context.Context) (client.Client, error) {
+	return p.client, nil
+}
+
 func Test_objectMover_toDirectory(t *testing.T) {
 	// NB. we are testing the move and move sequence using the same set of moveTests, but checking the results at different stages of the move process
 	for _, tt := range backupRestoreTests {
@@ -1960,10 +2030,11 @@ func Test_createTargetObject(t *testing.T) {
 	}
 
 	tests := []struct {
-		name    string
-		args    args
-		want    func(*WithT, client.Client)
-		wantErr bool
+		name       string
+		args       args
+		want       func(*WithT, client.Client)
+		wantNewUID types.UID
+		wantErr    bool
 	}{
 		{
 			name: "fails if the object is missing from the source",
@@ -2129,6 +2200,7 @@ func Test_createTargetObject(t *testing.T) {
 					&infrastructure.GenericClusterInfrastructureIdentity{
 						ObjectMeta: metav1.ObjectMeta{
 							Name: "foo",
+							UID:  "source-uid",
 						},
 					},
 				),
@@ -2136,6 +2208,7 @@ func Test_createTargetObject(t *testing.T) {
 					&infrastructure.GenericClusterInfrastructureIdentity{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:        "foo",
+							UID:         "target-uid",
 							Annotations: map[string]string{"foo": "bar"},
 						},
 					},
@@ -2157,6 +2230,7 @@ func Test_createTargetObject(t *testing.T) {
 				g.Expect(toClient.Get(context.Background(), key, c)).ToNot(HaveOccurred())
 				g.Expect(c.Annotations).ToNot(BeEmpty())
 			},
+			wantNewUID: "target-uid",
 		},
 		{
 			name: "should not update Global Hierarchy objects",
@@ -2221,6 +2295,10 @@ func Test_createTargetObject(t *testing.T) {
 			g.Expect(err).ToNot(HaveOccurred())
 
 			tt.want(g, toClient)
+
+			if tt.wantNewUID != "" {
+				g.Expect(tt.args.node.newUID).To(Equal(tt.wantNewUID))
+			}
 		})
 	}
 }
@@ -23,6 +23,8 @@ import (
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -59,6 +61,11 @@ type UpgradePlan struct {
 type UpgradeOptions struct {
 	WaitProviders       bool
 	WaitProviderTimeout time.Duration
+
+	// PauseClusters instructs the upgrade to pause all the Clusters before upgrading the providers, and to resume
+	// them once the upgraded providers are ready; it implies WaitProviders.
+	// Clusters already paused before the upgrade are not resumed.
+	PauseClusters bool
 }
 
 // isPartialUpgrade returns true if at least one upgradeItem in the plan does not have a target version.
@@ -348,7 +355,7 @@ func (u *providerUpgrader) getUpgradeComponents(ctx context.Context, provider Up
 	return components, nil
 }
 
-func (u *providerUpgrader) doUpgrade(ctx context.Context, upgradePlan *UpgradePlan, opts UpgradeOptions) error {
+func (u *providerUpgrader) doUpgrade(ctx context.Context, upgradePlan *UpgradePlan, opts UpgradeOptions) (reterr error) {
 	// Check for multiple instances of the same provider if current contract is v1alpha3.
 	// TODO(killianmuldoon) Assess if we can remove this piece of code.
 	if upgradePlan.Contract == clusterv1.GroupVersion.Version {
@@ -357,6 +364,21 @@ func (u *providerUpgrader) doUpgrade(ctx context.Context, upgradePlan *UpgradePl
 		}
 	}
 
+	// Pause the Clusters, if requested.
+	// Note: We have to do this before the providers are scaled down or deleted so webhooks still work.
+	var pausedClusters []types.NamespacedName
+	if opts.PauseClusters {
+		var err error
+		if pausedClusters, err = u.pauseClusters(ctx); err != nil {
+			return err
+		}
+		defer func() {
+			if reterr != nil && len(pausedClusters) > 0 {
+				logf.Log.Info("Upgrade failed, Clusters paused by clusterctl must be resumed manually", "Clusters", pausedClusters)
+			}
+		}()
+	}
+
 	// Ensure Providers are updated in the following order: Core, Bootstrap, ControlPlane, Infrastructure.
 	providers := upgradePlan.Providers
 	sort.Slice(providers, func(a, b int) bool {
@@ -448,7 +470,80 @@ func (u *providerUpgrader) doUpgrade(ctx context.Context, upgradePlan *UpgradePl
 		}
 	}
 
-	return waitForProvidersReady(ctx, InstallOptions(opts), installQueue, u.proxy)
+	// Note: If Clusters have been paused, it is required to wait for the providers to be ready, so webhooks
+	// work when resuming the Clusters.
+	waitOpts := InstallOptions{
+		WaitProviders:       opts.WaitProviders || opts.PauseClusters,
+		WaitProviderTimeout: opts.WaitProviderTimeout,
+	}
+	if err := waitForProvidersReady(ctx, waitOpts, installQueue, u.proxy); err != nil {
+		return err
+	}
+
+	return u.resumeClusters(ctx, pausedClusters)
+}
+
+// pauseClusters sets Cluster.Spec.Paused on all the Clusters which are not paused yet, and returns them.
+func (u *providerUpgrader) pauseClusters(ctx context.Context) ([]types.NamespacedName, error) {
+	log := logf.Log
+
+	c, err := u.proxy.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := retryWithExponentialBackoff(ctx, newReadBackoff(), func(ctx context.Context) error {
+		return c.List(ctx, clusterList)
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to list Clusters")
+	}
+
+	pausedClusters := []types.NamespacedName{}
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if cluster.Spec.Paused {
+			continue
+		}
+
+		log.Info("Pausing", "Cluster", klog.KObj(cluster))
+		if err := setClusterPause(ctx, u.proxy, clusterNodes(client.ObjectKeyFromObject(cluster)), true, false); err != nil {
+			return pausedClusters, err
+		}
+		pausedClusters = append(pausedClusters, client.ObjectKeyFromObject(cluster))
+	}
+	return pausedClusters, nil
+}
+
+// resumeClusters removes Cluster.Spec.Paused from the given Clusters.
+func (u *providerUpgrader) resumeClusters(ctx context.Context, clusters []types.NamespacedName) error {
+	if len(clusters) == 0 {
+		return nil
+	}
+
+	log := logf.Log
+
+	for _, cluster := range clusters {
+		log.Info("Resuming", "Cluster", klog.KRef(cluster.Namespace, cluster.Name))
+		if err := setClusterPause(ctx, u.proxy, clusterNodes(cluster), false, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clusterNodes returns the object graph nodes for the given Clusters, so they can be passed to setClusterPause.
+func clusterNodes(clusters ...types.NamespacedName) []*node {
+	nodes := make([]*node, 0, len(clusters))
+	for _, cluster := range clusters {
+		nodes = append(nodes, &node{identity: corev1.ObjectReference{
+			Kind:       clusterv1.ClusterKind,
+			APIVersion: clusterv1.GroupVersion.String(),
+			Namespace:  cluster.Namespace,
+			Name:       cluster.Name,
+		}})
+	}
+	return nodes
 }
 
 func (u *providerUpgrader) scaleDownProvider(ctx context.Context, provider clusterctlv1.Provider) error {
@@ -22,7 +22,11 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
@@ -1062,3 +1066,34 @@ func Test_providerUpgrader_ApplyCustomPlan(t *testing.T) {
 		})
 	}
 }
+
+func Test_providerUpgrader_pauseAndResumeClusters(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cluster1"}}
+	alreadyPausedCluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cluster2"}, Spec: clusterv1.ClusterSpec{Paused: true}}
+
+	proxy := test.NewFakeProxy().WithObjs(cluster, alreadyPausedCluster)
+	u := &providerUpgrader{proxy: proxy}
+
+	isPaused := func(obj *clusterv1.Cluster) bool {
+		c, err := proxy.NewClient(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+		got := &clusterv1.Cluster{}
+		g.Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), got)).To(Succeed())
+		return got.Spec.Paused
+	}
+
+	// Only Clusters which are not paused yet are paused and returned.
+	pausedClusters, err := u.pauseClusters(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pausedClusters).To(ConsistOf(types.NamespacedName{Namespace: "ns1", Name: "cluster1"}))
+	g.Expect(isPaused(cluster)).To(BeTrue())
+	g.Expect(isPaused(alreadyPausedCluster)).To(BeTrue())
+
+	// Only the Clusters paused by clusterctl are resumed.
+	g.Expect(u.resumeClusters(ctx, pausedClusters)).To(Succeed())
+	g.Expect(isPaused(cluster)).To(BeFalse())
+	g.Expect(isPaused(alreadyPausedCluster)).To(BeTrue())
+}
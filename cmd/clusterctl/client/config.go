@@ -22,8 +22,11 @@ import (
 	"strconv"
 
 	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
@@ -153,6 +156,11 @@ type GetClusterTemplateOptions struct {
 	// YamlProcessor defines the yaml processor to use for the cluster
 	// template processing. If not defined, SimpleProcessor will be used.
 	YamlProcessor Processor
+
+	// DryRun validates the objects of the workload cluster template against the management cluster with a
+	// server-side dry-run create, so mismatches with the installed CRDs and webhooks are detected before applying them;
+	// nothing is persisted. The target namespace must exist in the management cluster.
+	DryRun bool
 }
 
 // numSources return the number of template sources currently set on a GetClusterTemplateOptions.
@@ -243,6 +251,21 @@ func (c *clusterctlClient) GetClusterTemplate(ctx context.Context, options GetCl
 		return nil, err
 	}
 
+	template, err := c.getClusterTemplate(ctx, clusterClient, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.DryRun && !options.ListVariablesOnly {
+		if err := dryRunClusterTemplate(ctx, clusterClient, template); err != nil {
+			return nil, err
+		}
+	}
+	return template, nil
+}
+
+// getClusterTemplate returns the workload cluster template from the source selected in options.
+func (c *clusterctlClient) getClusterTemplate(ctx context.Context, clusterClient cluster.Client, options GetClusterTemplateOptions) (Template, error) {
 	// Gets the workload cluster template from the selected source
 	if options.ProviderRepositorySource != nil {
 		// Ensure this command only runs against management clusters with the current Cluster API contract.
@@ -266,6 +289,31 @@ func (c *clusterctlClient) GetClusterTemplate(ctx context.Context, options GetCl
 	return nil, errors.New("unable to read custom template. Please specify a template source")
 }
 
+// dryRunClusterTemplate submits all the objects of a workload cluster template to the management cluster
+// with a server-side dry-run create, and returns the errors reported by the API server, if any.
+func dryRunClusterTemplate(ctx context.Context, clusterClient cluster.Client, template Template) error {
+	if err := clusterClient.Proxy().CheckClusterAvailable(ctx); err != nil {
+		return errors.Wrap(err, "management cluster not available. Cannot validate the cluster template with a dry-run")
+	}
+
+	c, err := clusterClient.Proxy().NewClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, obj := range template.Objs() {
+		obj := obj.DeepCopy()
+		if err := c.Create(ctx, obj, client.DryRunAll); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to validate %s %s", obj.GetKind(), klog.KObj(obj)))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Wrap(kerrors.NewAggregate(errs), "cluster template failed validation with a server-side dry-run")
+	}
+	return nil
+}
+
 // getTemplateFromRepository returns a workload cluster template from a provider repository.
 func (c *clusterctlClient) getTemplateFromRepository(ctx context.Context, cluster cluster.Client, options GetClusterTemplateOptions) (Template, error) {
 	source := *options.ProviderRepositorySource
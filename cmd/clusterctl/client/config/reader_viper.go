@@ -28,6 +28,8 @@ import (
 	"time"
 
 	"github.com/adrg/xdg"
+	"github.com/drone/envsubst/v2"
+	"github.com/drone/envsubst/v2/parse"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 
@@ -43,12 +45,21 @@ const (
 	ConfigName = "clusterctl"
 	// DownloadConfigFile is the config file when fetching the config from a remote location.
 	DownloadConfigFile = "clusterctl-download.yaml"
+
+	// expandEnvVariablesKey is the variable which enables the expansion of environment variables in values of the
+	// clusterctl config file.
+	expandEnvVariablesKey = "CLUSTERCTL_EXPAND_ENV_VARIABLES"
 )
 
+// envKeyReplacer maps keys to the names of the corresponding environment variables, which cannot contain -.
+var envKeyReplacer = strings.NewReplacer("-", "_")
+
 // viperReader implements Reader using viper as backend for reading from environment variables
 // and from a clusterctl config file.
 type viperReader struct {
 	configPaths []string
+	// setKeys are the keys of the values set programmatically via Set.
+	setKeys map[string]bool
 }
 
 type viperReaderOption func(*viperReader)
@@ -82,8 +93,7 @@ func (v *viperReader) Init(ctx context.Context, path string) error {
 	// AutomaticEnv force viper to check for an environment variable any time a viper.Get request is made.
 	// It will check for a environment variable with a name matching the key uppercased; in case name use the - delimiter,
 	// the SetEnvKeyReplacer forces matching to name use the _ delimiter instead (- is not allowed in linux env variable names).
-	replacer := strings.NewReplacer("-", "_")
-	viper.SetEnvKeyReplacer(replacer)
+	viper.SetEnvKeyReplacer(envKeyReplacer)
 	viper.AllowEmptyEnv(true)
 	viper.AutomaticEnv()
 
@@ -180,10 +190,68 @@ func (v *viperReader) Get(key string) (string, error) {
 	if viper.Get(key) == nil {
 		return "", errors.Errorf("Failed to get value for variable %q. Please set the variable value using os env variables or using the .clusterctl config file", key)
 	}
-	return viper.GetString(key), nil
+	value := viper.GetString(key)
+
+	if !v.shouldExpandEnvVariables(key) {
+		return value, nil
+	}
+	expanded, err := expandEnvVariables(value)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to expand environment variables in the value of variable %q", key)
+	}
+	return expanded, nil
+}
+
+// shouldExpandEnvVariables returns true if environment variables, e.g. "${REGISTRY}/cluster-api", should be expanded
+// in the value of the given key. This happens only if enabled by setting CLUSTERCTL_EXPAND_ENV_VARIABLES to true, and
+// only for values read from the clusterctl config file, i.e. not for values overridden by environment variables or set programmatically.
+func (v *viperReader) shouldExpandEnvVariables(key string) bool {
+	if !viper.GetBool(expandEnvVariablesKey) {
+		return false
+	}
+	if !viper.InConfig(key) || v.setKeys[strings.ToLower(key)] {
+		return false
+	}
+	// NOTE: This matches the name of the environment variable viper checks for the key because of AutomaticEnv.
+	_, fromEnv := os.LookupEnv(strings.ToUpper(envKeyReplacer.Replace(key)))
+	return !fromEnv
+}
+
+// expandEnvVariables expands the environment variables in the given value; it fails if any of them is not set and
+// has no default value, e.g. ${MY_REGISTRY:=gcr.io}.
+func expandEnvVariables(value string) (string, error) {
+	tree, err := parse.Parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	var missingVariables []string
+	var traverse func(node parse.Node)
+	traverse = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			for _, ln := range n.Nodes {
+				traverse(ln)
+			}
+		case *parse.FuncNode:
+			if _, ok := os.LookupEnv(n.Param); !ok && len(n.Args) == 0 {
+				missingVariables = append(missingVariables, n.Param)
+			}
+		}
+	}
+	traverse(tree.Root)
+	if len(missingVariables) > 0 {
+		return "", errors.Errorf("environment variables [%s] are not set", strings.Join(missingVariables, ", "))
+	}
+
+	return envsubst.Eval(value, os.Getenv)
 }
 
 func (v *viperReader) Set(key, value string) {
+	if v.setKeys == nil {
+		v.setKeys = map[string]bool{}
+	}
+	v.setKeys[strings.ToLower(key)] = true
 	viper.Set(key, value)
 }
 
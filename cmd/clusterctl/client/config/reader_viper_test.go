@@ -128,18 +128,20 @@ func Test_viperReader_Get(t *testing.T) {
 	dir := t.TempDir()
 
 	t.Setenv("FOO", "foo")
+	t.Setenv("QUX", "${FOO}")
 
 	configFile := filepath.Join(dir, "clusterctl.yaml")
-	g.Expect(os.WriteFile(configFile, []byte("bar: bar"), 0600)).To(Succeed())
+	g.Expect(os.WriteFile(configFile, []byte("bar: bar\nregistry: ${FOO}/cluster-api\nqux: qux\npassword: pa$word\nescaped: pa$$word\nundefined: ${UNDEFINED}"), 0600)).To(Succeed())
 
 	type args struct {
 		key string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    string
-		wantErr bool
+		name               string
+		args               args
+		expandEnvVariables bool
+		want               string
+		wantErr            bool
 	}{
 		{
 			name: "Read from env",
@@ -157,6 +159,58 @@ func Test_viperReader_Get(t *testing.T) {
 			want:    "bar",
 			wantErr: false,
 		},
+		{
+			name: "Read from file without expanding environment variables by default",
+			args: args{
+				key: "REGISTRY",
+			},
+			want:    "${FOO}/cluster-api",
+			wantErr: false,
+		},
+		{
+			name: "Read from file a value with a literal $ without expanding environment variables by default",
+			args: args{
+				key: "PASSWORD",
+			},
+			want:    "pa$word",
+			wantErr: false,
+		},
+		{
+			name: "Read from file expanding environment variables",
+			args: args{
+				key: "REGISTRY",
+			},
+			expandEnvVariables: true,
+			want:               "foo/cluster-api",
+			wantErr:            false,
+		},
+		{
+			name: "Read from file a value with an escaped $ expanding environment variables",
+			args: args{
+				key: "ESCAPED",
+			},
+			expandEnvVariables: true,
+			want:               "pa$word",
+			wantErr:            false,
+		},
+		{
+			name: "Fails if expanding undefined environment variables",
+			args: args{
+				key: "UNDEFINED",
+			},
+			expandEnvVariables: true,
+			want:               "",
+			wantErr:            true,
+		},
+		{
+			name: "Read from env without expanding environment variables",
+			args: args{
+				key: "QUX",
+			},
+			expandEnvVariables: true,
+			want:               "${FOO}",
+			wantErr:            false,
+		},
 		{
 			name: "Fails if missing",
 			args: args{
@@ -172,6 +226,10 @@ func Test_viperReader_Get(t *testing.T) {
 
 			ctx := context.Background()
 
+			if tt.expandEnvVariables {
+				t.Setenv(expandEnvVariablesKey, "true")
+			}
+
 			v, _ := newViperReader(injectConfigPaths([]string{dir}))
 
 			gs.Expect(v.Init(ctx, configFile)).To(Succeed())
@@ -30,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
@@ -738,6 +739,65 @@ func Test_clusterctlClient_GetClusterTemplate_withClusterClass(t *testing.T) {
 	g.Expect(got.TargetNamespace()).To(Equal("ns1"))
 	g.Expect(got.Objs()).To(ContainElement(MatchClusterClass("dev", "ns1")))
 }
+func Test_clusterctlClient_GetClusterTemplate_dryRun(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDir, err := os.MkdirTemp("", "cc")
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "cluster-template.yaml")
+	NewWithT(t).Expect(os.WriteFile(path, mangedTopologyTemplateYAML("ns1", "${CLUSTER_NAME}", "dev"), 0600)).To(Succeed())
+
+	tests := []struct {
+		name      string
+		available bool
+		wantErr   bool
+	}{
+		{
+			name:      "pass if the API server accepts all the objects",
+			available: true,
+			wantErr:   false,
+		},
+		{
+			name:      "fail if the management cluster is not available",
+			available: false,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config1 := newFakeConfig(ctx).WithProvider(infraProviderConfig)
+			cluster1 := newFakeCluster(cluster.Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}, config1).
+				WithObjs(test.FakeCAPISetupObjects()...)
+			cluster1.fakeProxy.WithClusterAvailable(tt.available)
+			clusterctlClient := newFakeClient(ctx, config1).WithCluster(cluster1)
+
+			_, err := clusterctlClient.GetClusterTemplate(ctx, GetClusterTemplateOptions{
+				Kubeconfig:      Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				URLSource:       &URLSourceOptions{URL: path},
+				ClusterName:     "test",
+				TargetNamespace: "ns1",
+				DryRun:          true,
+			})
+			if tt.wantErr {
+				g.Expect(err).To(MatchError(ContainSubstring("Cannot validate the cluster template with a dry-run")))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			// Nothing is persisted.
+			c, err := cluster1.Proxy().NewClient(ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+			clusters := &clusterv1.ClusterList{}
+			g.Expect(c.List(ctx, clusters)).To(Succeed())
+			g.Expect(clusters.Items).To(BeEmpty())
+		})
+	}
+}
+
 func Test_clusterctlClient_GetClusterTemplate_onEmptyCluster(t *testing.T) {
 	g := NewWithT(t)
 
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// GetMachinesOptions carries all the options supported by GetMachines.
+type GetMachinesOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Namespace where the Machines are located. If empty, the current namespace will be used.
+	Namespace string
+
+	// AllNamespaces lists Machines across all the namespaces; when set, Namespace is ignored.
+	AllNamespaces bool
+}
+
+// MachineInfo is a flat representation of a Machine, suitable for inventory exports.
+type MachineInfo struct {
+	Cluster           string      `json:"cluster"`
+	Namespace         string      `json:"namespace"`
+	Name              string      `json:"name"`
+	Phase             string      `json:"phase"`
+	Version           string      `json:"version"`
+	ProviderID        string      `json:"providerID"`
+	Addresses         []string    `json:"addresses"`
+	NodeName          string      `json:"nodeName"`
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+}
+
+func (c *clusterctlClient) GetMachines(ctx context.Context, options GetMachinesOptions) ([]MachineInfo, error) {
+	// gets access to the management cluster
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure this command only runs against management clusters with the current Cluster API contract.
+	if err := clusterClient.ProviderInventory().CheckCAPIContract(ctx); err != nil {
+		return nil, err
+	}
+
+	listOptions := []client.ListOption{}
+	if !options.AllNamespaces {
+		if options.Namespace == "" {
+			currentNamespace, err := clusterClient.Proxy().CurrentNamespace()
+			if err != nil {
+				return nil, err
+			}
+			if currentNamespace == "" {
+				return nil, errors.New("failed to identify the current namespace. Please specify the namespace where the Machines exist")
+			}
+			options.Namespace = currentNamespace
+		}
+		listOptions = append(listOptions, client.InNamespace(options.Namespace))
+	}
+
+	cl, err := clusterClient.Proxy().NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	machineList := &clusterv1.MachineList{}
+	if err := cl.List(ctx, machineList, listOptions...); err != nil {
+		return nil, errors.Wrap(err, "failed to list Machines")
+	}
+
+	machines := make([]MachineInfo, 0, len(machineList.Items))
+	for i := range machineList.Items {
+		m := &machineList.Items[i]
+		info := MachineInfo{
+			Cluster:           m.Spec.ClusterName,
+			Namespace:         m.Namespace,
+			Name:              m.Name,
+			Phase:             m.Status.Phase,
+			ProviderID:        ptr.Deref(m.Spec.ProviderID, ""),
+			Version:           ptr.Deref(m.Spec.Version, ""),
+			Addresses:         []string{},
+			CreationTimestamp: m.CreationTimestamp,
+		}
+		for _, a := range m.Status.Addresses {
+			info.Addresses = append(info.Addresses, a.Address)
+		}
+		if m.Status.NodeRef != nil {
+			info.NodeName = m.Status.NodeRef.Name
+		}
+		machines = append(machines, info)
+	}
+
+	sort.Slice(machines, func(i, j int) bool {
+		if machines[i].Namespace != machines[j].Namespace {
+			return machines[i].Namespace < machines[j].Namespace
+		}
+		if machines[i].Cluster != machines[j].Cluster {
+			return machines[i].Cluster < machines[j].Cluster
+		}
+		return machines[i].Name < machines[j].Name
+	})
+
+	return machines, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+func Test_clusterctlClient_GetMachines(t *testing.T) {
+	ctx := context.Background()
+
+	configClient := newFakeConfig(ctx)
+	kubeconfig := cluster.Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}
+
+	m1 := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "m1"},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "cluster1",
+			Version:     ptr.To("v1.31.0"),
+			ProviderID:  ptr.To("docker:////m1"),
+		},
+		Status: clusterv1.MachineStatus{
+			Phase:     string(clusterv1.MachinePhaseRunning),
+			NodeRef:   &corev1.ObjectReference{Name: "node1"},
+			Addresses: clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"}},
+		},
+	}
+	m2 := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "m2"},
+		Spec:       clusterv1.MachineSpec{ClusterName: "cluster2"},
+		Status:     clusterv1.MachineStatus{Phase: string(clusterv1.MachinePhaseProvisioning)},
+	}
+
+	clusterClient := newFakeCluster(kubeconfig, configClient).WithObjs(m1, m2)
+	clusterClient.fakeProxy.WithNamespace("ns1").WithFakeCAPISetup()
+	client := newFakeClient(ctx, configClient).WithCluster(clusterClient)
+
+	tests := []struct {
+		name      string
+		client    *fakeClient
+		options   GetMachinesOptions
+		want      []MachineInfo
+		expectErr bool
+	}{
+		{
+			name:      "returns error if unable to get client for mgmt cluster",
+			client:    fakeEmptyCluster(),
+			expectErr: true,
+		},
+		{
+			name:    "returns Machines in the current namespace",
+			client:  client,
+			options: GetMachinesOptions{Kubeconfig: Kubeconfig(kubeconfig)},
+			want: []MachineInfo{
+				{Cluster: "cluster1", Namespace: "ns1", Name: "m1", Phase: "Running", Version: "v1.31.0", ProviderID: "docker:////m1", Addresses: []string{"10.0.0.1"}, NodeName: "node1"},
+			},
+		},
+		{
+			name:    "returns Machines in the given namespace",
+			client:  client,
+			options: GetMachinesOptions{Kubeconfig: Kubeconfig(kubeconfig), Namespace: "ns2"},
+			want: []MachineInfo{
+				{Cluster: "cluster2", Namespace: "ns2", Name: "m2", Phase: "Provisioning", Addresses: []string{}},
+			},
+		},
+		{
+			name:    "returns Machines in all the namespaces",
+			client:  client,
+			options: GetMachinesOptions{Kubeconfig: Kubeconfig(kubeconfig), Namespace: "ns2", AllNamespaces: true},
+			want: []MachineInfo{
+				{Cluster: "cluster1", Namespace: "ns1", Name: "m1", Phase: "Running", Version: "v1.31.0", ProviderID: "docker:////m1", Addresses: []string{"10.0.0.1"}, NodeName: "node1"},
+				{Cluster: "cluster2", Namespace: "ns2", Name: "m2", Phase: "Provisioning", Addresses: []string{}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machines, err := tt.client.GetMachines(ctx, tt.options)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			for i := range machines {
+				machines[i].CreationTimestamp = metav1.Time{}
+			}
+			g.Expect(machines).To(Equal(tt.want))
+		})
+	}
+}
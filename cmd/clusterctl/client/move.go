@@ -97,11 +97,38 @@ func (c *clusterctlClient) move(ctx context.Context, options MoveOptions) error
 		if toCluster, err = c.getClusterClient(ctx, options.ToKubeconfig); err != nil {
 			return err
 		}
+
+		// Moving objects onto the same management cluster would delete them from the source once they are
+		// "created" on the target, so block it.
+		if err := checkDifferentManagementClusters(fromCluster, toCluster, options); err != nil {
+			return err
+		}
 	}
 
 	return fromCluster.ObjectMover().Move(ctx, options.Namespace, toCluster, options.DryRun, options.ExperimentalResourceMutators...)
 }
 
+// checkDifferentManagementClusters returns an error if the source and the target of a move operation
+// point to the same management cluster.
+func checkDifferentManagementClusters(fromCluster, toCluster cluster.Client, options MoveOptions) error {
+	if options.FromKubeconfig == options.ToKubeconfig {
+		return errors.Errorf("the source and the target management clusters must be different (kubeconfig %q, context %q)", options.FromKubeconfig.Path, options.FromKubeconfig.Context)
+	}
+
+	fromConfig, err := fromCluster.Proxy().GetConfig()
+	if err != nil {
+		return err
+	}
+	toConfig, err := toCluster.Proxy().GetConfig()
+	if err != nil {
+		return err
+	}
+	if fromConfig != nil && toConfig != nil && fromConfig.Host != "" && fromConfig.Host == toConfig.Host {
+		return errors.Errorf("the source and the target management clusters must be different (both point to %q)", fromConfig.Host)
+	}
+	return nil
+}
+
 func (c *clusterctlClient) fromDirectory(ctx context.Context, options MoveOptions) error {
 	toCluster, err := c.getClusterClient(ctx, options.ToKubeconfig)
 	if err != nil {
@@ -81,6 +81,19 @@ func Test_clusterctlClient_Move(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "returns an error if from and to cluster are the same",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "returns an error if both move ToDirectory and FromDirectory is set",
 			fields: fields{
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/storage/names"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+)
+
+// PreviewMachineDeploymentOptions carries the options supported by PreviewMachineDeployment.
+type PreviewMachineDeploymentOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Namespace where the MachineDeployment is located. If empty, the current namespace will be used.
+	Namespace string
+
+	// Name of the MachineDeployment to preview.
+	Name string
+}
+
+// PreviewMachineDeployment returns the BootstrapConfig, InfraMachine and Machine objects that the controllers
+// would create when scaling up the given MachineDeployment.
+// The objects are computed from the MachineDeployment templates like the MachineSet controller does, and then
+// submitted to the management cluster with a dry-run create, so they include defaults set by API server, CRD
+// schemas and webhooks; nothing is persisted.
+func (c *clusterctlClient) PreviewMachineDeployment(ctx context.Context, options PreviewMachineDeploymentOptions) ([]unstructured.Unstructured, error) {
+	if options.Name == "" {
+		return nil, errors.New("name of the MachineDeployment to preview must be specified")
+	}
+
+	// gets access to the management cluster
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure this command only runs against management clusters with the current Cluster API contract.
+	if err := clusterClient.ProviderInventory().CheckCAPIContract(ctx); err != nil {
+		return nil, err
+	}
+
+	if options.Namespace == "" {
+		currentNamespace, err := clusterClient.Proxy().CurrentNamespace()
+		if err != nil {
+			return nil, err
+		}
+		if currentNamespace == "" {
+			return nil, errors.New("failed to identify the current namespace. Please specify the namespace where the MachineDeployment exists")
+		}
+		options.Namespace = currentNamespace
+	}
+
+	cl, err := clusterClient.Proxy().NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	md := &clusterv1.MachineDeployment{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: options.Namespace, Name: options.Name}, md); err != nil {
+		return nil, errors.Wrapf(err, "failed to get MachineDeployment %s/%s", options.Namespace, options.Name)
+	}
+
+	machine := previewMachine(md)
+	objs := []unstructured.Unstructured{}
+
+	if md.Spec.Template.Spec.Bootstrap.ConfigRef != nil {
+		bootstrapConfig, err := previewFromTemplate(ctx, cl, md.Spec.Template.Spec.Bootstrap.ConfigRef, machine)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to preview bootstrap config from %s %s", md.Spec.Template.Spec.Bootstrap.ConfigRef.Kind, md.Spec.Template.Spec.Bootstrap.ConfigRef.Name)
+		}
+		machine.Spec.Bootstrap.ConfigRef = external.GetObjectReference(bootstrapConfig)
+		objs = append(objs, *bootstrapConfig)
+	}
+
+	infraMachine, err := previewFromTemplate(ctx, cl, &md.Spec.Template.Spec.InfrastructureRef, machine)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to preview infrastructure machine from %s %s", md.Spec.Template.Spec.InfrastructureRef.Kind, md.Spec.Template.Spec.InfrastructureRef.Name)
+	}
+	machine.Spec.InfrastructureRef = *external.GetObjectReference(infraMachine)
+	objs = append(objs, *infraMachine)
+
+	if err := cl.Create(ctx, machine, client.DryRunAll); err != nil {
+		return nil, errors.Wrapf(err, "failed to preview Machine for MachineDeployment %s/%s", md.Namespace, md.Name)
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(machine)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert Machine to unstructured")
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(clusterv1.GroupVersion.WithKind("Machine"))
+	u.SetManagedFields(nil)
+	objs = append(objs, *u)
+
+	return objs, nil
+}
+
+// previewMachine computes the Machine the MachineSet controller would create for the given MachineDeployment.
+// NOTE: The labels identifying the MachineSet are not set, because the MachineSet might not exist yet.
+func previewMachine(md *clusterv1.MachineDeployment) *clusterv1.Machine {
+	machine := &clusterv1.Machine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Machine",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        names.SimpleNameGenerator.GenerateName(fmt.Sprintf("%s-", md.Name)),
+			Namespace:   md.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: *md.Spec.Template.Spec.DeepCopy(),
+	}
+	machine.Spec.ClusterName = md.Spec.ClusterName
+	machine.Spec.InfrastructureRef = corev1.ObjectReference{}
+	machine.Spec.Bootstrap.ConfigRef = nil
+
+	for k, v := range md.Spec.Template.Labels {
+		machine.Labels[k] = v
+	}
+	machine.Labels[clusterv1.MachineDeploymentNameLabel] = md.Name
+	machine.Labels[clusterv1.ClusterNameLabel] = md.Spec.ClusterName
+	for k, v := range md.Spec.Template.Annotations {
+		machine.Annotations[k] = v
+	}
+
+	return machine
+}
+
+// previewFromTemplate generates an object from the template the same way the MachineSet controller does,
+// and submits it with a dry-run create.
+func previewFromTemplate(ctx context.Context, c client.Client, templateRef *corev1.ObjectReference, machine *clusterv1.Machine) (*unstructured.Unstructured, error) {
+	template, err := external.Get(ctx, c, templateRef, machine.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := external.GenerateTemplate(&external.GenerateTemplateInput{
+		Template:    template,
+		TemplateRef: templateRef,
+		Namespace:   machine.Namespace,
+		Name:        machine.Name,
+		ClusterName: machine.Spec.ClusterName,
+		Labels:      machine.Labels,
+		Annotations: machine.Annotations,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Create(ctx, obj, client.DryRunAll); err != nil {
+		return nil, err
+	}
+	obj.SetManagedFields(nil)
+
+	return obj, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+func Test_clusterctlClient_PreviewMachineDeployment(t *testing.T) {
+	ctx := context.Background()
+
+	configClient := newFakeConfig(ctx)
+	kubeconfig := cluster.Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}
+
+	infraTemplate := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+		"kind":       "PreviewMachineTemplate",
+		"metadata": map[string]interface{}{
+			"namespace": "ns1",
+			"name":      "infra-template",
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"image": "foo",
+				},
+			},
+		},
+	}}
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "md"},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: "cluster1",
+			Template: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{
+					Labels:      map[string]string{"foo": "bar"},
+					Annotations: map[string]string{"annotation": "baz"},
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName: "cluster1",
+					Version:     ptr.To("v1.31.0"),
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To("bootstrap-data"),
+					},
+					InfrastructureRef: corev1.ObjectReference{
+						APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+						Kind:       "PreviewMachineTemplate",
+						Name:       "infra-template",
+					},
+				},
+			},
+		},
+	}
+
+	clusterClient := newFakeCluster(kubeconfig, configClient).WithObjs(md, infraTemplate)
+	clusterClient.fakeProxy.WithNamespace("ns1").WithFakeCAPISetup()
+	client := newFakeClient(ctx, configClient).WithCluster(clusterClient)
+
+	t.Run("returns error if the name is not set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := client.PreviewMachineDeployment(ctx, PreviewMachineDeploymentOptions{Kubeconfig: Kubeconfig(kubeconfig)})
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("returns error if unable to get client for mgmt cluster", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := fakeEmptyCluster().PreviewMachineDeployment(ctx, PreviewMachineDeploymentOptions{Name: "md"})
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("returns error if the MachineDeployment does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := client.PreviewMachineDeployment(ctx, PreviewMachineDeploymentOptions{Kubeconfig: Kubeconfig(kubeconfig), Name: "does-not-exist"})
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("returns the InfraMachine and the Machine", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objs, err := client.PreviewMachineDeployment(ctx, PreviewMachineDeploymentOptions{Kubeconfig: Kubeconfig(kubeconfig), Name: "md"})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(objs).To(HaveLen(2))
+
+		infraMachine := objs[0]
+		g.Expect(infraMachine.GetKind()).To(Equal("PreviewMachine"))
+		g.Expect(infraMachine.GetLabels()).To(HaveKeyWithValue("foo", "bar"))
+		g.Expect(infraMachine.GetLabels()).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "cluster1"))
+		g.Expect(infraMachine.GetAnnotations()).To(HaveKeyWithValue("annotation", "baz"))
+		image, _, _ := unstructured.NestedString(infraMachine.Object, "spec", "image")
+		g.Expect(image).To(Equal("foo"))
+
+		machine := objs[1]
+		g.Expect(machine.GetKind()).To(Equal("Machine"))
+		g.Expect(machine.GetName()).To(Equal(infraMachine.GetName()))
+		g.Expect(machine.GetLabels()).To(HaveKeyWithValue(clusterv1.MachineDeploymentNameLabel, "md"))
+		infraRefName, _, _ := unstructured.NestedString(machine.Object, "spec", "infrastructureRef", "name")
+		g.Expect(infraRefName).To(Equal(infraMachine.GetName()))
+
+		// Nothing is persisted.
+		c, err := clusterClient.Proxy().NewClient(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+		machines := &clusterv1.MachineList{}
+		g.Expect(c.List(ctx, machines)).To(Succeed())
+		g.Expect(machines.Items).To(BeEmpty())
+	})
+}
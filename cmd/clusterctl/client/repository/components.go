@@ -21,6 +21,7 @@ import (
 	"sort"
 	"strings"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/pkg/errors"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
@@ -29,6 +30,9 @@ import (
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
@@ -182,6 +186,9 @@ type ComponentsInput struct {
 	Processor    yaml.Processor
 	RawYaml      []byte
 	Options      ComponentsOptions
+	// Patches are applied to the objects with the same apiVersion, kind, name and, if set in the patch, namespace,
+	// e.g. for changing the resource limits of a provider's Deployment; see applyPatches for the patch semantics.
+	Patches []unstructured.Unstructured
 }
 
 // NewComponents returns a new objects embedding a component YAML file
@@ -190,9 +197,10 @@ type ComponentsInput struct {
 // from the provider repositories:
 // 1. Checks for all the variables in the component YAML file and replace with corresponding config values
 // 2. The variables replacement can be skipped using the SkipTemplateProcess flag in the input options
-// 3. Ensure all the provider components are deployed in the target namespace (apply only to namespaced objects)
-// 4. Ensure all the ClusterRoleBinding which are referencing namespaced objects have the name prefixed with the namespace name
-// 5. Adds labels to all the components in order to allow easy identification of the provider objects.
+// 3. Applies the patches from the overrides layer, if any, and the image overrides
+// 4. Ensure all the provider components are deployed in the target namespace (apply only to namespaced objects)
+// 5. Ensure all the ClusterRoleBinding which are referencing namespaced objects have the name prefixed with the namespace name
+// 6. Adds labels to all the components in order to allow easy identification of the provider objects.
 func NewComponents(input ComponentsInput) (Components, error) {
 	variables, err := input.Processor.GetVariables(input.RawYaml)
 	if err != nil {
@@ -215,6 +223,12 @@ func NewComponents(input ComponentsInput) (Components, error) {
 		return nil, errors.Wrap(err, "failed to parse yaml")
 	}
 
+	// Apply patches, if defined
+	objs, err = applyPatches(objs, input.Patches)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply patches")
+	}
+
 	// Apply image overrides, if defined
 	objs, err = util.FixImages(objs, func(image string) (string, error) {
 		return input.ConfigClient.ImageMeta().AlterImage(input.Provider.ManifestLabel(), image)
@@ -286,6 +300,61 @@ func NewComponents(input ComponentsInput) (Components, error) {
 	}, nil
 }
 
+// applyPatches applies each patch to the objects with the same apiVersion, kind, name and, if set in the patch, namespace.
+// Patches for Kubernetes built-in kinds, e.g. Deployments, are applied with strategic merge patch semantics, so lists like
+// containers are merged by name, while patches for other kinds are applied with JSON merge patch semantics.
+// An error is returned if a patch does not match any object, so typos in the patches are surfaced instead of being silently ignored.
+func applyPatches(objs, patches []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	for _, p := range patches {
+		if p.GetAPIVersion() == "" || p.GetKind() == "" || p.GetName() == "" {
+			return nil, errors.New("invalid patch: apiVersion, kind and metadata.name must be set")
+		}
+
+		patch, err := p.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal patch for %s %s", p.GetKind(), p.GetName())
+		}
+
+		matched := false
+		for i := range objs {
+			o := &objs[i]
+			if o.GetAPIVersion() != p.GetAPIVersion() || o.GetKind() != p.GetKind() || o.GetName() != p.GetName() {
+				continue
+			}
+			if p.GetNamespace() != "" && o.GetNamespace() != p.GetNamespace() {
+				continue
+			}
+
+			original, err := o.MarshalJSON()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to marshal %s %s", o.GetKind(), o.GetName())
+			}
+			patched, err := mergePatch(o.GroupVersionKind(), original, patch)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to apply patch to %s %s", o.GetKind(), o.GetName())
+			}
+			if err := o.UnmarshalJSON(patched); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal patched %s %s", o.GetKind(), o.GetName())
+			}
+			matched = true
+		}
+		if !matched {
+			return nil, errors.Errorf("failed to apply patch for %s %s: no matching object found", p.GetKind(), p.GetName())
+		}
+	}
+	return objs, nil
+}
+
+// mergePatch applies the patch to the original object using strategic merge patch for Kubernetes built-in kinds and
+// JSON merge patch otherwise.
+func mergePatch(gvk schema.GroupVersionKind, original, patch []byte) ([]byte, error) {
+	dataStruct, err := clientgoscheme.Scheme.New(gvk)
+	if err != nil {
+		return jsonpatch.MergePatch(original, patch)
+	}
+	return strategicpatch.StrategicMergePatch(original, patch, dataStruct)
+}
+
 // inspectTargetNamespace identifies the name of the namespace object contained in the components YAML, if any.
 // In case more than one Namespace object is identified, an error is returned.
 func inspectTargetNamespace(objs []unstructured.Unstructured) (string, error) {
@@ -65,7 +65,22 @@ func (f *componentsClient) Get(ctx context.Context, options ComponentsOptions) (
 	if err != nil {
 		return nil, err
 	}
-	return NewComponents(ComponentsInput{f.provider, f.configClient, f.processor, file, options})
+	patches, err := getLocalOverridePatches(&newOverrideInput{
+		configVariablesClient: f.configClient.Variables(),
+		provider:              f.provider,
+		version:               options.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewComponents(ComponentsInput{
+		Provider:     f.provider,
+		ConfigClient: f.configClient,
+		Processor:    f.processor,
+		RawYaml:      file,
+		Options:      options,
+		Patches:      patches,
+	})
 }
 
 func (f *componentsClient) getRawBytes(ctx context.Context, options *ComponentsOptions) ([]byte, error) {
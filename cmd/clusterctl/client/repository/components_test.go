@@ -687,6 +687,194 @@ func Test_addCommonLabels(t *testing.T) {
 	}
 }
 
+func Test_applyPatches(t *testing.T) {
+	deployment := func() unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":      "manager",
+					"namespace": "system",
+				},
+				"spec": map[string]interface{}{
+					"replicas": int64(1),
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"priorityClassName": "high",
+							"containers": []interface{}{
+								map[string]interface{}{
+									"name":  "manager",
+									"image": "registry.k8s.io/cluster-api/manager:v1.0.0",
+									"args":  []interface{}{"--leader-elect"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		patches []unstructured.Unstructured
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:    "no patches",
+			patches: nil,
+			want:    deployment().Object,
+		},
+		{
+			name: "patch matching object",
+			patches: []unstructured.Unstructured{
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "apps/v1",
+						"kind":       "Deployment",
+						"metadata": map[string]interface{}{
+							"name": "manager",
+						},
+						"spec": map[string]interface{}{
+							"replicas": int64(2),
+							"template": map[string]interface{}{
+								"spec": map[string]interface{}{
+									"priorityClassName": nil,
+								},
+							},
+						},
+					},
+				},
+			},
+			want: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":      "manager",
+					"namespace": "system",
+				},
+				"spec": map[string]interface{}{
+					"replicas": int64(2),
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{
+									"name":  "manager",
+									"image": "registry.k8s.io/cluster-api/manager:v1.0.0",
+									"args":  []interface{}{"--leader-elect"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "patch merging containers by name",
+			patches: []unstructured.Unstructured{
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "apps/v1",
+						"kind":       "Deployment",
+						"metadata": map[string]interface{}{
+							"name":      "manager",
+							"namespace": "system",
+						},
+						"spec": map[string]interface{}{
+							"template": map[string]interface{}{
+								"spec": map[string]interface{}{
+									"containers": []interface{}{
+										map[string]interface{}{
+											"name": "manager",
+											"resources": map[string]interface{}{
+												"limits": map[string]interface{}{
+													"memory": "1Gi",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":      "manager",
+					"namespace": "system",
+				},
+				"spec": map[string]interface{}{
+					"replicas": int64(1),
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"priorityClassName": "high",
+							"containers": []interface{}{
+								map[string]interface{}{
+									"name":  "manager",
+									"image": "registry.k8s.io/cluster-api/manager:v1.0.0",
+									"args":  []interface{}{"--leader-elect"},
+									"resources": map[string]interface{}{
+										"limits": map[string]interface{}{
+											"memory": "1Gi",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "fails if patch does not match any object",
+			patches: []unstructured.Unstructured{
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "apps/v1",
+						"kind":       "Deployment",
+						"metadata": map[string]interface{}{
+							"name":      "manager",
+							"namespace": "another-namespace",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if patch has no name",
+			patches: []unstructured.Unstructured{
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "apps/v1",
+						"kind":       "Deployment",
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := applyPatches([]unstructured.Unstructured{deployment()}, tt.patches)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(HaveLen(1))
+			g.Expect(got[0].Object).To(BeComparableTo(tt.want))
+		})
+	}
+}
+
 func TestAlterComponents(t *testing.T) {
 	c := &components{
 		targetNamespace: "test-ns",
@@ -26,14 +26,17 @@ import (
 	"github.com/adrg/xdg"
 	"github.com/drone/envsubst/v2"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
 )
 
 const (
-	overrideFolder    = "overrides"
-	overrideFolderKey = "overridesFolder"
+	overrideFolder        = "overrides"
+	overrideFolderKey     = "overridesFolder"
+	overridePatchesFolder = "patches"
 )
 
 // Overrider provides behavior to determine the overrides layer.
@@ -135,3 +138,46 @@ func getLocalOverride(info *newOverrideInput) ([]byte, error) {
 	// blocks for any other error
 	return nil, err
 }
+
+// getLocalOverridePatches returns the patches defined in the patches folder of the local overrides for a provider version, if any.
+// Patches are read from all the YAML files in the folder, in lexical order.
+func getLocalOverridePatches(info *newOverrideInput) ([]unstructured.Unstructured, error) {
+	log := logf.Log
+
+	patchesInfo := *info
+	patchesInfo.filePath = overridePatchesFolder
+	patchesPath, err := newOverride(&patchesInfo).Path()
+	if err != nil {
+		return nil, err
+	}
+	log.V(5).Info("Potential override patches folder", "searchFolder", patchesPath, "provider", info.provider.ManifestLabel(), "version", info.version)
+
+	entries, err := os.ReadDir(patchesPath)
+	if err != nil {
+		// if the patches folder does not exist, return (so the components are used as they are)
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read local override patches from %s", patchesPath)
+	}
+
+	patches := []unstructured.Unstructured{}
+	for _, entry := range entries {
+		if entry.IsDir() || (filepath.Ext(entry.Name()) != ".yaml" && filepath.Ext(entry.Name()) != ".yml") {
+			continue
+		}
+
+		patchPath := filepath.Join(patchesPath, entry.Name())
+		content, err := os.ReadFile(patchPath) //nolint:gosec
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read local override patch %s", patchPath)
+		}
+		objs, err := utilyaml.ToUnstructured(content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse local override patch %s", patchPath)
+		}
+		log.Info("Using", "override patch", entry.Name(), "provider", info.provider.ManifestLabel(), "version", info.version)
+		patches = append(patches, objs...)
+	}
+	return patches, nil
+}
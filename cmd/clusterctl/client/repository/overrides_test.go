@@ -124,3 +124,43 @@ func TestGetLocalOverrides(t *testing.T) {
 		g.Expect(err).ToNot(HaveOccurred())
 	})
 }
+
+func TestGetLocalOverridePatches(t *testing.T) {
+	t.Run("returns patches from the patches folder", func(t *testing.T) {
+		g := NewWithT(t)
+
+		tmpDir := t.TempDir()
+
+		createLocalTestProviderFile(t, tmpDir, "infrastructure-myinfra/v1.0.1/patches/b.yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b")
+		createLocalTestProviderFile(t, tmpDir, "infrastructure-myinfra/v1.0.1/patches/a.yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: a")
+		createLocalTestProviderFile(t, tmpDir, "infrastructure-myinfra/v1.0.1/patches/README.md", "not a patch")
+
+		info := &newOverrideInput{
+			configVariablesClient: test.NewFakeVariableClient().WithVar(overrideFolderKey, tmpDir),
+			provider:              config.NewProvider("myinfra", "", clusterctlv1.InfrastructureProviderType),
+			version:               "v1.0.1",
+		}
+
+		patches, err := getLocalOverridePatches(info)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(patches).To(HaveLen(3))
+		g.Expect(patches[0].GetKind()).To(Equal("ConfigMap"))
+		g.Expect(patches[0].GetName()).To(Equal("a"))
+		g.Expect(patches[1].GetKind()).To(Equal("Secret"))
+		g.Expect(patches[2].GetName()).To(Equal("b"))
+	})
+
+	t.Run("doesn't return error if the patches folder does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		info := &newOverrideInput{
+			configVariablesClient: test.NewFakeVariableClient().WithVar(overrideFolderKey, "do-not-exist"),
+			provider:              config.NewProvider("myinfra", "", clusterctlv1.InfrastructureProviderType),
+			version:               "v1.0.1",
+		}
+
+		patches, err := getLocalOverridePatches(info)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(patches).To(BeEmpty())
+	})
+}
@@ -123,6 +123,10 @@ type ApplyUpgradeOptions struct {
 
 	// WaitProviderTimeout sets the timeout per provider upgrade.
 	WaitProviderTimeout time.Duration
+
+	// PauseClusters instructs the upgrade apply command to pause all the Clusters while upgrading the providers,
+	// and to resume them once the upgraded providers are ready. It implies WaitProviders.
+	PauseClusters bool
 }
 
 func (c *clusterctlClient) ApplyUpgrade(ctx context.Context, options ApplyUpgradeOptions) error {
@@ -173,6 +177,7 @@ func (c *clusterctlClient) ApplyUpgrade(ctx context.Context, options ApplyUpgrad
 	opts := cluster.UpgradeOptions{
 		WaitProviders:       options.WaitProviders,
 		WaitProviderTimeout: options.WaitProviderTimeout,
+		PauseClusters:       options.PauseClusters,
 	}
 
 	// If we are upgrading a specific set of providers only, process the providers and call ApplyCustomPlan.
@@ -139,11 +139,11 @@ func runDelete() error {
 		(len(dd.addonProviders) > 0)
 
 	if dd.deleteAll && hasProviderNames {
-		return errors.New("The --all flag can't be used in combination with --core, --bootstrap, --control-plane, --infrastructure, --ipam, --extension, --addon")
+		return errors.New("The --all flag can't be used in combination with --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon")
 	}
 
 	if !dd.deleteAll && !hasProviderNames {
-		return errors.New("At least one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --extension, --addon should be specified or the --all flag should be set")
+		return errors.New("At least one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon should be specified or the --all flag should be set")
 	}
 
 	return c.Delete(ctx, client.DeleteOptions{
@@ -93,6 +93,9 @@ var describeClusterClusterCmd = &cobra.Command{
 		# e.g. un-group all the machines with Ready=true instead of showing a single group node.
 		clusterctl describe cluster test-1 --grouping=false
 
+		# Describe the cluster named test-1 showing the MachineSets of each MachineDeployment and the Machines they own.
+		clusterctl describe cluster test-1 --show-machinesets
+
 		# Describe the cluster named test-1 showing the MachineInfrastructure and BootstrapConfig objects
 		# also when their status is the same as the status of the corresponding machine object.
 		clusterctl describe cluster test-1 --echo`),
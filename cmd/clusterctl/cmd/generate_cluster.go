@@ -44,6 +44,7 @@ type generateClusterOptions struct {
 	configMapDataKey   string
 
 	listVariables bool
+	dryRun        bool
 
 	output string
 }
@@ -91,7 +92,10 @@ var generateClusterClusterCmd = &cobra.Command{
 		clusterctl generate cluster my-cluster --from ~/workspace/cluster-template.yaml
 
 		# Prints the list of variables required by the yaml file for creating workload cluster.
-		clusterctl generate cluster my-cluster --list-variables`),
+		clusterctl generate cluster my-cluster --list-variables
+
+		# Validates the generated objects against the management cluster with a server-side dry-run before writing them.
+		clusterctl generate cluster my-cluster --dry-run`),
 
 	Args: func(_ *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -143,8 +147,24 @@ func init() {
 	generateClusterClusterCmd.Flags().BoolVar(&gc.listVariables, "list-variables", false,
 		"Returns the list of variables expected by the template instead of the template yaml")
 	generateClusterClusterCmd.Flags().StringVar(&gc.output, "write-to", "", "Specify the output file to write the template to, defaults to STDOUT if the flag is not set")
+	generateClusterClusterCmd.Flags().BoolVar(&gc.dryRun, "dry-run", false,
+		"Validate the generated objects against the management cluster with a server-side dry-run create before writing the template; the target namespace must exist")
 
 	generateCmd.AddCommand(generateClusterClusterCmd)
+
+	// clusterctl config cluster is kept for users coming from older releases; it shares flags and behavior with
+	// clusterctl generate cluster and it is going to be removed in v1.11.
+	configClusterCmd.Flags().AddFlagSet(generateClusterClusterCmd.Flags())
+	configCmd.AddCommand(configClusterCmd)
+}
+
+var configClusterCmd = &cobra.Command{
+	Use:        "cluster NAME",
+	Short:      "Generate templates for creating workload clusters",
+	Long:       generateClusterClusterCmd.Long,
+	Deprecated: "use \"clusterctl generate cluster\" instead; this command will be removed in v1.11.",
+	Args:       generateClusterClusterCmd.Args,
+	RunE:       generateClusterClusterCmd.RunE,
 }
 
 func runGenerateClusterTemplate(cmd *cobra.Command, name string) error {
@@ -161,6 +181,7 @@ func runGenerateClusterTemplate(cmd *cobra.Command, name string) error {
 		TargetNamespace:   gc.targetNamespace,
 		KubernetesVersion: gc.kubernetesVersion,
 		ListVariablesOnly: gc.listVariables,
+		DryRun:            gc.dryRun,
 	}
 
 	if cmd.Flags().Changed("control-plane-machine-count") {
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+func Test_configClusterCmd(t *testing.T) {
+	g := NewWithT(t)
+
+	cmd, args, err := RootCmd.Find([]string{"config", "cluster", "my-cluster"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cmd).To(BeIdenticalTo(configClusterCmd))
+	g.Expect(args).To(Equal([]string{"my-cluster"}))
+	g.Expect(cmd.Deprecated).To(ContainSubstring("clusterctl generate cluster"))
+
+	// The deprecated alias must support the same flags of clusterctl generate cluster.
+	generateClusterClusterCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		g.Expect(configClusterCmd.Flags().Lookup(flag.Name)).To(BeIdenticalTo(flag), "flag %q is missing", flag.Name)
+	})
+	g.Expect(configClusterCmd.Args(configClusterCmd, []string{})).ToNot(Succeed())
+}
@@ -18,13 +18,17 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/scaffold"
+	"sigs.k8s.io/cluster-api/version"
 )
 
 type generateProvidersOptions struct {
@@ -39,6 +43,10 @@ type generateProvidersOptions struct {
 	textOutput               bool
 	raw                      bool
 	outputFile               string
+	kind                     string
+	name                     string
+	module                   string
+	outputDirectory          string
 }
 
 var gpo = &generateProvidersOptions{}
@@ -53,7 +61,11 @@ var generateProviderCmd = &cobra.Command{
 		clusterctl fetches the provider components from the provider repository and performs variable substitution.
 		
 		Variable values are either sourced from the clusterctl config file or
-		from environment variables`),
+		from environment variables.
+
+		When --kind is set, clusterctl instead scaffolds the repository of a new provider, including the API types,
+		the controllers, the webhooks and the tests verifying the rules of the Cluster API contract.
+		Only infrastructure providers can be scaffolded.`),
 
 	Example: templates.Examples(`
 		# Generates a yaml file for creating provider with variable values using
@@ -73,9 +85,18 @@ var generateProviderCmd = &cobra.Command{
 
 		# Generates a yaml file for creating provider for a specific version.
 		# No variables will be processed and substituted using this flag
-		clusterctl generate provider --infrastructure aws:v0.4.1 --raw`),
+		clusterctl generate provider --infrastructure aws:v0.4.1 --raw
+
+		# Scaffolds a new infrastructure provider named foo in the current directory.
+		clusterctl generate provider --kind infrastructure --name foo
+
+		# Scaffolds a new infrastructure provider named foo with a custom Go module in the cluster-api-provider-foo directory.
+		clusterctl generate provider --kind infrastructure --name foo --module github.com/foo/cluster-api-provider-foo --output-directory cluster-api-provider-foo`),
 
 	RunE: func(*cobra.Command, []string) error {
+		if gpo.kind != "" {
+			return runScaffoldProvider()
+		}
 		return runGenerateProviderComponents()
 	},
 }
@@ -104,6 +125,22 @@ func init() {
 
 	generateProviderCmd.Flags().StringVar(&gpo.outputFile, "write-to", "", "Specify the output file to write the template to, defaults to STDOUT if the flag is not set")
 
+	generateProviderCmd.Flags().StringVar(&gpo.kind, "kind", "",
+		"Scaffold a new provider of the given kind instead of generating the components of an existing provider. Only infrastructure is supported.")
+	generateProviderCmd.Flags().StringVar(&gpo.name, "name", "",
+		"The name of the provider to scaffold (e.g. foo). Used with --kind.")
+	generateProviderCmd.Flags().StringVar(&gpo.module, "module", "",
+		"The Go module of the provider to scaffold. Used with --kind. Defaults to github.com/example/cluster-api-provider-<name>.")
+	generateProviderCmd.Flags().StringVar(&gpo.outputDirectory, "output-directory", ".",
+		"The directory where the provider is scaffolded. Used with --kind. Existing files are never overwritten.")
+	generateProviderCmd.MarkFlagsMutuallyExclusive("kind", "core")
+	generateProviderCmd.MarkFlagsMutuallyExclusive("kind", "infrastructure")
+	generateProviderCmd.MarkFlagsMutuallyExclusive("kind", "bootstrap")
+	generateProviderCmd.MarkFlagsMutuallyExclusive("kind", "control-plane")
+	generateProviderCmd.MarkFlagsMutuallyExclusive("kind", "ipam")
+	generateProviderCmd.MarkFlagsMutuallyExclusive("kind", "runtime-extension")
+	generateProviderCmd.MarkFlagsMutuallyExclusive("kind", "addon")
+
 	generateCmd.AddCommand(generateProviderCmd)
 }
 
@@ -136,54 +173,96 @@ func runGenerateProviderComponents() error {
 	return printYamlOutput(components, gpo.outputFile)
 }
 
+func runScaffoldProvider() error {
+	if gpo.name == "" {
+		return errors.New("--name must be set when scaffolding a new provider")
+	}
+
+	kinds := map[string]clusterctlv1.ProviderType{
+		"core":              clusterctlv1.CoreProviderType,
+		"bootstrap":         clusterctlv1.BootstrapProviderType,
+		"control-plane":     clusterctlv1.ControlPlaneProviderType,
+		"infrastructure":    clusterctlv1.InfrastructureProviderType,
+		"ipam":              clusterctlv1.IPAMProviderType,
+		"runtime-extension": clusterctlv1.RuntimeExtensionProviderType,
+		"addon":             clusterctlv1.AddonProviderType,
+	}
+	kind, ok := kinds[gpo.kind]
+	if !ok {
+		return errors.Errorf("invalid --kind %q, only infrastructure is supported", gpo.kind)
+	}
+
+	// Make the scaffolded provider depend on the Cluster API release matching clusterctl, if any.
+	clusterAPIVersion := ""
+	if v, err := semver.ParseTolerant(version.Get().GitVersion); err == nil && len(v.Pre) == 0 && len(v.Build) == 0 {
+		clusterAPIVersion = "v" + v.String()
+	}
+
+	files, err := scaffold.Generate(scaffold.Options{
+		Kind:              kind,
+		Name:              gpo.name,
+		Module:            gpo.module,
+		ClusterAPIVersion: clusterAPIVersion,
+	})
+	if err != nil {
+		return err
+	}
+	if err := scaffold.Write(gpo.outputDirectory, files); err != nil {
+		return err
+	}
+
+	fmt.Printf("Provider %s scaffolded in %s, run `go mod tidy` and `make generate` to complete the setup\n", gpo.name, gpo.outputDirectory)
+	return nil
+}
+
 // parseProvider parses command line flags and returns the provider name and type.
 func parseProvider() (string, clusterctlv1.ProviderType, error) {
 	providerName := gpo.coreProvider
 	providerType := clusterctlv1.CoreProviderType
 	if gpo.bootstrapProvider != "" {
 		if providerName != "" {
-			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --extension, --addon should be set")
+			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon should be set")
 		}
 		providerName = gpo.bootstrapProvider
 		providerType = clusterctlv1.BootstrapProviderType
 	}
 	if gpo.controlPlaneProvider != "" {
 		if providerName != "" {
-			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --extension, --addon should be set")
+			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon should be set")
 		}
 		providerName = gpo.controlPlaneProvider
 		providerType = clusterctlv1.ControlPlaneProviderType
 	}
 	if gpo.infrastructureProvider != "" {
 		if providerName != "" {
-			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --extension, --addon should be set")
+			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon should be set")
 		}
 		providerName = gpo.infrastructureProvider
 		providerType = clusterctlv1.InfrastructureProviderType
 	}
 	if gpo.ipamProvider != "" {
 		if providerName != "" {
-			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --extension, --addon should be set")
+			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon should be set")
 		}
 		providerName = gpo.ipamProvider
 		providerType = clusterctlv1.IPAMProviderType
 	}
 	if gpo.runtimeExtensionProvider != "" {
 		if providerName != "" {
-			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --extension, --addon should be set")
+			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon should be set")
 		}
 		providerName = gpo.runtimeExtensionProvider
 		providerType = clusterctlv1.RuntimeExtensionProviderType
 	}
 	if gpo.addonProvider != "" {
 		if providerName != "" {
-			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --extension, --addon should be set")
+			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon should be set")
 		}
 		providerName = gpo.addonProvider
 		providerType = clusterctlv1.AddonProviderType
 	}
 	if providerName == "" {
-		return "", "", errors.New("at least one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --extension, --addon should be set")
+		return "", "", errors.New("at least one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon should be set")
 	}
 
 	return providerName, providerType, nil
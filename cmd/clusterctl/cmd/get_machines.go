@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+const (
+	// MachinesOutputTable is an option used to print the machine inventory as a table.
+	MachinesOutputTable = "table"
+	// MachinesOutputCSV is an option used to print the machine inventory in csv format.
+	MachinesOutputCSV = "csv"
+	// MachinesOutputJSON is an option used to print the machine inventory in json format.
+	MachinesOutputJSON = "json"
+)
+
+var (
+	// MachinesOutputs is a list of valid machine inventory outputs.
+	MachinesOutputs = []string{MachinesOutputTable, MachinesOutputCSV, MachinesOutputJSON}
+)
+
+type getMachinesOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	namespace         string
+	allNamespaces     bool
+	output            string
+}
+
+var gm = &getMachinesOptions{}
+
+var getMachinesCmd = &cobra.Command{
+	Use:   "machines",
+	Args:  cobra.NoArgs,
+	Short: "Gets an inventory of the Machines in a management cluster",
+	Long: templates.LongDesc(`
+		Gets a flat inventory of the Machines in a management cluster, including the
+		Cluster they belong to, phase, version, provider ID, addresses, Node and age.`),
+
+	Example: templates.Examples(`
+		# Get the Machines in the current namespace.
+		clusterctl get machines
+
+		# Export the Machines from all the namespaces in csv format.
+		clusterctl get machines --all-namespaces -o csv
+
+		# Export the Machines in a particular namespace in json format.
+		clusterctl get machines --namespace foo -o json`),
+
+	RunE: func(*cobra.Command, []string) error {
+		return runGetMachines(os.Stdout)
+	},
+}
+
+func init() {
+	getMachinesCmd.Flags().StringVarP(&gm.namespace, "namespace", "n", "",
+		"Namespace where the Machines exist. If unspecified, the current namespace will be used.")
+	getMachinesCmd.Flags().BoolVarP(&gm.allNamespaces, "all-namespaces", "A", false,
+		"List the Machines across all namespaces.")
+	getMachinesCmd.Flags().StringVar(&gm.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
+	getMachinesCmd.Flags().StringVar(&gm.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	getMachinesCmd.Flags().StringVarP(&gm.output, "output", "o", MachinesOutputTable,
+		fmt.Sprintf("Output format. Valid values: %v.", MachinesOutputs))
+
+	getCmd.AddCommand(getMachinesCmd)
+}
+
+func runGetMachines(out io.Writer) error {
+	if gm.output != MachinesOutputTable && gm.output != MachinesOutputCSV && gm.output != MachinesOutputJSON {
+		return errors.Errorf("invalid output format %q, valid values: %v", gm.output, MachinesOutputs)
+	}
+
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	machines, err := c.GetMachines(ctx, client.GetMachinesOptions{
+		Kubeconfig:    client.Kubeconfig{Path: gm.kubeconfig, Context: gm.kubeconfigContext},
+		Namespace:     gm.namespace,
+		AllNamespaces: gm.allNamespaces,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printMachines(out, gm.output, machines, time.Now())
+}
+
+func printMachines(out io.Writer, output string, machines []client.MachineInfo, now time.Time) error {
+	switch output {
+	case MachinesOutputJSON:
+		e := json.NewEncoder(out)
+		e.SetIndent("", "  ")
+		return e.Encode(machines)
+	case MachinesOutputCSV:
+		w := csv.NewWriter(out)
+		if err := w.Write(machineInventoryHeader()); err != nil {
+			return err
+		}
+		for _, m := range machines {
+			if err := w.Write(machineInventoryRow(m, now)); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+		fmt.Fprintln(w, strings.Join(machineInventoryHeader(), "\t"))
+		for _, m := range machines {
+			fmt.Fprintln(w, strings.Join(machineInventoryRow(m, now), "\t"))
+		}
+		return w.Flush()
+	}
+}
+
+func machineInventoryHeader() []string {
+	return []string{"NAMESPACE", "CLUSTER", "NAME", "PHASE", "VERSION", "PROVIDERID", "ADDRESSES", "NODENAME", "AGE"}
+}
+
+func machineInventoryRow(m client.MachineInfo, now time.Time) []string {
+	age := ""
+	if !m.CreationTimestamp.IsZero() {
+		age = duration.HumanDuration(now.Sub(m.CreationTimestamp.Time))
+	}
+	return []string{m.Namespace, m.Cluster, m.Name, m.Phase, m.Version, m.ProviderID, strings.Join(m.Addresses, " "), m.NodeName, age}
+}
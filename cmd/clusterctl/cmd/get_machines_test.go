@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+func Test_printMachines(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	machines := []client.MachineInfo{
+		{
+			Cluster:           "cluster1",
+			Namespace:         "ns1",
+			Name:              "m1",
+			Phase:             "Running",
+			Version:           "v1.31.0",
+			ProviderID:        "docker:////m1",
+			Addresses:         []string{"10.0.0.1", "m1.example.com"},
+			NodeName:          "node1",
+			CreationTimestamp: metav1.NewTime(now.Add(-48 * time.Hour)),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "csv",
+			output: MachinesOutputCSV,
+			want: "NAMESPACE,CLUSTER,NAME,PHASE,VERSION,PROVIDERID,ADDRESSES,NODENAME,AGE\n" +
+				"ns1,cluster1,m1,Running,v1.31.0,docker:////m1,10.0.0.1 m1.example.com,node1,2d\n",
+		},
+		{
+			name:   "json",
+			output: MachinesOutputJSON,
+			want: `[
+  {
+    "cluster": "cluster1",
+    "namespace": "ns1",
+    "name": "m1",
+    "phase": "Running",
+    "version": "v1.31.0",
+    "providerID": "docker:////m1",
+    "addresses": [
+      "10.0.0.1",
+      "m1.example.com"
+    ],
+    "nodeName": "node1",
+    "creationTimestamp": "2023-12-31T00:00:00Z"
+  }
+]
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			buf := &bytes.Buffer{}
+			g.Expect(printMachines(buf, tt.output, machines, now)).To(Succeed())
+			g.Expect(buf.String()).To(BeComparableTo(tt.want))
+		})
+	}
+}
+
+func Test_runGetMachines(t *testing.T) {
+	t.Run("returns error for invalid output format", func(t *testing.T) {
+		g := NewWithT(t)
+
+		gm.output = "yaml"
+		defer func() { gm.output = MachinesOutputTable }()
+		g.Expect(runGetMachines(&bytes.Buffer{})).ToNot(Succeed())
+	})
+}
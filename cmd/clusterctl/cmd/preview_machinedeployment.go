@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+type previewMachineDeploymentOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	namespace         string
+}
+
+var pmd = &previewMachineDeploymentOptions{}
+
+var previewMachineDeploymentCmd = &cobra.Command{
+	Use:     "machinedeployment NAME",
+	Aliases: []string{"machinedeployments", "md"},
+	Args:    cobra.ExactArgs(1),
+	Short:   "Preview the objects created when scaling up a MachineDeployment",
+	Long: templates.LongDesc(`
+		Preview the BootstrapConfig, InfraMachine and Machine objects the controllers would create
+		when scaling up a MachineDeployment.
+
+		The objects are computed from the MachineDeployment templates and submitted to the management
+		cluster with a server-side dry-run, so they include the defaults and the changes applied by
+		CRD schemas and webhooks. Nothing is persisted. This can be used to verify the node configuration
+		before scaling up a MachineDeployment.
+
+		The labels identifying the MachineSet and the generated names might differ from the
+		objects which are eventually created.`),
+
+	Example: templates.Examples(`
+		# Preview the objects created when scaling up the MachineDeployment md-0 in the current namespace.
+		clusterctl alpha preview machinedeployment md-0
+
+		# Preview the objects created when scaling up the MachineDeployment md-0 in the namespace foo.
+		clusterctl alpha preview machinedeployment md-0 --namespace foo`),
+
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runPreviewMachineDeployment(os.Stdout, args[0])
+	},
+}
+
+func init() {
+	previewMachineDeploymentCmd.Flags().StringVarP(&pmd.namespace, "namespace", "n", "",
+		"Namespace where the MachineDeployment exists. If unspecified, the current namespace will be used.")
+	previewMachineDeploymentCmd.Flags().StringVar(&pmd.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
+	previewMachineDeploymentCmd.Flags().StringVar(&pmd.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+
+	previewCmd.AddCommand(previewMachineDeploymentCmd)
+}
+
+func runPreviewMachineDeployment(out io.Writer, name string) error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	objs, err := c.PreviewMachineDeployment(ctx, client.PreviewMachineDeploymentOptions{
+		Kubeconfig: client.Kubeconfig{Path: pmd.kubeconfig, Context: pmd.kubeconfigContext},
+		Namespace:  pmd.namespace,
+		Name:       name,
+	})
+	if err != nil {
+		return err
+	}
+
+	yaml, err := utilyaml.FromUnstructured(objs)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert objects to yaml")
+	}
+	_, err = fmt.Fprintln(out, string(yaml))
+	return err
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+// historyOptions is the start of the data required to perform the operation.
+type historyOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	resource          string
+	namespace         string
+}
+
+var historyOpt = &historyOptions{}
+
+var (
+	historyLong = templates.LongDesc(`
+		View the rollout history of a cluster-api resource.
+
+		The change cause of each revision is read from the kubernetes.io/change-cause annotation,
+		which is copied from the resource when the revision is created.`)
+
+	historyExample = templates.Examples(`
+		# View the rollout history of a machinedeployment
+		clusterctl alpha rollout history machinedeployment/my-md-0`)
+)
+
+// NewCmdRolloutHistory returns a Command instance for 'rollout history' sub command.
+func NewCmdRolloutHistory(cfgFile string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "history RESOURCE",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		Short:                 "View the rollout history of a cluster-api resource",
+		Long:                  historyLong,
+		Example:               historyExample,
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runHistory(cfgFile, os.Stdout, args)
+		},
+	}
+	cmd.Flags().StringVar(&historyOpt.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
+	cmd.Flags().StringVar(&historyOpt.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	cmd.Flags().StringVarP(&historyOpt.namespace, "namespace", "n", "", "Namespace where the resource resides. If unspecified, the default namespace will be used.")
+
+	return cmd
+}
+
+func runHistory(cfgFile string, out io.Writer, args []string) error {
+	historyOpt.resource = args[0]
+
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := c.RolloutHistory(ctx, client.RolloutHistoryOptions{
+		Kubeconfig: client.Kubeconfig{Path: historyOpt.kubeconfig, Context: historyOpt.kubeconfigContext},
+		Namespace:  historyOpt.namespace,
+		Resource:   historyOpt.resource,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printHistory(out, revisions)
+}
+
+func printHistory(out io.Writer, revisions []client.RolloutRevision) error {
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "REVISION\tMACHINESET\tVERSION\tCHANGE-CAUSE")
+	for _, r := range revisions {
+		changeCause := r.ChangeCause
+		if changeCause == "" {
+			changeCause = "<none>"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", r.Revision, r.MachineSet, r.Version, changeCause)
+	}
+	return w.Flush()
+}
@@ -40,6 +40,7 @@ type upgradeApplyOptions struct {
 	addonProviders            []string
 	waitProviders             bool
 	waitProviderTimeout       int
+	pauseClusters             bool
 }
 
 var ua = &upgradeApplyOptions{}
@@ -94,6 +95,8 @@ func init() {
 		"Wait for providers to be upgraded.")
 	upgradeApplyCmd.Flags().IntVar(&ua.waitProviderTimeout, "wait-provider-timeout", 5*60,
 		"Wait timeout per provider upgrade in seconds. This value is ignored if --wait-providers is false")
+	upgradeApplyCmd.Flags().BoolVar(&ua.pauseClusters, "pause-clusters", false,
+		"Pause all the Clusters while upgrading the providers, and resume them once the upgraded providers are ready. This implies --wait-providers.")
 }
 
 func runUpgradeApply() error {
@@ -113,10 +116,10 @@ func runUpgradeApply() error {
 		(len(ua.addonProviders) > 0)
 
 	if ua.contract == "" && !hasProviderNames {
-		return errors.New("Either the --contract flag or at least one of the following flags has to be set: --core, --bootstrap, --control-plane, --infrastructure, --ipam, --extension, --addon")
+		return errors.New("Either the --contract flag or at least one of the following flags has to be set: --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon")
 	}
 	if ua.contract != "" && hasProviderNames {
-		return errors.New("The --contract flag can't be used in combination with --core, --bootstrap, --control-plane, --infrastructure, --ipam, --extension, --addon")
+		return errors.New("The --contract flag can't be used in combination with --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon")
 	}
 
 	return c.ApplyUpgrade(ctx, client.ApplyUpgradeOptions{
@@ -131,5 +134,6 @@ func runUpgradeApply() error {
 		AddonProviders:            ua.addonProviders,
 		WaitProviders:             ua.waitProviders,
 		WaitProviderTimeout:       time.Duration(ua.waitProviderTimeout) * time.Second,
+		PauseClusters:             ua.pauseClusters,
 	})
 }
@@ -0,0 +1,288 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scaffold implements the scaffolding of new Cluster API providers.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+)
+
+const (
+	// apiVersion is the version of the API types of the scaffolded provider.
+	apiVersion = "v1alpha1"
+
+	// goVersion is the Go version used by the scaffolded provider.
+	goVersion = "1.22.0"
+
+	// controllerGenVersion and kustomizeVersion are the versions of the tools used by the
+	// Makefile of the scaffolded provider; they should be kept in sync with the ones used by Cluster API.
+	controllerGenVersion = "v0.16.1"
+	kustomizeVersion     = "v5.3.0"
+
+	// group is the API group of the infrastructure providers.
+	group = "infrastructure.cluster.x-k8s.io"
+
+	templatesDir = "templates"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var nameRegex = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// Options are the options for scaffolding a new provider.
+type Options struct {
+	// Kind is the type of the provider to scaffold; only infrastructure providers are supported.
+	Kind clusterctlv1.ProviderType
+
+	// Name is the name of the provider, e.g. "foo". The name is used to derive the name of the
+	// API types, e.g. FooCluster, and the name of the provider in clusterctl, e.g. infrastructure-foo.
+	Name string
+
+	// Module is the Go module of the provider.
+	// If empty, github.com/example/cluster-api-provider-<name> is used.
+	Module string
+
+	// ClusterAPIVersion is the version of Cluster API the provider should depend on.
+	// If empty, the dependency is added by go mod tidy.
+	ClusterAPIVersion string
+}
+
+// File is a file of a scaffolded provider.
+type File struct {
+	// Path is the path of the file, relative to the root of the provider repository.
+	Path string
+
+	// Content is the content of the file.
+	Content []byte
+}
+
+// data is the data used to render the templates.
+type data struct {
+	Name                 string
+	Kind                 string
+	Lower                string
+	Var                  string
+	Module               string
+	Group                string
+	GroupPath            string
+	Version              string
+	ContractVersion      string
+	ClusterAPIVersion    string
+	GoVersion            string
+	ControllerGenVersion string
+	KustomizeVersion     string
+	Year                 int
+	Resource             string
+}
+
+// webhookResources are the API types of the infrastructure provider which get a webhook.
+var webhookResources = []string{"Cluster", "ClusterTemplate", "Machine", "MachineTemplate"}
+
+// Generate returns the files of a new provider.
+func Generate(opts Options) ([]File, error) {
+	if opts.Kind != clusterctlv1.InfrastructureProviderType {
+		return nil, errors.Errorf("scaffolding %q providers is not supported, only %q providers can be scaffolded", opts.Kind, clusterctlv1.InfrastructureProviderType)
+	}
+	if !nameRegex.MatchString(opts.Name) {
+		return nil, errors.Errorf("invalid provider name %q: the name must consist of lower case alphanumeric characters separated by '-', and must start with a letter", opts.Name)
+	}
+
+	kind := ""
+	for _, part := range strings.Split(opts.Name, "-") {
+		kind += strings.ToUpper(part[:1]) + part[1:]
+	}
+	module := opts.Module
+	if module == "" {
+		module = fmt.Sprintf("github.com/example/cluster-api-provider-%s", opts.Name)
+	}
+
+	d := data{
+		Name:                 opts.Name,
+		Kind:                 kind,
+		Lower:                strings.ToLower(kind),
+		Var:                  strings.ToLower(kind[:1]) + kind[1:],
+		Module:               module,
+		Group:                group,
+		GroupPath:            strings.ReplaceAll(group, ".", "-"),
+		Version:              apiVersion,
+		ContractVersion:      clusterv1.GroupVersion.Version,
+		ClusterAPIVersion:    opts.ClusterAPIVersion,
+		GoVersion:            goVersion,
+		ControllerGenVersion: controllerGenVersion,
+		KustomizeVersion:     kustomizeVersion,
+		Year:                 time.Now().Year(),
+	}
+
+	t, err := parseTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	files := []File{}
+	for _, name := range templateNames(t) {
+		switch name {
+		case "webhooks/webhook.go.tmpl":
+			for _, resource := range webhookResources {
+				rd := d
+				rd.Resource = resource
+				f, err := render(t, name, path.Join("internal/webhooks", strings.ToLower(kind+resource)+"_webhook.go"), rd)
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, f)
+			}
+		default:
+			f, err := render(t, name, outputPath(name, d), d)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// Write writes files into dir.
+// Write fails without writing any file if one of the files already exists.
+func Write(dir string, files []File) error {
+	for _, f := range files {
+		p := filepath.Join(dir, filepath.FromSlash(f.Path))
+		if _, err := os.Stat(p); err == nil {
+			return errors.Errorf("failed to scaffold provider: file %s already exists", p)
+		} else if !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to scaffold provider: failed to check if file %s exists", p)
+		}
+	}
+
+	for _, f := range files {
+		p := filepath.Join(dir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return errors.Wrapf(err, "failed to create directory %s", filepath.Dir(p))
+		}
+		if err := os.WriteFile(p, f.Content, 0o600); err != nil {
+			return errors.Wrapf(err, "failed to write file %s", p)
+		}
+	}
+	return nil
+}
+
+func parseTemplates() (*template.Template, error) {
+	t := template.New("").Funcs(template.FuncMap{"lower": strings.ToLower})
+	if _, err := t.New("boilerplate").Parse(boilerplate); err != nil {
+		return nil, errors.Wrap(err, "failed to parse boilerplate template")
+	}
+
+	err := fs.WalkDir(templates, templatesDir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := templates.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(p, templatesDir+"/")
+		if _, err := t.New(name).Parse(string(content)); err != nil {
+			return errors.Wrapf(err, "failed to parse template %s", name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read templates")
+	}
+	return t, nil
+}
+
+// templateNames returns the names of the templates for files, sorted.
+func templateNames(t *template.Template) []string {
+	names := []string{}
+	for _, tt := range t.Templates() {
+		if strings.HasSuffix(tt.Name(), ".tmpl") {
+			names = append(names, tt.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// outputPath returns the path of the file generated from a template.
+func outputPath(name string, d data) string {
+	p := strings.TrimSuffix(name, ".tmpl")
+	dir, file := path.Split(p)
+	switch dir {
+	case "api/":
+		if strings.HasSuffix(file, "_types.go") {
+			file = d.Lower + file
+		}
+		return path.Join("api", d.Version, file)
+	case "controllers/":
+		return path.Join("internal/controllers", d.Lower+file)
+	case "webhooks/":
+		return path.Join("internal/webhooks", file)
+	}
+	return p
+}
+
+func render(t *template.Template, name, outputPath string, d data) (File, error) {
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, d); err != nil {
+		return File{}, errors.Wrapf(err, "failed to render template %s", name)
+	}
+
+	content := buf.Bytes()
+	if strings.HasSuffix(outputPath, ".go") {
+		formatted, err := format.Source(content)
+		if err != nil {
+			return File{}, errors.Wrapf(err, "failed to format %s", outputPath)
+		}
+		content = formatted
+	}
+	return File{Path: outputPath, Content: content}, nil
+}
+
+const boilerplate = `/*
+Copyright {{ .Year }} The {{ .Kind }} provider authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/`
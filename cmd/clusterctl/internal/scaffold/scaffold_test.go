@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "infrastructure provider",
+			opts: Options{Kind: clusterctlv1.InfrastructureProviderType, Name: "foo"},
+			want: map[string][]string{
+				"go.mod":                           {"module github.com/example/cluster-api-provider-foo"},
+				"main.go":                          {`infrav1 "github.com/example/cluster-api-provider-foo/api/v1alpha1"`, `fs.StringSliceVar(&watchNamespace, "namespace"`},
+				"metadata.yaml":                    {"contract: v1beta1"},
+				"api/v1alpha1/foocluster_types.go": {"type FooCluster struct", "ControlPlaneEndpoint clusterv1.APIEndpoint"},
+				"api/v1alpha1/fooclustertemplate_types.go":      {"type FooClusterTemplate struct"},
+				"api/v1alpha1/foomachine_types.go":              {"type FooMachine struct", "ProviderID *string"},
+				"api/v1alpha1/foomachinetemplate_types.go":      {"type FooMachineTemplate struct"},
+				"api/v1alpha1/zz_generated.deepcopy.go":         {"func (in *FooMachine) DeepCopyObject() runtime.Object"},
+				"api/v1alpha1/contract_test.go":                 {"func TestContractFields(t *testing.T)"},
+				"internal/controllers/foocluster_controller.go": {"type FooClusterReconciler struct"},
+				"internal/controllers/foomachine_controller.go": {"type FooMachineReconciler struct"},
+				"internal/webhooks/foomachine_webhook.go":       {"path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-foomachine,"},
+				"config/crd/kustomization.yaml":                 {"cluster.x-k8s.io/v1beta1: v1alpha1", "bases/infrastructure.cluster.x-k8s.io_foomachines.yaml"},
+				"config/default/kustomization.yaml":             {"namespace: capfoo-system", "cluster.x-k8s.io/provider: infrastructure-foo"},
+			},
+		},
+		{
+			name: "infrastructure provider with a multi-word name and a custom module",
+			opts: Options{Kind: clusterctlv1.InfrastructureProviderType, Name: "my-cloud", Module: "example.com/capmc", ClusterAPIVersion: "v1.9.0"},
+			want: map[string][]string{
+				"go.mod":                               {"module example.com/capmc", "require sigs.k8s.io/cluster-api v1.9.0"},
+				"api/v1alpha1/mycloudmachine_types.go": {"type MyCloudMachine struct"},
+				"internal/controllers/mycloudcluster_controller.go": {"type MyCloudClusterReconciler struct"},
+				"config/default/kustomization.yaml":                 {"namePrefix: capmycloud-", "cluster.x-k8s.io/provider: infrastructure-my-cloud"},
+			},
+		},
+		{
+			name:    "fails for unsupported provider types",
+			opts:    Options{Kind: clusterctlv1.BootstrapProviderType, Name: "foo"},
+			wantErr: true,
+		},
+		{
+			name:    "fails for invalid names",
+			opts:    Options{Kind: clusterctlv1.InfrastructureProviderType, Name: "Foo_Bar"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			files, err := Generate(tt.opts)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got := map[string]string{}
+			for _, f := range files {
+				got[f.Path] = string(f.Content)
+			}
+			for path, contents := range tt.want {
+				g.Expect(got).To(HaveKey(path))
+				for _, content := range contents {
+					g.Expect(got[path]).To(ContainSubstring(content), "file %s", path)
+				}
+			}
+			for path, content := range got {
+				g.Expect(content).ToNot(ContainSubstring("<no value>"), "file %s", path)
+				if strings.HasSuffix(path, ".go") {
+					g.Expect(content).To(ContainSubstring("/*\nCopyright"), "file %s", path)
+				}
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	files := []File{
+		{Path: "go.mod", Content: []byte("module foo\n")},
+		{Path: "api/v1alpha1/types.go", Content: []byte("package v1alpha1\n")},
+	}
+	g.Expect(Write(dir, files)).To(Succeed())
+
+	content, err := os.ReadFile(filepath.Join(dir, "api", "v1alpha1", "types.go")) //nolint:gosec
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(content)).To(Equal("package v1alpha1\n"))
+
+	// Existing files are never overwritten.
+	g.Expect(os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module bar\n"), 0o600)).To(Succeed())
+	g.Expect(Write(dir, files)).ToNot(Succeed())
+	content, err = os.ReadFile(filepath.Join(dir, "go.mod")) //nolint:gosec
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(content)).To(Equal("module bar\n"))
+}
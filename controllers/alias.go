@@ -46,6 +46,10 @@ type ClusterReconciler struct {
 	APIReader    client.Reader
 	ClusterCache clustercache.ClusterCache
 
+	// SecretCachingClient is a client which reads Secrets with the cluster name label from the cache.
+	// If not set, Client is used instead.
+	SecretCachingClient client.Client
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
@@ -57,6 +61,7 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		Client:                      r.Client,
 		APIReader:                   r.APIReader,
 		ClusterCache:                r.ClusterCache,
+		SecretCachingClient:         r.SecretCachingClient,
 		WatchFilterValue:            r.WatchFilterValue,
 		RemoteConnectionGracePeriod: r.RemoteConnectionGracePeriod,
 	}).SetupWithManager(ctx, mgr, options)
@@ -72,6 +77,9 @@ type MachineReconciler struct {
 	WatchFilterValue string
 
 	RemoteConditionsGracePeriod time.Duration
+
+	// ReconcileFailureThreshold is the number of consecutive failed reconciles after which a Machine is parked.
+	ReconcileFailureThreshold int
 }
 
 func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -81,6 +89,7 @@ func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		ClusterCache:                r.ClusterCache,
 		WatchFilterValue:            r.WatchFilterValue,
 		RemoteConditionsGracePeriod: r.RemoteConditionsGracePeriod,
+		ReconcileFailureThreshold:   r.ReconcileFailureThreshold,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -206,6 +206,9 @@ type clusterAccessorLockedHealthCheckingState struct {
 	// lastProbeSuccessTimestamp is the time when the health probe was successfully executed last.
 	lastProbeSuccessTimestamp time.Time
 
+	// lastProbeSuccessDuration is the latency of the last successful health probe.
+	lastProbeSuccessDuration time.Duration
+
 	// consecutiveFailures is the number of consecutive health probe failures.
 	consecutiveFailures int
 }
@@ -291,6 +294,7 @@ func (ca *clusterAccessor) Connect(ctx context.Context) (retErr error) {
 		cache:        connection.Cache,
 		watches:      sets.Set[string]{},
 	}
+	setConnectionUp(ca.cluster, true)
 
 	return nil
 }
@@ -317,6 +321,7 @@ func (ca *clusterAccessor) Disconnect(ctx context.Context) {
 	log.Info("Disconnected")
 
 	ca.lockedState.connection = nil
+	setConnectionUp(ca.cluster, false)
 }
 
 // HealthCheck will run a health probe against the cluster's apiserver (a "GET /" call).
@@ -335,7 +340,10 @@ func (ca *clusterAccessor) HealthCheck(ctx context.Context) (bool, bool) {
 	log.V(6).Info("Run health probe")
 
 	// Executing the health probe is intentionally done without a lock to avoid blocking other reconcilers.
+	start := time.Now()
 	_, err := restClient.Get().AbsPath("/").Timeout(ca.config.HealthProbe.Timeout).DoRaw(ctx)
+	duration := time.Since(start)
+	observeHealthProbe(ca.cluster, duration, err)
 
 	ca.lock(ctx)
 	defer ca.unlock(ctx)
@@ -358,6 +366,7 @@ func (ca *clusterAccessor) HealthCheck(ctx context.Context) (bool, bool) {
 	default:
 		ca.lockedState.healthChecking.consecutiveFailures = 0
 		ca.lockedState.healthChecking.lastProbeSuccessTimestamp = ca.lockedState.healthChecking.lastProbeTimestamp
+		ca.lockedState.healthChecking.lastProbeSuccessDuration = duration
 		log.V(6).Info("Health probe succeeded")
 	}
 
@@ -453,6 +462,13 @@ func (ca *clusterAccessor) GetLastProbeSuccessTimestamp(ctx context.Context) tim
 	return ca.lockedState.healthChecking.lastProbeSuccessTimestamp
 }
 
+func (ca *clusterAccessor) GetLastProbeSuccessDuration(ctx context.Context) time.Duration {
+	ca.rLock(ctx)
+	defer ca.rUnlock(ctx)
+
+	return ca.lockedState.healthChecking.lastProbeSuccessDuration
+}
+
 func (ca *clusterAccessor) GetLastProbeTimestamp(ctx context.Context) time.Time {
 	ca.rLock(ctx)
 	defer ca.rUnlock(ctx)
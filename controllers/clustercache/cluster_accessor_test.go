@@ -26,6 +26,7 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -295,6 +296,16 @@ func TestHealthCheck(t *testing.T) {
 			gotTooManyConsecutiveFailures, gotUnauthorizedErrorOccurred := accessor.HealthCheck(ctx)
 			g.Expect(gotTooManyConsecutiveFailures).To(Equal(tt.wantTooManyConsecutiveFailures))
 			g.Expect(gotUnauthorizedErrorOccurred).To(Equal(tt.wantUnauthorizedErrorOccurred))
+
+			// Verify the result of the health probe is reported by the metrics.
+			if tt.connected {
+				defer deleteClusterMetrics(clusterKey)
+				wantProbeSuccess := 0.0
+				if tt.wantConsecutiveFailures == 0 {
+					wantProbeSuccess = 1.0
+				}
+				g.Expect(testutil.ToFloat64(healthProbeSuccess.WithLabelValues(clusterKey.Namespace, clusterKey.Name))).To(Equal(wantProbeSuccess))
+			}
 		})
 	}
 }
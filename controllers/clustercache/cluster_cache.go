@@ -165,6 +165,14 @@ type ClusterCache interface {
 	GetClusterSource(controllerName string, mapFunc func(ctx context.Context, cluster client.Object) []ctrl.Request, opts ...GetClusterSourceOption) source.Source
 }
 
+// ProbeDurationGetter is implemented by ClusterCaches which report the latency of the health probe.
+// Note: This is not part of the ClusterCache interface to not break existing implementations of it;
+// callers should check if a ClusterCache implements ProbeDurationGetter.
+type ProbeDurationGetter interface {
+	// GetLastProbeSuccessDuration returns the latency of the last successful health probe.
+	GetLastProbeSuccessDuration(ctx context.Context, cluster client.ObjectKey) time.Duration
+}
+
 // ErrClusterNotConnected is returned by the ClusterCache when e.g. a Client cannot be returned
 // because there is no connection to the workload cluster.
 var ErrClusterNotConnected = errors.New("connection to the workload cluster is down")
@@ -398,6 +406,16 @@ func (cc *clusterCache) GetLastProbeSuccessTimestamp(ctx context.Context, cluste
 	return accessor.GetLastProbeSuccessTimestamp(ctx)
 }
 
+var _ ProbeDurationGetter = &clusterCache{}
+
+func (cc *clusterCache) GetLastProbeSuccessDuration(ctx context.Context, cluster client.ObjectKey) time.Duration {
+	accessor := cc.getClusterAccessor(cluster)
+	if accessor == nil {
+		return 0
+	}
+	return accessor.GetLastProbeSuccessDuration(ctx)
+}
+
 const (
 	// defaultRequeueAfter is used as a fallback if no other duration should be used.
 	defaultRequeueAfter = 10 * time.Second
@@ -417,6 +435,7 @@ func (cc *clusterCache) Reconcile(ctx context.Context, req reconcile.Request) (r
 			accessor.Disconnect(ctx)
 			cc.deleteClusterAccessor(clusterKey)
 			cc.cleanupClusterSourcesForCluster(clusterKey)
+			deleteClusterMetrics(clusterKey)
 			return ctrl.Result{}, nil
 		}
 
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustercache
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Metrics subsystem used by the ClusterCache.
+const clusterCacheSubsystem = "capi_cluster_cache"
+
+var (
+	// connectionUp reports if the ClusterCache is connected to a workload cluster.
+	connectionUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: clusterCacheSubsystem,
+		Name:      "connection_up",
+		Help:      "Whether the ClusterCache is connected to the workload cluster (1) or not (0), partitioned by cluster namespace and name.",
+	}, []string{"namespace", "cluster"})
+
+	// healthProbeSuccess reports the result of the last health probe against a workload cluster.
+	healthProbeSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: clusterCacheSubsystem,
+		Name:      "health_probe_success",
+		Help:      "Whether the last health probe against the workload cluster apiserver succeeded (1) or not (0), partitioned by cluster namespace and name.",
+	}, []string{"namespace", "cluster"})
+
+	// healthProbeDuration reports the latency of the last health probe against a workload cluster.
+	healthProbeDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: clusterCacheSubsystem,
+		Name:      "health_probe_duration_seconds",
+		Help:      "Latency of the last health probe against the workload cluster apiserver in seconds, partitioned by cluster namespace and name.",
+	}, []string{"namespace", "cluster"})
+)
+
+// RegisterMetrics registers the ClusterCache metrics at the given registerer.
+// NOTE: This must be called by managers which run a ClusterCache, usually with the controller-runtime metrics
+// registry, because the metrics are not registered when this package is imported.
+func RegisterMetrics(registerer prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{connectionUp, healthProbeSuccess, healthProbeDuration} {
+		if err := registerer.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setConnectionUp records if the ClusterCache is connected to a workload cluster.
+func setConnectionUp(cluster client.ObjectKey, up bool) {
+	connectionUp.WithLabelValues(cluster.Namespace, cluster.Name).Set(boolToFloat64(up))
+}
+
+// observeHealthProbe records the result and latency of a health probe against a workload cluster.
+func observeHealthProbe(cluster client.ObjectKey, duration time.Duration, err error) {
+	healthProbeSuccess.WithLabelValues(cluster.Namespace, cluster.Name).Set(boolToFloat64(err == nil))
+	healthProbeDuration.WithLabelValues(cluster.Namespace, cluster.Name).Set(duration.Seconds())
+}
+
+// deleteClusterMetrics deletes the metrics of a workload cluster, e.g. after the Cluster has been deleted.
+func deleteClusterMetrics(cluster client.ObjectKey) {
+	connectionUp.DeleteLabelValues(cluster.Namespace, cluster.Name)
+	healthProbeSuccess.DeleteLabelValues(cluster.Namespace, cluster.Name)
+	healthProbeDuration.DeleteLabelValues(cluster.Namespace, cluster.Name)
+}
+
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
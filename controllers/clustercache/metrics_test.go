@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustercache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRegisterMetrics(t *testing.T) {
+	g := NewWithT(t)
+
+	registry := prometheus.NewRegistry()
+	g.Expect(RegisterMetrics(registry)).To(Succeed())
+
+	// Registering the metrics twice at the same registry must fail.
+	g.Expect(RegisterMetrics(registry)).ToNot(Succeed())
+
+	cluster := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "register-metrics"}
+	setConnectionUp(cluster, true)
+	defer deleteClusterMetrics(cluster)
+	g.Expect(testutil.CollectAndCount(registry, "capi_cluster_cache_connection_up")).To(BeNumerically(">", 0))
+}
+
+func TestClusterMetrics(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "cluster-metrics"}
+	defer deleteClusterMetrics(cluster)
+
+	setConnectionUp(cluster, true)
+	g.Expect(testutil.ToFloat64(connectionUp.WithLabelValues(cluster.Namespace, cluster.Name))).To(Equal(1.0))
+
+	observeHealthProbe(cluster, 250*time.Millisecond, nil)
+	g.Expect(testutil.ToFloat64(healthProbeSuccess.WithLabelValues(cluster.Namespace, cluster.Name))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(healthProbeDuration.WithLabelValues(cluster.Namespace, cluster.Name))).To(Equal(0.25))
+
+	observeHealthProbe(cluster, 2*time.Second, errors.New("probe failed"))
+	g.Expect(testutil.ToFloat64(healthProbeSuccess.WithLabelValues(cluster.Namespace, cluster.Name))).To(Equal(0.0))
+	g.Expect(testutil.ToFloat64(healthProbeDuration.WithLabelValues(cluster.Namespace, cluster.Name))).To(Equal(2.0))
+
+	setConnectionUp(cluster, false)
+	g.Expect(testutil.ToFloat64(connectionUp.WithLabelValues(cluster.Namespace, cluster.Name))).To(Equal(0.0))
+
+	// Deleting the metrics of a cluster must drop all its series.
+	deleteClusterMetrics(cluster)
+	g.Expect(connectionUp.DeleteLabelValues(cluster.Namespace, cluster.Name)).To(BeFalse())
+	g.Expect(healthProbeSuccess.DeleteLabelValues(cluster.Namespace, cluster.Name)).To(BeFalse())
+	g.Expect(healthProbeDuration.DeleteLabelValues(cluster.Namespace, cluster.Name)).To(BeFalse())
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Metrics subsystem used for calls against external objects.
+const externalObjectSubsystem = "capi_external_object"
+
+var (
+	// requestsTotal reports the number of calls against external objects.
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: externalObjectSubsystem,
+		Name:      "requests_total",
+		Help:      "Number of API calls against external objects, partitioned by group, version, kind, verb and result.",
+	}, []string{"group", "version", "kind", "verb", "result"})
+
+	// requestDuration reports the latency of calls against external objects in seconds.
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: externalObjectSubsystem,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of API calls against external objects in seconds, partitioned by group, version, kind and verb.",
+		Buckets:   []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"group", "version", "kind", "verb"})
+)
+
+// RegisterMetrics registers the metrics of calls against external objects at the given registerer,
+// usually the controller-runtime metrics registry.
+// NOTE: The metrics are not registered when this package is imported, so controllers which want
+// to expose them have to call RegisterMetrics while setting up the manager.
+func RegisterMetrics(registerer prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{requestsTotal, requestDuration} {
+		if err := registerer.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// observeRequest records the result and latency of a call against an external object.
+// The error is reduced to its API status reason to keep the label cardinality bounded.
+func observeRequest(gvk schema.GroupVersionKind, verb string, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+		if reason := apierrors.ReasonForError(err); reason != "" {
+			result = string(reason)
+		}
+	}
+
+	requestsTotal.WithLabelValues(gvk.Group, gvk.Version, gvk.Kind, verb, result).Inc()
+	requestDuration.WithLabelValues(gvk.Group, gvk.Version, gvk.Kind, verb).Observe(time.Since(start).Seconds())
+}
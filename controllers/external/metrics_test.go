@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetObservesRequests(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("metrics.test.cluster.x-k8s.io/v1beta1")
+	obj.SetKind("MetricsTestMachine")
+	obj.SetName("found")
+	obj.SetNamespace(metav1.NamespaceDefault)
+	fakeClient := fake.NewClientBuilder().WithObjects(obj).Build()
+
+	success := requestsTotal.WithLabelValues("metrics.test.cluster.x-k8s.io", "v1beta1", "MetricsTestMachine", "get", "success")
+	notFound := requestsTotal.WithLabelValues("metrics.test.cluster.x-k8s.io", "v1beta1", "MetricsTestMachine", "get", "NotFound")
+	successBefore := testutil.ToFloat64(success)
+	notFoundBefore := testutil.ToFloat64(notFound)
+
+	ref := &corev1.ObjectReference{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Name: "found"}
+	_, err := Get(ctx, fakeClient, ref, metav1.NamespaceDefault)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	ref.Name = "not-found"
+	_, err = Get(ctx, fakeClient, ref, metav1.NamespaceDefault)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(testutil.ToFloat64(success)).To(Equal(successBefore + 1))
+	g.Expect(testutil.ToFloat64(notFound)).To(Equal(notFoundBefore + 1))
+}
+
+func TestRegisterMetrics(t *testing.T) {
+	g := NewWithT(t)
+
+	registry := prometheus.NewRegistry()
+	g.Expect(RegisterMetrics(registry)).To(Succeed())
+
+	// Registering the metrics twice at the same registry must fail.
+	g.Expect(RegisterMetrics(registry)).ToNot(Succeed())
+
+	requestsTotal.WithLabelValues("metrics.test.cluster.x-k8s.io", "v1beta1", "MetricsTestMachine", "get", "success").Inc()
+	g.Expect(testutil.CollectAndCount(registry, "capi_external_object_requests_total")).To(BeNumerically(">", 0))
+}
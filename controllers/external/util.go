@@ -19,6 +19,7 @@ package external
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -40,7 +41,10 @@ func Get(ctx context.Context, c client.Reader, ref *corev1.ObjectReference, name
 	obj.SetKind(ref.Kind)
 	obj.SetName(ref.Name)
 	key := client.ObjectKey{Name: obj.GetName(), Namespace: namespace}
-	if err := c.Get(ctx, key, obj); err != nil {
+	start := time.Now()
+	err := c.Get(ctx, key, obj)
+	observeRequest(obj.GroupVersionKind(), "get", start, err)
+	if err != nil {
 		return nil, errors.Wrapf(err, "failed to retrieve %s external object %q/%q", obj.GetKind(), key.Namespace, key.Name)
 	}
 	return obj, nil
@@ -53,7 +57,10 @@ func Delete(ctx context.Context, c client.Writer, ref *corev1.ObjectReference) e
 	obj.SetKind(ref.Kind)
 	obj.SetName(ref.Name)
 	obj.SetNamespace(ref.Namespace)
-	if err := c.Delete(ctx, obj); err != nil {
+	start := time.Now()
+	err := c.Delete(ctx, obj)
+	observeRequest(obj.GroupVersionKind(), "delete", start, err)
+	if err != nil {
 		return errors.Wrapf(err, "failed to delete %s external object %q/%q", obj.GetKind(), obj.GetNamespace(), obj.GetName())
 	}
 	return nil
@@ -112,7 +119,10 @@ func CreateFromTemplate(ctx context.Context, in *CreateFromTemplateInput) (*core
 	}
 
 	// Create the external clone.
-	if err := in.Client.Create(ctx, to); err != nil {
+	start := time.Now()
+	err = in.Client.Create(ctx, to)
+	observeRequest(to.GroupVersionKind(), "create", start, err)
+	if err != nil {
 		return nil, err
 	}
 
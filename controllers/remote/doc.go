@@ -15,4 +15,9 @@ limitations under the License.
 */
 
 // Package remote implements remote controllers.
+//
+// The ClusterCacheTracker in this package maintains cached clients for workload clusters; it is deprecated and
+// will be removed in Cluster API v1.10. Use the ClusterCache from sigs.k8s.io/cluster-api/controllers/clustercache
+// instead, which also reconnects when the kubeconfig is rotated, health checks the connections and provides
+// sources to watch objects in workload clusters (e.g. Nodes) and enqueue reconciles in the management cluster.
 package remote
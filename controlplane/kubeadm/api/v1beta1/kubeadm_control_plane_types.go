@@ -48,6 +48,12 @@ const (
 	// SkipKubeProxyAnnotation annotation explicitly skips reconciling kube-proxy if set.
 	SkipKubeProxyAnnotation = "controlplane.cluster.x-k8s.io/skip-kube-proxy"
 
+	// RebalanceFailureDomainsAnnotation can be set to "true" on a KubeadmControlPlane to make KCP roll out, one at a time,
+	// the control plane Machines which are not in a control plane failure domain of the Cluster, or which are
+	// in a failure domain with at least two Machines more than another failure domain, e.g. after failure domains
+	// have been added or removed; replacement Machines are placed in the failure domains with fewer Machines.
+	RebalanceFailureDomainsAnnotation = "controlplane.cluster.x-k8s.io/rebalance-failure-domains"
+
 	// KubeadmClusterConfigurationAnnotation is a machine annotation that stores the json-marshalled string of KCP ClusterConfiguration.
 	// This annotation is used to detect any changes in ClusterConfiguration and trigger machine rollout in KCP.
 	KubeadmClusterConfigurationAnnotation = "controlplane.cluster.x-k8s.io/kubeadm-cluster-configuration"
@@ -128,6 +134,13 @@ type KubeadmControlPlaneSpec struct {
 	// InfraMachines & KubeadmConfigs will use the same name as the corresponding Machines.
 	// +optional
 	MachineNamingStrategy *MachineNamingStrategy `json:"machineNamingStrategy,omitempty"`
+
+	// preUpgradeEtcdSnapshot configures an etcd snapshot to be taken before the control plane is upgraded
+	// to a new Kubernetes version, so it is possible to recover etcd from a failed upgrade.
+	// If set, KCP starts replacing Machines only after the snapshot has been taken and recorded in status.lastEtcdSnapshot.
+	// Snapshots are only taken when using local etcd.
+	// +optional
+	PreUpgradeEtcdSnapshot *EtcdSnapshot `json:"preUpgradeEtcdSnapshot,omitempty"`
 }
 
 // KubeadmControlPlaneMachineTemplate defines the template for Machines
@@ -168,6 +181,18 @@ type RolloutBefore struct {
 	CertificatesExpiryDays *int32 `json:"certificatesExpiryDays,omitempty"`
 }
 
+// EtcdSnapshot defines where etcd snapshots are stored.
+type EtcdSnapshot struct {
+	// persistentVolumeClaimName is the name of a PersistentVolumeClaim in the kube-system namespace of the
+	// workload cluster where the snapshots are stored.
+	// The snapshots are taken by a Job running etcdctl on a control plane node of the workload cluster,
+	// and the PersistentVolumeClaim must be accessible from the control plane nodes.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+}
+
 // RolloutStrategy describes how to replace existing machines
 // with new ones.
 type RolloutStrategy struct {
@@ -338,6 +363,10 @@ type KubeadmControlPlaneStatus struct {
 	// +optional
 	LastRemediation *LastRemediationStatus `json:"lastRemediation,omitempty"`
 
+	// lastEtcdSnapshot stores info about the last etcd snapshot taken before upgrading the control plane.
+	// +optional
+	LastEtcdSnapshot *LastEtcdSnapshotStatus `json:"lastEtcdSnapshot,omitempty"`
+
 	// v1beta2 groups all the fields that will be added or modified in KubeadmControlPlane's status with the V1Beta2 version.
 	// +optional
 	V1Beta2 *KubeadmControlPlaneV1Beta2Status `json:"v1beta2,omitempty"`
@@ -383,6 +412,25 @@ type LastRemediationStatus struct {
 	RetryCount int32 `json:"retryCount"`
 }
 
+// LastEtcdSnapshotStatus stores info about the last etcd snapshot taken before upgrading the control plane.
+type LastEtcdSnapshotStatus struct {
+	// persistentVolumeClaimName is the name of the PersistentVolumeClaim in the kube-system namespace of the
+	// workload cluster where the snapshot is stored.
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+
+	// path is the path of the snapshot in the PersistentVolumeClaim.
+	Path string `json:"path"`
+
+	// fromVersion is the Kubernetes version of the control plane when the snapshot was taken.
+	FromVersion string `json:"fromVersion"`
+
+	// toVersion is the Kubernetes version the control plane was being upgraded to when the snapshot was taken.
+	ToVersion string `json:"toVersion"`
+
+	// timestamp is when the snapshot was taken. It is represented in RFC3339 form and is in UTC.
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=kubeadmcontrolplanes,shortName=kcp,scope=Namespaced,categories=cluster-api
 // +kubebuilder:storageversion
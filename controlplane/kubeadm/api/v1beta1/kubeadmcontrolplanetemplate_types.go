@@ -106,6 +106,12 @@ type KubeadmControlPlaneTemplateResourceSpec struct {
 	// InfraMachines & KubeadmConfigs will use the same name as the corresponding Machines.
 	// +optional
 	MachineNamingStrategy *MachineNamingStrategy `json:"machineNamingStrategy,omitempty"`
+
+	// preUpgradeEtcdSnapshot configures an etcd snapshot to be taken before the control plane is upgraded
+	// to a new Kubernetes version, so it is possible to recover etcd from a failed upgrade.
+	// Snapshots are only taken when using local etcd.
+	// +optional
+	PreUpgradeEtcdSnapshot *EtcdSnapshot `json:"preUpgradeEtcdSnapshot,omitempty"`
 }
 
 // KubeadmControlPlaneTemplateMachineTemplate defines the template for Machines
@@ -27,6 +27,21 @@ import (
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshot) DeepCopyInto(out *EtcdSnapshot) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshot.
+func (in *EtcdSnapshot) DeepCopy() *EtcdSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlane) DeepCopyInto(out *KubeadmControlPlane) {
 	*out = *in
@@ -152,6 +167,11 @@ func (in *KubeadmControlPlaneSpec) DeepCopyInto(out *KubeadmControlPlaneSpec) {
 		*out = new(MachineNamingStrategy)
 		**out = **in
 	}
+	if in.PreUpgradeEtcdSnapshot != nil {
+		in, out := &in.PreUpgradeEtcdSnapshot, &out.PreUpgradeEtcdSnapshot
+		*out = new(EtcdSnapshot)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneSpec.
@@ -189,6 +209,11 @@ func (in *KubeadmControlPlaneStatus) DeepCopyInto(out *KubeadmControlPlaneStatus
 		*out = new(LastRemediationStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LastEtcdSnapshot != nil {
+		in, out := &in.LastEtcdSnapshot, &out.LastEtcdSnapshot
+		*out = new(LastEtcdSnapshotStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.V1Beta2 != nil {
 		in, out := &in.V1Beta2, &out.V1Beta2
 		*out = new(KubeadmControlPlaneV1Beta2Status)
@@ -345,6 +370,11 @@ func (in *KubeadmControlPlaneTemplateResourceSpec) DeepCopyInto(out *KubeadmCont
 		*out = new(MachineNamingStrategy)
 		**out = **in
 	}
+	if in.PreUpgradeEtcdSnapshot != nil {
+		in, out := &in.PreUpgradeEtcdSnapshot, &out.PreUpgradeEtcdSnapshot
+		*out = new(EtcdSnapshot)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneTemplateResourceSpec.
@@ -410,6 +440,22 @@ func (in *KubeadmControlPlaneV1Beta2Status) DeepCopy() *KubeadmControlPlaneV1Bet
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LastEtcdSnapshotStatus) DeepCopyInto(out *LastEtcdSnapshotStatus) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastEtcdSnapshotStatus.
+func (in *LastEtcdSnapshotStatus) DeepCopy() *LastEtcdSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LastEtcdSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LastRemediationStatus) DeepCopyInto(out *LastRemediationStatus) {
 	*out = *in
@@ -18,12 +18,14 @@ package internal
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -129,6 +131,17 @@ func NewControlPlane(ctx context.Context, managementCluster ManagementCluster, c
 		}
 	}
 
+	// Select a machine that should be rolled out to rebalance control plane machines across failure domains.
+	if kcp.Annotations[controlplanev1.RebalanceFailureDomainsAnnotation] == "true" &&
+		len(machinesNotUptoDate) == 0 && len(ownedMachines.Filter(collections.HasDeletionTimestamp)) == 0 &&
+		kcp.Spec.Replicas != nil && int32(len(ownedMachines)) == *kcp.Spec.Replicas {
+		if m, message := machineToRebalance(cluster.Status.FailureDomains.FilterControlPlane(), ownedMachines); m != nil {
+			machinesNotUptoDate.Insert(m)
+			machinesNotUptoDateLogMessages[m.Name] = []string{message}
+			machinesNotUptoDateConditionMessages[m.Name] = []string{message}
+		}
+	}
+
 	return &ControlPlane{
 		KCP:                                  kcp,
 		Cluster:                              cluster,
@@ -195,6 +208,40 @@ func (c *ControlPlane) NextFailureDomainForScaleUp(ctx context.Context) (*string
 	return failuredomains.PickFewest(ctx, c.FailureDomains().FilterControlPlane(), c.UpToDateMachines().Filter(collections.Not(collections.HasDeletionTimestamp))), nil
 }
 
+// machineToRebalance returns the oldest machine which is not in one of the given failure domains or, if all the
+// machines are in one of the given failure domains, the oldest machine in the failure domain with the most machines
+// if it has at least two machines more than the failure domain with the fewest machines.
+func machineToRebalance(failureDomains clusterv1.FailureDomains, machines collections.Machines) (*clusterv1.Machine, string) {
+	if len(failureDomains) == 0 {
+		return nil, ""
+	}
+
+	if m := machines.Filter(collections.Not(collections.InFailureDomains(failureDomains.GetIDs()...))).Oldest(); m != nil {
+		return m, "Machine is not in a control plane failure domain of the Cluster"
+	}
+
+	counts := map[string]int{}
+	for id := range failureDomains {
+		counts[id] = 0
+	}
+	for _, m := range machines {
+		counts[*m.Spec.FailureDomain]++
+	}
+	most, fewest := "", ""
+	for _, id := range sets.List(sets.KeySet(counts)) {
+		if most == "" || counts[id] > counts[most] {
+			most = id
+		}
+		if fewest == "" || counts[id] < counts[fewest] {
+			fewest = id
+		}
+	}
+	if counts[most]-counts[fewest] < 2 {
+		return nil, ""
+	}
+	return machines.Filter(collections.InFailureDomains(&most)).Oldest(), fmt.Sprintf("Failure domain %s has %d Machines, failure domain %s has %d Machines", most, counts[most], fewest, counts[fewest])
+}
+
 // InitialControlPlaneConfig returns a new KubeadmConfigSpec that is to be used for an initializing control plane.
 func (c *ControlPlane) InitialControlPlaneConfig() *bootstrapv1.KubeadmConfigSpec {
 	bootstrapSpec := c.KCP.Spec.KubeadmConfigSpec.DeepCopy()
@@ -18,6 +18,7 @@ package internal
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -252,6 +253,77 @@ func TestHasHealthyMachineStillProvisioning(t *testing.T) {
 	})
 }
 
+func TestMachineToRebalance(t *testing.T) {
+	failureDomains := clusterv1.FailureDomains{
+		"one":   failureDomain(true),
+		"two":   failureDomain(true),
+		"three": failureDomain(true),
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		failureDomains clusterv1.FailureDomains
+		machines       collections.Machines
+		want           string
+		wantMessage    string
+	}{
+		{
+			name:           "no machine if there are no failure domains",
+			failureDomains: nil,
+			machines: collections.FromMachines(
+				machine("machine-1"),
+			),
+		},
+		{
+			name:           "no machine if machines are spread across failure domains",
+			failureDomains: failureDomains,
+			machines: collections.FromMachines(
+				machine("machine-1", withFailureDomain("one")),
+				machine("machine-2", withFailureDomain("two")),
+				machine("machine-3", withFailureDomain("three")),
+				machine("machine-4", withFailureDomain("three")),
+			),
+		},
+		{
+			name:           "the oldest machine not in a failure domain",
+			failureDomains: failureDomains,
+			machines: collections.FromMachines(
+				machine("machine-1", withFailureDomain("one")),
+				machine("machine-2", withCreationTimestamp(now.Add(-time.Hour))),
+				machine("machine-3", withFailureDomain("removed"), withCreationTimestamp(now.Add(-2*time.Hour))),
+			),
+			want:        "machine-3",
+			wantMessage: "Machine is not in a control plane failure domain of the Cluster",
+		},
+		{
+			name:           "the oldest machine in the failure domain with most machines",
+			failureDomains: failureDomains,
+			machines: collections.FromMachines(
+				machine("machine-1", withFailureDomain("one"), withCreationTimestamp(now.Add(-time.Hour))),
+				machine("machine-2", withFailureDomain("two"), withCreationTimestamp(now.Add(-3*time.Hour))),
+				machine("machine-3", withFailureDomain("two"), withCreationTimestamp(now.Add(-2*time.Hour))),
+			),
+			want:        "machine-2",
+			wantMessage: "Failure domain two has 2 Machines, failure domain three has 0 Machines",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m, message := machineToRebalance(tt.failureDomains, tt.machines)
+			if tt.want == "" {
+				g.Expect(m).To(BeNil())
+				return
+			}
+			g.Expect(m).ToNot(BeNil())
+			g.Expect(m.Name).To(Equal(tt.want))
+			g.Expect(message).To(Equal(tt.wantMessage))
+		})
+	}
+}
+
 type machineOpt func(*clusterv1.Machine)
 
 func failureDomain(controlPlane bool) clusterv1.FailureDomainSpec {
@@ -266,6 +338,12 @@ func withFailureDomain(fd string) machineOpt {
 	}
 }
 
+func withCreationTimestamp(t time.Time) machineOpt {
+	return func(m *clusterv1.Machine) {
+		m.CreationTimestamp = metav1.NewTime(t)
+	}
+}
+
 func machine(name string, opts ...machineOpt) *clusterv1.Machine {
 	m := &clusterv1.Machine{
 		ObjectMeta: metav1.ObjectMeta{
@@ -30,4 +30,8 @@ const (
 	// dependentCertRequeueAfter is how long to wait before checking again to see if
 	// dependent certificates have been created.
 	dependentCertRequeueAfter = 30 * time.Second
+
+	// etcdSnapshotRequeueAfter is how long to wait before checking again to see if
+	// the etcd snapshot taken before an upgrade has been completed.
+	etcdSnapshotRequeueAfter = 10 * time.Second
 )
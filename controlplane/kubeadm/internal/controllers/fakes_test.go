@@ -72,9 +72,12 @@ type fakeWorkloadCluster struct {
 	Status                     internal.ClusterStatus
 	EtcdMembersResult          []string
 	APIServerCertificateExpiry *time.Time
+	EtcdSnapshotResult         *internal.EtcdSnapshot
+	EtcdSnapshotErr            error
 
 	forwardEtcdLeadershipCalled      int
 	removeEtcdMemberForMachineCalled int
+	takeEtcdSnapshotCalled           []string
 }
 
 func (f *fakeWorkloadCluster) ForwardEtcdLeadership(_ context.Context, _ *clusterv1.Machine, leaderCandidate *clusterv1.Machine) error {
@@ -138,6 +141,11 @@ func (f *fakeWorkloadCluster) UpdateClusterConfiguration(context.Context, semver
 	return nil
 }
 
+func (f *fakeWorkloadCluster) TakeEtcdSnapshot(_ context.Context, name, _ string) (*internal.EtcdSnapshot, error) {
+	f.takeEtcdSnapshotCalled = append(f.takeEtcdSnapshotCalled, name)
+	return f.EtcdSnapshotResult, f.EtcdSnapshotErr
+}
+
 type fakeMigrator struct {
 	migrateCalled    bool
 	migrateErr       error
@@ -18,9 +18,14 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -55,6 +60,11 @@ func (r *KubeadmControlPlaneReconciler) upgradeControlPlane(
 		return ctrl.Result{}, errors.Wrapf(err, "failed to parse kubernetes version %q", controlPlane.KCP.Spec.Version)
 	}
 
+	// Take the etcd snapshot, if required, before changing anything in the workload cluster.
+	if result, err := r.reconcilePreUpgradeEtcdSnapshot(ctx, controlPlane, workloadCluster, machinesRequireUpgrade); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	if err := workloadCluster.ReconcileKubeletRBACRole(ctx, parsedVersion); err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile the remote kubelet RBAC role")
 	}
@@ -129,3 +139,69 @@ func (r *KubeadmControlPlaneReconciler) upgradeControlPlane(
 		return ctrl.Result{}, nil
 	}
 }
+
+// reconcilePreUpgradeEtcdSnapshot takes an etcd snapshot before upgrading the control plane to a new Kubernetes version,
+// if spec.preUpgradeEtcdSnapshot is set, and records it in status.lastEtcdSnapshot.
+// Machines are replaced only after the snapshot is completed.
+func (r *KubeadmControlPlaneReconciler) reconcilePreUpgradeEtcdSnapshot(
+	ctx context.Context,
+	controlPlane *internal.ControlPlane,
+	workloadCluster internal.WorkloadCluster,
+	machinesRequireUpgrade collections.Machines,
+) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	if kcp.Spec.PreUpgradeEtcdSnapshot == nil || !controlPlane.IsEtcdManaged() {
+		return ctrl.Result{}, nil
+	}
+
+	// A snapshot has already been taken before starting this upgrade.
+	if kcp.Status.LastEtcdSnapshot != nil && kcp.Status.LastEtcdSnapshot.ToVersion == kcp.Spec.Version {
+		return ctrl.Result{}, nil
+	}
+
+	// Snapshots are only taken when upgrading the Kubernetes version, not for other rollouts.
+	fromVersion := ""
+	for _, m := range machinesRequireUpgrade.SortedByCreationTimestamp() {
+		if m.Spec.Version != nil && *m.Spec.Version != kcp.Spec.Version {
+			fromVersion = *m.Spec.Version
+			break
+		}
+	}
+	if fromVersion == "" {
+		return ctrl.Result{}, nil
+	}
+
+	name := etcdSnapshotName(fromVersion, kcp.Spec.Version)
+	persistentVolumeClaimName := kcp.Spec.PreUpgradeEtcdSnapshot.PersistentVolumeClaimName
+	snapshot, err := workloadCluster.TakeEtcdSnapshot(ctx, name, persistentVolumeClaimName)
+	if err != nil {
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdSnapshot", "Failed to take etcd snapshot before upgrading from %s to %s: %v", fromVersion, kcp.Spec.Version, err)
+		return ctrl.Result{}, errors.Wrapf(err, "failed to take etcd snapshot before upgrading from %s to %s", fromVersion, kcp.Spec.Version)
+	}
+	if snapshot.CompletionTime == nil {
+		logger.Info("Waiting for the etcd snapshot to complete before upgrading the control plane", "Job", klog.KRef(metav1.NamespaceSystem, name))
+		return ctrl.Result{RequeueAfter: etcdSnapshotRequeueAfter}, nil
+	}
+
+	kcp.Status.LastEtcdSnapshot = &controlplanev1.LastEtcdSnapshotStatus{
+		PersistentVolumeClaimName: persistentVolumeClaimName,
+		Path:                      snapshot.Path,
+		FromVersion:               fromVersion,
+		ToVersion:                 kcp.Spec.Version,
+		Timestamp:                 *snapshot.CompletionTime,
+	}
+	logger.Info("Etcd snapshot completed, starting the control plane upgrade", "PersistentVolumeClaim", klog.KRef(metav1.NamespaceSystem, persistentVolumeClaimName), "path", snapshot.Path)
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "SuccessfulEtcdSnapshot", "Took etcd snapshot %s before upgrading from %s to %s", snapshot.Path, fromVersion, kcp.Spec.Version)
+	return ctrl.Result{}, nil
+}
+
+// etcdSnapshotName returns the name of the etcd snapshot taken before upgrading from a version to another.
+func etcdSnapshotName(fromVersion, toVersion string) string {
+	name := strings.ToLower(strings.ReplaceAll(fmt.Sprintf("etcd-snapshot-%s-to-%s", fromVersion, toVersion), "+", "-"))
+	if len(name) > validation.DNS1123LabelMaxLength {
+		name = strings.TrimRight(name[:validation.DNS1123LabelMaxLength], "-.")
+	}
+	return name
+}
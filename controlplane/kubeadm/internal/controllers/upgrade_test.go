@@ -23,6 +23,7 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,6 +34,7 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/util"
@@ -283,3 +285,131 @@ func machine(name string, opts ...machineOpt) *clusterv1.Machine {
 	}
 	return m
 }
+
+func TestKubeadmControlPlaneReconciler_reconcilePreUpgradeEtcdSnapshot(t *testing.T) {
+	completionTime := metav1.Now()
+	machine := func(name, version string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name},
+			Spec:       clusterv1.MachineSpec{Version: ptr.To(version)},
+		}
+	}
+
+	tests := []struct {
+		name                   string
+		preUpgradeEtcdSnapshot *controlplanev1.EtcdSnapshot
+		externalEtcd           bool
+		lastEtcdSnapshot       *controlplanev1.LastEtcdSnapshotStatus
+		machines               collections.Machines
+		snapshot               *internal.EtcdSnapshot
+		snapshotErr            error
+		wantSnapshotTaken      bool
+		wantResult             ctrl.Result
+		wantErr                bool
+		wantLastEtcdSnapshot   *controlplanev1.LastEtcdSnapshotStatus
+	}{
+		{
+			name:     "no snapshot if preUpgradeEtcdSnapshot is not set",
+			machines: collections.FromMachines(machine("m1", "v1.30.0")),
+		},
+		{
+			name:                   "no snapshot with external etcd",
+			preUpgradeEtcdSnapshot: &controlplanev1.EtcdSnapshot{PersistentVolumeClaimName: "snapshots"},
+			externalEtcd:           true,
+			machines:               collections.FromMachines(machine("m1", "v1.30.0")),
+		},
+		{
+			name:                   "no snapshot for rollouts which are not version upgrades",
+			preUpgradeEtcdSnapshot: &controlplanev1.EtcdSnapshot{PersistentVolumeClaimName: "snapshots"},
+			machines:               collections.FromMachines(machine("m1", "v1.31.0")),
+		},
+		{
+			name:                   "no snapshot if the snapshot for this upgrade has already been taken",
+			preUpgradeEtcdSnapshot: &controlplanev1.EtcdSnapshot{PersistentVolumeClaimName: "snapshots"},
+			lastEtcdSnapshot:       &controlplanev1.LastEtcdSnapshotStatus{FromVersion: "v1.30.0", ToVersion: "v1.31.0"},
+			machines:               collections.FromMachines(machine("m1", "v1.30.0")),
+			wantLastEtcdSnapshot:   &controlplanev1.LastEtcdSnapshotStatus{FromVersion: "v1.30.0", ToVersion: "v1.31.0"},
+		},
+		{
+			name:                   "wait for the snapshot to complete",
+			preUpgradeEtcdSnapshot: &controlplanev1.EtcdSnapshot{PersistentVolumeClaimName: "snapshots"},
+			machines:               collections.FromMachines(machine("m1", "v1.30.0")),
+			snapshot:               &internal.EtcdSnapshot{Path: "etcd-snapshot-v1.30.0-to-v1.31.0.db"},
+			wantSnapshotTaken:      true,
+			wantResult:             ctrl.Result{RequeueAfter: etcdSnapshotRequeueAfter},
+		},
+		{
+			name:                   "record the completed snapshot",
+			preUpgradeEtcdSnapshot: &controlplanev1.EtcdSnapshot{PersistentVolumeClaimName: "snapshots"},
+			lastEtcdSnapshot:       &controlplanev1.LastEtcdSnapshotStatus{FromVersion: "v1.29.0", ToVersion: "v1.30.0"},
+			machines:               collections.FromMachines(machine("m1", "v1.31.0"), machine("m2", "v1.30.0")),
+			snapshot:               &internal.EtcdSnapshot{Path: "etcd-snapshot-v1.30.0-to-v1.31.0.db", CompletionTime: &completionTime},
+			wantSnapshotTaken:      true,
+			wantLastEtcdSnapshot: &controlplanev1.LastEtcdSnapshotStatus{
+				PersistentVolumeClaimName: "snapshots",
+				Path:                      "etcd-snapshot-v1.30.0-to-v1.31.0.db",
+				FromVersion:               "v1.30.0",
+				ToVersion:                 "v1.31.0",
+				Timestamp:                 completionTime,
+			},
+		},
+		{
+			name:                   "fail if the snapshot fails",
+			preUpgradeEtcdSnapshot: &controlplanev1.EtcdSnapshot{PersistentVolumeClaimName: "snapshots"},
+			machines:               collections.FromMachines(machine("m1", "v1.30.0")),
+			snapshotErr:            errors.New("etcd snapshot Job failed"),
+			wantSnapshotTaken:      true,
+			wantErr:                true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Version:                "v1.31.0",
+					PreUpgradeEtcdSnapshot: tt.preUpgradeEtcdSnapshot,
+				},
+				Status: controlplanev1.KubeadmControlPlaneStatus{
+					LastEtcdSnapshot: tt.lastEtcdSnapshot,
+				},
+			}
+			if tt.externalEtcd {
+				kcp.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+					Etcd: bootstrapv1.Etcd{External: &bootstrapv1.ExternalEtcd{}},
+				}
+			}
+			workloadCluster := &fakeWorkloadCluster{
+				EtcdSnapshotResult: tt.snapshot,
+				EtcdSnapshotErr:    tt.snapshotErr,
+			}
+			r := &KubeadmControlPlaneReconciler{
+				recorder: record.NewFakeRecorder(32),
+			}
+			controlPlane := &internal.ControlPlane{KCP: kcp, Machines: tt.machines}
+
+			result, err := r.reconcilePreUpgradeEtcdSnapshot(ctx, controlPlane, workloadCluster, tt.machines)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(result).To(BeComparableTo(tt.wantResult))
+			if tt.wantSnapshotTaken {
+				g.Expect(workloadCluster.takeEtcdSnapshotCalled).To(ConsistOf("etcd-snapshot-v1.30.0-to-v1.31.0"))
+			} else {
+				g.Expect(workloadCluster.takeEtcdSnapshotCalled).To(BeEmpty())
+			}
+			g.Expect(kcp.Status.LastEtcdSnapshot).To(BeComparableTo(tt.wantLastEtcdSnapshot))
+		})
+	}
+}
+
+func TestEtcdSnapshotName(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(etcdSnapshotName("v1.30.0", "v1.31.0")).To(Equal("etcd-snapshot-v1.30.0-to-v1.31.0"))
+	g.Expect(etcdSnapshotName("v1.30.0+build.1", "v1.31.0-rc.0+build.2")).To(Equal("etcd-snapshot-v1.30.0-build.1-to-v1.31.0-rc.0-build.2"))
+	g.Expect(len(etcdSnapshotName("v1.30.0-alpha.0.123456789.abcdef", "v1.31.0-alpha.0.123456789.abcdef"))).To(BeNumerically("<=", 63))
+}
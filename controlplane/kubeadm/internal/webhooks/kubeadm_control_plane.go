@@ -238,6 +238,8 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 		{spec, "remediationStrategy", "*"},
 		{spec, "machineNamingStrategy"},
 		{spec, "machineNamingStrategy", "*"},
+		{spec, "preUpgradeEtcdSnapshot"},
+		{spec, "preUpgradeEtcdSnapshot", "*"},
 		{spec, "rolloutAfter"},
 		{spec, "rolloutBefore"},
 		{spec, "rolloutBefore", "*"},
@@ -400,6 +402,8 @@ func validateKubeadmControlPlaneSpec(s controlplanev1.KubeadmControlPlaneSpec, n
 	if s.MachineNamingStrategy != nil {
 		allErrs = append(allErrs, validateNamingStrategy(s.MachineNamingStrategy, pathPrefix.Child("machineNamingStrategy"))...)
 	}
+
+	allErrs = append(allErrs, validatePreUpgradeEtcdSnapshot(s.PreUpgradeEtcdSnapshot, s.KubeadmConfigSpec.ClusterConfiguration, pathPrefix.Child("preUpgradeEtcdSnapshot"))...)
 	return allErrs
 }
 
@@ -419,6 +423,37 @@ func validateRolloutBefore(rolloutBefore *controlplanev1.RolloutBefore, pathPref
 	return allErrs
 }
 
+func validatePreUpgradeEtcdSnapshot(etcdSnapshot *controlplanev1.EtcdSnapshot, clusterConfiguration *bootstrapv1.ClusterConfiguration, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if etcdSnapshot == nil {
+		return allErrs
+	}
+
+	if clusterConfiguration != nil && clusterConfiguration.Etcd.External != nil {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix,
+				"etcd snapshots can only be taken when etcd is stacked",
+			),
+		)
+	}
+
+	for _, msg := range validation.IsDNS1123Subdomain(etcdSnapshot.PersistentVolumeClaimName) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("persistentVolumeClaimName"),
+				etcdSnapshot.PersistentVolumeClaimName,
+				msg,
+			),
+		)
+	}
+
+	return allErrs
+}
+
 func validateRolloutStrategy(rolloutStrategy *controlplanev1.RolloutStrategy, replicas *int32, pathPrefix *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
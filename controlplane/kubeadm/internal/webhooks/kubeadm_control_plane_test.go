@@ -154,6 +154,21 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 		CertificatesExpiryDays: ptr.To[int32](5), // less than minimum
 	}
 
+	validPreUpgradeEtcdSnapshot := valid.DeepCopy()
+	validPreUpgradeEtcdSnapshot.Spec.PreUpgradeEtcdSnapshot = &controlplanev1.EtcdSnapshot{
+		PersistentVolumeClaimName: "etcd-snapshots",
+	}
+
+	invalidPreUpgradeEtcdSnapshotName := valid.DeepCopy()
+	invalidPreUpgradeEtcdSnapshotName.Spec.PreUpgradeEtcdSnapshot = &controlplanev1.EtcdSnapshot{
+		PersistentVolumeClaimName: "Etcd_Snapshots",
+	}
+
+	invalidPreUpgradeEtcdSnapshotExternalEtcd := evenReplicasExternalEtcd.DeepCopy()
+	invalidPreUpgradeEtcdSnapshotExternalEtcd.Spec.PreUpgradeEtcdSnapshot = &controlplanev1.EtcdSnapshot{
+		PersistentVolumeClaimName: "etcd-snapshots",
+	}
+
 	invalidIgnitionConfiguration := valid.DeepCopy()
 	invalidIgnitionConfiguration.Spec.KubeadmConfigSpec.Ignition = &bootstrapv1.IgnitionSpec{}
 
@@ -242,6 +257,21 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: true,
 			kcp:       invalidRolloutBeforeCertificateExpiryDays,
 		},
+		{
+			name:      "should succeed when given a valid preUpgradeEtcdSnapshot",
+			expectErr: false,
+			kcp:       validPreUpgradeEtcdSnapshot,
+		},
+		{
+			name:      "should return error when given an invalid preUpgradeEtcdSnapshot.persistentVolumeClaimName",
+			expectErr: true,
+			kcp:       invalidPreUpgradeEtcdSnapshotName,
+		},
+		{
+			name:      "should return error when preUpgradeEtcdSnapshot is set with external etcd",
+			expectErr: true,
+			kcp:       invalidPreUpgradeEtcdSnapshotExternalEtcd,
+		},
 
 		{
 			name:                  "should return error when Ignition configuration is invalid",
@@ -394,6 +424,11 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	invalidUpdateKubeadmConfigFormat := beforeKubeadmConfigFormatSet.DeepCopy()
 	invalidUpdateKubeadmConfigFormat.Spec.KubeadmConfigSpec.Format = bootstrapv1.Ignition
 
+	validUpdatePreUpgradeEtcdSnapshot := before.DeepCopy()
+	validUpdatePreUpgradeEtcdSnapshot.Spec.PreUpgradeEtcdSnapshot = &controlplanev1.EtcdSnapshot{
+		PersistentVolumeClaimName: "etcd-snapshots",
+	}
+
 	validUpdate := before.DeepCopy()
 	validUpdate.Labels = map[string]string{"blue": "green"}
 	validUpdate.Spec.KubeadmConfigSpec.PreKubeadmCommands = []string{"ab", "abc"}
@@ -735,6 +770,12 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:    before,
 			kcp:       validUpdateKubeadmConfigInit,
 		},
+		{
+			name:      "should not return an error when trying to mutate the preUpgradeEtcdSnapshot",
+			expectErr: false,
+			before:    before,
+			kcp:       validUpdatePreUpgradeEtcdSnapshot,
+		},
 		{
 			name:      "should return error when trying to mutate the kubeadmconfigspec clusterconfiguration",
 			expectErr: true,
@@ -74,9 +74,13 @@ func (webhook *KubeadmControlPlaneTemplate) ValidateCreate(_ context.Context, ob
 	// NOTE: KubeadmControlPlaneTemplate is behind ClusterTopology feature gate flag; the web hook
 	// must prevent creating new objects in case the feature flag is disabled.
 	if !feature.Gates.Enabled(feature.ClusterTopology) {
-		return nil, field.Forbidden(
-			field.NewPath("spec"),
-			"can be set only if the ClusterTopology feature flag is enabled",
+		return nil, apierrors.NewInvalid(
+			clusterv1.GroupVersion.WithKind("KubeadmControlPlaneTemplate").GroupKind(),
+			k.Name,
+			field.ErrorList{field.Forbidden(
+				field.NewPath("spec"),
+				"can be set only if the ClusterTopology feature flag is enabled",
+			)},
 		)
 	}
 
@@ -144,6 +148,7 @@ func validateKubeadmControlPlaneTemplateResourceSpec(s controlplanev1.KubeadmCon
 	if s.MachineNamingStrategy != nil {
 		allErrs = append(allErrs, validateNamingStrategy(s.MachineNamingStrategy, pathPrefix.Child("machineNamingStrategy"))...)
 	}
+	allErrs = append(allErrs, validatePreUpgradeEtcdSnapshot(s.PreUpgradeEtcdSnapshot, s.KubeadmConfigSpec.ClusterConfiguration, pathPrefix.Child("preUpgradeEtcdSnapshot"))...)
 
 	if s.MachineTemplate != nil {
 		// Validate the metadata of the MachineTemplate
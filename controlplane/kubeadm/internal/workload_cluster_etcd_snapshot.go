@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api/util"
+)
+
+const (
+	// EtcdSnapshotJobLabel is the label set on the Jobs taking etcd snapshots in the workload cluster.
+	EtcdSnapshotJobLabel = "controlplane.cluster.x-k8s.io/etcd-snapshot"
+
+	etcdSnapshotContainerName = "etcd-snapshot"
+	etcdSnapshotMountPath     = "/var/lib/etcd-snapshots"
+	etcdPKIPath               = "/etc/kubernetes/pki/etcd"
+)
+
+// EtcdSnapshot describes an etcd snapshot taken in the workload cluster.
+type EtcdSnapshot struct {
+	// Path is the path of the snapshot in the PersistentVolumeClaim.
+	Path string
+
+	// CompletionTime is when the snapshot was completed; it is nil while the snapshot is in progress.
+	CompletionTime *metav1.Time
+}
+
+// TakeEtcdSnapshot ensures a Job saving an etcd snapshot into the given PersistentVolumeClaim exists in the
+// kube-system namespace of the workload cluster, and returns the snapshot.
+// The Job runs etcdctl using the etcd image of the control plane node it runs on.
+func (w *Workload) TakeEtcdSnapshot(ctx context.Context, name, persistentVolumeClaimName string) (*EtcdSnapshot, error) {
+	snapshot := &EtcdSnapshot{Path: fmt.Sprintf("%s.db", name)}
+
+	job := &batchv1.Job{}
+	err := w.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: metav1.NamespaceSystem, Name: name}, job)
+	switch {
+	case apierrors.IsNotFound(err):
+		job, err := w.newEtcdSnapshotJob(ctx, name, persistentVolumeClaimName, snapshot.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := w.Client.Create(ctx, job); err != nil {
+			return nil, errors.Wrapf(err, "failed to create etcd snapshot Job %s", name)
+		}
+		return snapshot, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to get etcd snapshot Job %s", name)
+	}
+
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			snapshot.CompletionTime = job.Status.CompletionTime
+			if snapshot.CompletionTime == nil {
+				snapshot.CompletionTime = &c.LastTransitionTime
+			}
+		case batchv1.JobFailed:
+			return nil, errors.Errorf("etcd snapshot Job %s failed: %s", name, c.Message)
+		}
+	}
+	return snapshot, nil
+}
+
+func (w *Workload) newEtcdSnapshotJob(ctx context.Context, name, persistentVolumeClaimName, snapshotPath string) (*batchv1.Job, error) {
+	nodes, err := w.getControlPlaneNodes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list control plane nodes")
+	}
+
+	// Run etcdctl on a ready control plane node, using the same image of the etcd member running on that node.
+	var nodeName, image string
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !util.IsNodeReady(node) {
+			continue
+		}
+		pod := &corev1.Pod{}
+		if err := w.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: metav1.NamespaceSystem, Name: staticPodName("etcd", node.Name)}, pod); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get etcd Pod on Node %s", node.Name)
+		}
+		for _, c := range pod.Spec.Containers {
+			if c.Name == "etcd" {
+				nodeName, image = node.Name, c.Image
+				break
+			}
+		}
+		if nodeName != "" {
+			break
+		}
+	}
+	if nodeName == "" {
+		return nil, errors.New("failed to find a ready control plane node running etcd")
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceSystem,
+			Labels: map[string]string{
+				EtcdSnapshotJobLabel: "",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](3),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						EtcdSnapshotJobLabel: "",
+					},
+				},
+				Spec: corev1.PodSpec{
+					NodeName:      nodeName,
+					HostNetwork:   true,
+					RestartPolicy: corev1.RestartPolicyNever,
+					Tolerations: []corev1.Toleration{
+						{Operator: corev1.TolerationOpExists},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:    etcdSnapshotContainerName,
+							Image:   image,
+							Command: []string{"etcdctl"},
+							Args: []string{
+								"--endpoints=https://127.0.0.1:2379",
+								"--cacert=" + path.Join(etcdPKIPath, "ca.crt"),
+								"--cert=" + path.Join(etcdPKIPath, "healthcheck-client.crt"),
+								"--key=" + path.Join(etcdPKIPath, "healthcheck-client.key"),
+								"snapshot",
+								"save",
+								path.Join(etcdSnapshotMountPath, snapshotPath),
+							},
+							Env: []corev1.EnvVar{
+								{Name: "ETCDCTL_API", Value: "3"},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "etcd-certs", MountPath: etcdPKIPath, ReadOnly: true},
+								{Name: "etcd-snapshots", MountPath: etcdSnapshotMountPath},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "etcd-certs",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{
+									Path: etcdPKIPath,
+									Type: ptr.To(corev1.HostPathDirectory),
+								},
+							},
+						},
+						{
+							Name: "etcd-snapshots",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: persistentVolumeClaimName,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWorkload_TakeEtcdSnapshot(t *testing.T) {
+	node := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{labelNodeRoleControlPlane: ""},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+			},
+		}
+	}
+	etcdPod := func(nodeName, image string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceSystem,
+				Name:      staticPodName("etcd", nodeName),
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "etcd", Image: image}},
+			},
+		}
+	}
+	job := func(conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceSystem,
+				Name:      "etcd-snapshot",
+			},
+			Status: batchv1.JobStatus{Conditions: conditions},
+		}
+	}
+	completionTime := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	tests := []struct {
+		name         string
+		objs         []ctrlclient.Object
+		wantErr      bool
+		wantSnapshot *EtcdSnapshot
+		wantJobNode  string
+		wantJobImage string
+	}{
+		{
+			name: "creates the Job on a ready control plane node running etcd",
+			objs: []ctrlclient.Object{
+				node("n1", corev1.ConditionFalse), etcdPod("n1", "registry.k8s.io/etcd:3.5.15-0"),
+				node("n2", corev1.ConditionTrue), etcdPod("n2", "registry.k8s.io/etcd:3.5.16-0"),
+			},
+			wantSnapshot: &EtcdSnapshot{Path: "etcd-snapshot.db"},
+			wantJobNode:  "n2",
+			wantJobImage: "registry.k8s.io/etcd:3.5.16-0",
+		},
+		{
+			name: "fails if there is no ready control plane node running etcd",
+			objs: []ctrlclient.Object{
+				node("n1", corev1.ConditionFalse), etcdPod("n1", "registry.k8s.io/etcd:3.5.15-0"),
+				node("n2", corev1.ConditionTrue),
+			},
+			wantErr: true,
+		},
+		{
+			name:         "returns the snapshot in progress",
+			objs:         []ctrlclient.Object{job()},
+			wantSnapshot: &EtcdSnapshot{Path: "etcd-snapshot.db"},
+		},
+		{
+			name: "returns the completed snapshot",
+			objs: []ctrlclient.Object{job(batchv1.JobCondition{
+				Type:               batchv1.JobComplete,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: completionTime,
+			})},
+			wantSnapshot: &EtcdSnapshot{Path: "etcd-snapshot.db", CompletionTime: &completionTime},
+		},
+		{
+			name: "fails if the Job failed",
+			objs: []ctrlclient.Object{job(batchv1.JobCondition{
+				Type:    batchv1.JobFailed,
+				Status:  corev1.ConditionTrue,
+				Message: "BackoffLimitExceeded",
+			})},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			w := &Workload{
+				Client: fake.NewClientBuilder().WithObjects(tt.objs...).WithStatusSubresource(&batchv1.Job{}).Build(),
+			}
+			snapshot, err := w.TakeEtcdSnapshot(ctx, "etcd-snapshot", "snapshots")
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(snapshot).To(BeComparableTo(tt.wantSnapshot))
+
+			if tt.wantJobNode == "" {
+				return
+			}
+			got := &batchv1.Job{}
+			g.Expect(w.Client.Get(ctx, ctrlclient.ObjectKey{Namespace: metav1.NamespaceSystem, Name: "etcd-snapshot"}, got)).To(Succeed())
+			g.Expect(got.Labels).To(HaveKey(EtcdSnapshotJobLabel))
+			g.Expect(got.Spec.Template.Spec.NodeName).To(Equal(tt.wantJobNode))
+			g.Expect(got.Spec.Template.Spec.Containers).To(HaveLen(1))
+			g.Expect(got.Spec.Template.Spec.Containers[0].Image).To(Equal(tt.wantJobImage))
+			g.Expect(got.Spec.Template.Spec.Containers[0].Args).To(ContainElement("/var/lib/etcd-snapshots/etcd-snapshot.db"))
+			g.Expect(got.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("VolumeSource.PersistentVolumeClaim.ClaimName", "snapshots")))
+		})
+	}
+}
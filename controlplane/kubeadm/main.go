@@ -44,11 +44,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	kubeadmcontrolplanecontrollers "sigs.k8s.io/cluster-api/controlplane/kubeadm/controllers"
@@ -70,11 +72,12 @@ var (
 
 	// flags.
 	enableLeaderElection        bool
+	leaderElectionNamespace     string
 	leaderElectionLeaseDuration time.Duration
 	leaderElectionRenewDeadline time.Duration
 	leaderElectionRetryPeriod   time.Duration
 	watchFilterValue            string
-	watchNamespace              string
+	watchNamespace              []string
 	profilerAddress             string
 	enableContentionProfiling   bool
 	syncPeriod                  time.Duration
@@ -116,6 +119,9 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 
+	fs.StringVar(&leaderElectionNamespace, "leader-elect-namespace", "",
+		"Namespace where the leader election resource lock is created. If unspecified, the namespace the controller manager is running in is used.")
+
 	fs.DurationVar(&leaderElectionLeaseDuration, "leader-elect-lease-duration", 1*time.Minute,
 		"Interval at which non-leader candidates will wait to force acquire leadership (duration string)")
 
@@ -125,8 +131,8 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&leaderElectionRetryPeriod, "leader-elect-retry-period", 5*time.Second,
 		"Duration the LeaderElector clients should wait between tries of actions (duration string)")
 
-	fs.StringVar(&watchNamespace, "namespace", "",
-		"Namespace that the controller watches to reconcile cluster-api objects. If unspecified, the controller watches for cluster-api objects across all namespaces.")
+	fs.StringSliceVar(&watchNamespace, "namespace", nil,
+		"Comma-separated list of namespaces that the controller watches to reconcile cluster-api objects. If unspecified, the controller watches for cluster-api objects across all namespaces.")
 
 	fs.StringVar(&watchFilterValue, "watch-filter", "",
 		fmt.Sprintf("Label value that the controller watches to reconcile cluster-api objects. Label key is always %s. If unspecified, the controller watches for all cluster-api objects.", clusterv1.WatchLabel))
@@ -237,9 +243,10 @@ func main() {
 	}
 
 	var watchNamespaces map[string]cache.Config
-	if watchNamespace != "" {
-		watchNamespaces = map[string]cache.Config{
-			watchNamespace: {},
+	if len(watchNamespace) > 0 {
+		watchNamespaces = map[string]cache.Config{}
+		for _, namespace := range watchNamespace {
+			watchNamespaces[namespace] = cache.Config{}
 		}
 	}
 
@@ -254,6 +261,7 @@ func main() {
 		Scheme:                     scheme,
 		LeaderElection:             enableLeaderElection,
 		LeaderElectionID:           "kubeadm-control-plane-manager-leader-election-capi",
+		LeaderElectionNamespace:    leaderElectionNamespace,
 		LeaseDuration:              &leaderElectionLeaseDuration,
 		RenewDeadline:              &leaderElectionRenewDeadline,
 		RetryPeriod:                &leaderElectionRetryPeriod,
@@ -307,6 +315,7 @@ func main() {
 	ctx := ctrl.SetupSignalHandler()
 
 	setupChecks(mgr)
+	setupMetrics()
 	setupReconcilers(ctx, mgr)
 	setupWebhooks(mgr)
 
@@ -329,6 +338,17 @@ func setupChecks(mgr ctrl.Manager) {
 	}
 }
 
+func setupMetrics() {
+	if err := external.RegisterMetrics(ctrlmetrics.Registry); err != nil {
+		setupLog.Error(err, "unable to register metrics")
+		os.Exit(1)
+	}
+	if err := clustercache.RegisterMetrics(ctrlmetrics.Registry); err != nil {
+		setupLog.Error(err, "unable to register metrics")
+		os.Exit(1)
+	}
+}
+
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	secretCachingClient, err := client.New(mgr.GetConfig(), client.Options{
 		HTTPClient: mgr.GetHTTPClient(),
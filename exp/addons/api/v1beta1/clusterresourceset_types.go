@@ -49,6 +49,12 @@ type ClusterResourceSetSpec struct {
 	// +kubebuilder:validation:Enum=ApplyOnce;Reconcile
 	// +optional
 	Strategy string `json:"strategy,omitempty"`
+
+	// createPrerequisitesFirst defines if the Namespaces, PriorityClasses, ClusterRoles, ClusterRoleBindings, Roles
+	// and RoleBindings defined in any of the resources must be created before applying the resources, so resources
+	// do not depend on the order in which they are listed. Defaults to false.
+	// +optional
+	CreatePrerequisitesFirst bool `json:"createPrerequisitesFirst,omitempty"`
 }
 
 // ANCHOR_END: ClusterResourceSetSpec
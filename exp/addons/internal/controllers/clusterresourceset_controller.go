@@ -373,7 +373,8 @@ func (r *ClusterResourceSetReconciler) ApplyClusterResourceSet(ctx context.Conte
 		return errors.Wrapf(err, "failed to retrieve the Service for Kubernetes API Server of the cluster %s/%s", cluster.Namespace, cluster.Name)
 	}
 
-	// Iterate all resources and apply them to the cluster and update the resource status in the ClusterResourceSetBinding object.
+	// Iterate all resources and compute the scope for the ones which must be applied to the cluster.
+	resourceScopes := make([]resourceReconcileScope, len(clusterResourceSet.Spec.Resources))
 	for i, resource := range clusterResourceSet.Spec.Resources {
 		unstructuredObj := objList[i]
 		if unstructuredObj == nil {
@@ -397,6 +398,30 @@ func (r *ClusterResourceSetReconciler) ApplyClusterResourceSet(ctx context.Conte
 		if !resourceScope.needsApply() {
 			continue
 		}
+		resourceScopes[i] = resourceScope
+	}
+
+	// If requested, create Namespaces, PriorityClasses and RBAC rules defined in the resources to be applied before
+	// applying them, so resources do not depend on the order in which they are listed.
+	if clusterResourceSet.Spec.CreatePrerequisitesFirst {
+		toApply := []resourceReconcileScope{}
+		for _, resourceScope := range resourceScopes {
+			if resourceScope != nil {
+				toApply = append(toApply, resourceScope)
+			}
+		}
+		if err := ensurePrerequisitesCreated(ctx, remoteClient, toApply); err != nil {
+			conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedCondition, addonsv1.ApplyFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return errors.Wrap(err, "failed to create prerequisites for ClusterResourceSet resources")
+		}
+	}
+
+	// Iterate all resources and apply them to the cluster and update the resource status in the ClusterResourceSetBinding object.
+	for i, resource := range clusterResourceSet.Spec.Resources {
+		resourceScope := resourceScopes[i]
+		if resourceScope == nil {
+			continue
+		}
 
 		// Set status in ClusterResourceSetBinding in case of early continue due to a failure.
 		// Set only when resource is retrieved successfully.
@@ -29,11 +29,15 @@ import (
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -46,6 +50,18 @@ import (
 
 var jsonListPrefix = []byte("[")
 
+// prerequisiteGroupKinds are the kinds of objects which are created in the workload cluster before applying the resources
+// of a ClusterResourceSet, so objects defined in a resource can depend on objects of those kinds defined in any other resource,
+// e.g. CNI manifests targeting a non-default namespace for which the Namespace and the RBAC rules are defined separately.
+var prerequisiteGroupKinds = sets.New(
+	schema.GroupKind{Kind: "Namespace"},
+	schema.GroupKind{Group: schedulingv1.GroupName, Kind: "PriorityClass"},
+	schema.GroupKind{Group: rbacv1.GroupName, Kind: "ClusterRole"},
+	schema.GroupKind{Group: rbacv1.GroupName, Kind: "ClusterRoleBinding"},
+	schema.GroupKind{Group: rbacv1.GroupName, Kind: "Role"},
+	schema.GroupKind{Group: rbacv1.GroupName, Kind: "RoleBinding"},
+)
+
 // objsFromYamlData parses a collection of yaml documents into Unstructured objects.
 // The returned objects are sorted for creation priority within the objects defined
 // in the same document. The flattening of the documents preserves the original order.
@@ -244,6 +260,28 @@ func getClusterNameFromOwnerRef(obj metav1.ObjectMeta) (string, error) {
 	return "", errors.New("failed to find cluster name in ownerRefs: no cluster ownerRef")
 }
 
+// ensurePrerequisitesCreated creates the objects of the prerequisite kinds defined in the given resources, if they do
+// not exist yet; Namespaces are created first. Objects which already exist are not changed, they are reconciled
+// when the resource defining them is applied according to the ClusterResourceSet strategy.
+func ensurePrerequisitesCreated(ctx context.Context, c client.Client, resourceScopes []resourceReconcileScope) error {
+	prerequisites := []unstructured.Unstructured{}
+	for _, resourceScope := range resourceScopes {
+		for _, obj := range resourceScope.objs() {
+			if prerequisiteGroupKinds.Has(obj.GroupVersionKind().GroupKind()) {
+				prerequisites = append(prerequisites, *obj.DeepCopy())
+			}
+		}
+	}
+
+	errList := []error{}
+	for _, obj := range utilresource.SortForCreate(prerequisites) {
+		if err := createUnstructured(ctx, c, &obj); err != nil && !apierrors.IsAlreadyExists(err) {
+			errList = append(errList, err)
+		}
+	}
+	return kerrors.NewAggregate(errList)
+}
+
 // ensureKubernetesServiceCreated ensures that the Service for Kubernetes API Server has been created.
 func ensureKubernetesServiceCreated(ctx context.Context, client client.Client) error {
 	err := client.Get(ctx, types.NamespacedName{
@@ -22,7 +22,11 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -271,3 +275,76 @@ func TestEnsureKubernetesServiceCreated(t *testing.T) {
 		})
 	}
 }
+
+func TestEnsurePrerequisitesCreated(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(rbacv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(schedulingv1.AddToScheme(scheme)).To(Succeed())
+
+	crs := &addonsv1.ClusterResourceSet{
+		Spec: addonsv1.ClusterResourceSetSpec{
+			Strategy: string(addonsv1.ClusterResourceSetStrategyApplyOnce),
+		},
+	}
+	resourceScope := func(yaml string) resourceReconcileScope {
+		objs, err := objsFromYamlData([][]byte{[]byte(yaml)})
+		g.Expect(err).ToNot(HaveOccurred())
+		return newResourceReconcileScope(crs, addonsv1.ResourceRef{}, &addonsv1.ResourceSetBinding{}, nil, objs)
+	}
+
+	existingNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "existing",
+			Labels: map[string]string{"existing": "true"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingNamespace).Build()
+
+	resourceScopes := []resourceReconcileScope{
+		resourceScope(`apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: cni
+  namespace: cni-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: cni
+  namespace: cni-system`),
+		resourceScope(`apiVersion: scheduling.k8s.io/v1
+kind: PriorityClass
+metadata:
+  name: cni-critical
+value: 1000
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: cni-system
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: existing`),
+	}
+
+	g.Expect(ensurePrerequisitesCreated(ctx, c, resourceScopes)).To(Succeed())
+
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "cni-system"}, &corev1.Namespace{})).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "cni-critical"}, &schedulingv1.PriorityClass{})).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "cni-system", Name: "cni"}, &rbacv1.Role{})).To(Succeed())
+
+	// Existing objects are not changed.
+	namespace := &corev1.Namespace{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "existing"}, namespace)).To(Succeed())
+	g.Expect(namespace.Labels).To(HaveKeyWithValue("existing", "true"))
+
+	// Objects not of a prerequisite kind are only created when applying the resource.
+	err := c.Get(ctx, client.ObjectKey{Namespace: "cni-system", Name: "cni"}, &appsv1.DaemonSet{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
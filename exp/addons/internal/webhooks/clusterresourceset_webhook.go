@@ -94,9 +94,13 @@ func (webhook *ClusterResourceSet) validate(oldCRS, newCRS *addonsv1.ClusterReso
 	// NOTE: ClusterResourceSet is behind ClusterResourceSet feature gate flag; the web hook
 	// must prevent creating new objects when the feature flag is disabled.
 	if !feature.Gates.Enabled(feature.ClusterResourceSet) {
-		return field.Forbidden(
-			field.NewPath("spec"),
-			"can be set only if the ClusterResourceSet feature flag is enabled",
+		return apierrors.NewInvalid(
+			addonsv1.GroupVersion.WithKind("ClusterResourceSet").GroupKind(),
+			newCRS.Name,
+			field.ErrorList{field.Forbidden(
+				field.NewPath("spec"),
+				"can be set only if the ClusterResourceSet feature flag is enabled",
+			)},
 		)
 	}
 	var allErrs field.ErrorList
@@ -118,6 +122,22 @@ func (webhook *ClusterResourceSet) validate(oldCRS, newCRS *addonsv1.ClusterReso
 		)
 	}
 
+	// Validate that each resource is referenced only once, as it would be applied only once anyway.
+	// NOTE: This is only validated on create or when resources are changed, so existing ClusterResourceSets
+	// with duplicate resources can still be updated, e.g. to remove a finalizer.
+	if oldCRS == nil || !reflect.DeepEqual(oldCRS.Spec.Resources, newCRS.Spec.Resources) {
+		resources := map[addonsv1.ResourceRef]bool{}
+		for i, resource := range newCRS.Spec.Resources {
+			if resources[resource] {
+				allErrs = append(
+					allErrs,
+					field.Duplicate(field.NewPath("spec", "resources").Index(i), resource),
+				)
+			}
+			resources[resource] = true
+		}
+	}
+
 	if oldCRS != nil && oldCRS.Spec.Strategy != "" && oldCRS.Spec.Strategy != newCRS.Spec.Strategy {
 		allErrs = append(
 			allErrs,
@@ -213,3 +213,110 @@ func TestClusterResourceSetSelectorNotEmptyValidation(t *testing.T) {
 	g.Expect(err).To(HaveOccurred())
 	g.Expect(err.Error()).To(ContainSubstring("selector must not be empty"))
 }
+
+func TestClusterResourceSetDuplicateResourcesValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []addonsv1.ResourceRef
+		expectErr bool
+	}{
+		{
+			name: "should succeed with distinct resources",
+			resources: []addonsv1.ResourceRef{
+				{Name: "foo", Kind: string(addonsv1.ConfigMapClusterResourceSetResourceKind)},
+				{Name: "foo", Kind: string(addonsv1.SecretClusterResourceSetResourceKind)},
+			},
+			expectErr: false,
+		},
+		{
+			name: "should fail with duplicate resources",
+			resources: []addonsv1.ResourceRef{
+				{Name: "foo", Kind: string(addonsv1.ConfigMapClusterResourceSetResourceKind)},
+				{Name: "foo", Kind: string(addonsv1.ConfigMapClusterResourceSetResourceKind)},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterResourceSet := &addonsv1.ClusterResourceSet{
+				Spec: addonsv1.ClusterResourceSetSpec{
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"foo": "bar"},
+					},
+					Resources: tt.resources,
+				},
+			}
+			webhook := ClusterResourceSet{}
+			err := webhook.validate(nil, clusterResourceSet)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("Duplicate value"))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestClusterResourceSetDuplicateResourcesValidationOnUpdate(t *testing.T) {
+	duplicateResources := []addonsv1.ResourceRef{
+		{Name: "foo", Kind: string(addonsv1.ConfigMapClusterResourceSetResourceKind)},
+		{Name: "foo", Kind: string(addonsv1.ConfigMapClusterResourceSetResourceKind)},
+	}
+
+	tests := []struct {
+		name         string
+		oldResources []addonsv1.ResourceRef
+		newResources []addonsv1.ResourceRef
+		expectErr    bool
+	}{
+		{
+			name:         "should succeed if existing duplicate resources are not changed",
+			oldResources: duplicateResources,
+			newResources: duplicateResources,
+			expectErr:    false,
+		},
+		{
+			name: "should fail if resources are changed to contain duplicates",
+			oldResources: []addonsv1.ResourceRef{
+				{Name: "foo", Kind: string(addonsv1.ConfigMapClusterResourceSetResourceKind)},
+			},
+			newResources: duplicateResources,
+			expectErr:    true,
+		},
+		{
+			name:         "should fail if resources with duplicates are changed but still contain duplicates",
+			oldResources: duplicateResources,
+			newResources: append([]addonsv1.ResourceRef{{Name: "bar", Kind: string(addonsv1.SecretClusterResourceSetResourceKind)}}, duplicateResources...),
+			expectErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			oldClusterResourceSet := &addonsv1.ClusterResourceSet{
+				Spec: addonsv1.ClusterResourceSetSpec{
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"foo": "bar"},
+					},
+					Resources: tt.oldResources,
+				},
+			}
+			newClusterResourceSet := oldClusterResourceSet.DeepCopy()
+			newClusterResourceSet.Spec.Resources = tt.newResources
+
+			webhook := ClusterResourceSet{}
+			err := webhook.validate(oldClusterResourceSet, newClusterResourceSet)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("Duplicate value"))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
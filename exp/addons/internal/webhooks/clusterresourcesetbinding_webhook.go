@@ -76,9 +76,13 @@ func (webhook *ClusterResourceSetBinding) validate(oldCRSB, newCRSB *addonsv1.Cl
 	// NOTE: ClusterResourceSet is behind ClusterResourceSet feature gate flag; the web hook
 	// must prevent creating new objects in case the feature flag is disabled.
 	if !feature.Gates.Enabled(feature.ClusterResourceSet) {
-		return field.Forbidden(
-			field.NewPath("spec"),
-			"can be set only if the ClusterResourceSet feature flag is enabled",
+		return apierrors.NewInvalid(
+			addonsv1.GroupVersion.WithKind("ClusterResourceSetBinding").GroupKind(),
+			newCRSB.Name,
+			field.ErrorList{field.Forbidden(
+				field.NewPath("spec"),
+				"can be set only if the ClusterResourceSet feature flag is enabled",
+			)},
 		)
 	}
 	var allErrs field.ErrorList
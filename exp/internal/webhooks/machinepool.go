@@ -163,9 +163,13 @@ func (webhook *MachinePool) validate(oldObj, newObj *expv1.MachinePool) error {
 	// must prevent creating newObj objects when the feature flag is disabled.
 	specPath := field.NewPath("spec")
 	if !feature.Gates.Enabled(feature.MachinePool) {
-		return field.Forbidden(
-			specPath,
-			"can be set only if the MachinePool feature flag is enabled",
+		return apierrors.NewInvalid(
+			clusterv1.GroupVersion.WithKind("MachinePool").GroupKind(),
+			newObj.Name,
+			field.ErrorList{field.Forbidden(
+				specPath,
+				"can be set only if the MachinePool feature flag is enabled",
+			)},
 		)
 	}
 	var allErrs field.ErrorList
@@ -194,7 +198,7 @@ func (webhook *MachinePool) validate(oldObj, newObj *expv1.MachinePool) error {
 		allErrs = append(
 			allErrs,
 			field.Invalid(
-				specPath.Child("infrastructureRef", "namespace"),
+				specPath.Child("template", "spec", "infrastructureRef", "namespace"),
 				newObj.Spec.Template.Spec.InfrastructureRef.Namespace,
 				"must match metadata.namespace",
 			),
@@ -210,12 +214,28 @@ func (webhook *MachinePool) validate(oldObj, newObj *expv1.MachinePool) error {
 		)
 	}
 
+	if newObj.Spec.Replicas != nil && *newObj.Spec.Replicas < 0 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				specPath.Child("replicas"),
+				*newObj.Spec.Replicas,
+				"must be greater than or equal to 0",
+			),
+		)
+	}
+
 	if newObj.Spec.Template.Spec.Version != nil {
 		if !version.KubeSemver.MatchString(*newObj.Spec.Template.Spec.Version) {
 			allErrs = append(allErrs, field.Invalid(specPath.Child("template", "spec", "version"), *newObj.Spec.Template.Spec.Version, "must be a valid semantic version"))
 		}
 	}
 
+	// Taints are set on the Nodes by the Machine controller, which does not reconcile the Nodes of MachinePools.
+	if len(newObj.Spec.Template.Spec.Taints) > 0 {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("template", "spec", "taints"), "taints are not supported for MachinePools"))
+	}
+
 	// Validate the metadata of the MachinePool template.
 	allErrs = append(allErrs, newObj.Spec.Template.ObjectMeta.Validate(specPath.Child("template", "metadata"))...)
 
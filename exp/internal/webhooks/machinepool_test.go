@@ -22,14 +22,18 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/webhooks/util"
 )
 
@@ -228,6 +232,31 @@ func TestCalculateMachinePoolReplicas(t *testing.T) {
 	}
 }
 
+func TestMachinePoolFeatureGateValidation(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePool, false)
+	g := NewWithT(t)
+
+	mp := &expv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "mp"},
+		Spec: expv1.MachinePoolSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{ConfigRef: &corev1.ObjectReference{}},
+				},
+			},
+		},
+	}
+	webhook := &MachinePool{}
+
+	// The rejection should be a structured Invalid error pointing to the spec field.
+	_, err := webhook.ValidateCreate(ctx, mp)
+	g.Expect(apierrors.IsInvalid(err)).To(BeTrue())
+	var apiStatus apierrors.APIStatus
+	g.Expect(errors.As(err, &apiStatus)).To(BeTrue())
+	g.Expect(apiStatus.Status().Details.Causes).To(HaveLen(1))
+	g.Expect(apiStatus.Status().Details.Causes[0].Field).To(Equal("spec"))
+}
+
 func TestMachinePoolBootstrapValidation(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -417,6 +446,61 @@ func TestMachinePoolClusterNameImmutable(t *testing.T) {
 	}
 }
 
+func TestMachinePoolReplicasValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		replicas  *int32
+		expectErr bool
+	}{
+		{
+			name:      "should succeed if replicas is not set",
+			replicas:  nil,
+			expectErr: false,
+		},
+		{
+			name:      "should succeed if replicas is zero",
+			replicas:  ptr.To[int32](0),
+			expectErr: false,
+		},
+		{
+			name:      "should succeed if replicas is positive",
+			replicas:  ptr.To[int32](3),
+			expectErr: false,
+		},
+		{
+			name:      "should fail if replicas is negative",
+			replicas:  ptr.To[int32](-1),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mp := &expv1.MachinePool{
+				Spec: expv1.MachinePoolSpec{
+					Replicas: tt.replicas,
+					Template: clusterv1.MachineTemplateSpec{
+						Spec: clusterv1.MachineSpec{
+							Bootstrap: clusterv1.Bootstrap{ConfigRef: &corev1.ObjectReference{}},
+						},
+					},
+				},
+			}
+			webhook := &MachinePool{}
+
+			warnings, err := webhook.ValidateCreate(ctx, mp)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
+
 func TestMachinePoolVersionValidation(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -534,3 +618,23 @@ func TestMachinePoolMetadataValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestMachinePoolTaintsValidation(t *testing.T) {
+	g := NewWithT(t)
+
+	mp := &expv1.MachinePool{
+		Spec: expv1.MachinePoolSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{ConfigRef: &corev1.ObjectReference{}},
+					Taints:    []corev1.Taint{{Key: "foo", Effect: corev1.TaintEffectNoSchedule}},
+				},
+			},
+		},
+	}
+	webhook := &MachinePool{}
+
+	warnings, err := webhook.ValidateCreate(ctx, mp)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(warnings).To(BeEmpty())
+}
@@ -20,6 +20,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// ReconcileIDHeader is the HTTP header set on requests to Runtime Extensions with the ID of the
+// reconcile of the controller calling the extension, if any. It allows to correlate the logs of
+// the extension with the logs of the controller.
+const ReconcileIDHeader = "X-Cluster-API-Reconcile-ID"
+
 // RequestObject is a runtime.Object extended with methods to handle request-specific fields.
 // +kubebuilder:object:generate=false
 type RequestObject interface {
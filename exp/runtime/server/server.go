@@ -312,7 +312,12 @@ func (s *Server) callHandler(handler ExtensionHandler, r *http.Request) runtimeh
 
 	// log.Log is the logger previously set via ctrl.SetLogger.
 	// This implemented analog to the logger in the controller-runtime manager.
-	ctx := ctrl.LoggerInto(r.Context(), log.Log)
+	logger := log.Log
+	// Add the reconcileID of the calling controller, so the logs of the handler can be correlated with its logs.
+	if reconcileID := r.Header.Get(runtimehooksv1.ReconcileIDHeader); reconcileID != "" {
+		logger = logger.WithValues("reconcileID", reconcileID)
+	}
+	ctx := ctrl.LoggerInto(r.Context(), logger)
 
 	reflect.ValueOf(handler.HandlerFunc).Call([]reflect.Value{
 		reflect.ValueOf(ctx),
@@ -0,0 +1,233 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+const (
+	operationCreate  = "create"
+	operationUpgrade = "upgrade"
+	operationDelete  = "delete"
+)
+
+// generator creates, upgrades and deletes clusters.
+type generator struct {
+	client       client.Client
+	template     *clusterv1.Cluster
+	namespace    string
+	namePrefix   string
+	clusters     int
+	concurrency  int
+	upgradeTo    string
+	timeout      time.Duration
+	pollInterval time.Duration
+}
+
+// readClusterTemplate reads the Cluster to use as a template for the clusters from a YAML file.
+func readClusterTemplate(path string) (*clusterv1.Cluster, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+	objs, err := utilyaml.ToUnstructured(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) != 1 || objs[0].GetKind() != "Cluster" {
+		return nil, errors.Errorf("expected %s to contain exactly one Cluster", path)
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	cluster := &clusterv1.Cluster{}
+	if err := scheme.Convert(&objs[0], cluster, nil); err != nil {
+		return nil, errors.Wrapf(err, "failed to convert %s to a Cluster", path)
+	}
+	return cluster, nil
+}
+
+// run creates all the clusters, then upgrades them if an upgrade version is set, and finally deletes them if
+// deleteClusters is true; every operation is recorded in the report.
+func (g *generator) run(ctx context.Context, r *report, deleteClusters bool) {
+	if err := g.ensureNamespace(ctx); err != nil {
+		klog.Fatalf("Failed to create namespace %s: %v", g.namespace, err)
+	}
+
+	g.forEachCluster(ctx, r, operationCreate, g.createCluster)
+	if g.upgradeTo != "" {
+		g.forEachCluster(ctx, r, operationUpgrade, g.upgradeCluster)
+	}
+	if deleteClusters {
+		g.forEachCluster(ctx, r, operationDelete, g.deleteCluster)
+	}
+}
+
+// forEachCluster runs an operation for all the clusters, running up to g.concurrency operations concurrently.
+func (g *generator) forEachCluster(ctx context.Context, r *report, operation string, f func(ctx context.Context, name string) error) {
+	names := make(chan string)
+	wg := &sync.WaitGroup{}
+	for range g.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range names {
+				start := time.Now()
+				err := f(ctx, name)
+				if err != nil {
+					klog.Errorf("Failed to %s Cluster %s: %v", operation, klog.KRef(g.namespace, name), err)
+				} else {
+					klog.V(2).Infof("Completed %s of Cluster %s in %s", operation, klog.KRef(g.namespace, name), time.Since(start))
+				}
+				r.add(operation, name, time.Since(start), err)
+			}
+		}()
+	}
+
+	start := time.Now()
+	digits := 1 + int(math.Log10(float64(g.clusters)))
+	for i := 1; i <= g.clusters; i++ {
+		// This ensures we always have the right number of leading zeros in the cluster names, so they sort properly.
+		names <- fmt.Sprintf("%s-%0*d", g.namePrefix, digits, i)
+	}
+	close(names)
+	wg.Wait()
+	klog.Infof("Completed %s of %d clusters in %s", operation, g.clusters, time.Since(start))
+}
+
+func (g *generator) ensureNamespace(ctx context.Context) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: g.namespace}}
+	if err := g.client.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// createCluster creates a cluster from the template and waits for it to be available.
+func (g *generator) createCluster(ctx context.Context, name string) error {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   g.namespace,
+			Labels:      g.template.Labels,
+			Annotations: g.template.Annotations,
+		},
+		Spec: *g.template.Spec.DeepCopy(),
+	}
+	if err := g.client.Create(ctx, cluster); err != nil {
+		return errors.Wrap(err, "failed to create Cluster")
+	}
+
+	return g.waitFor(ctx, name, func(cluster *clusterv1.Cluster) (bool, error) {
+		return isAvailable(cluster), nil
+	})
+}
+
+// upgradeCluster upgrades a cluster to the upgrade version and waits for all its Machines to be upgraded.
+func (g *generator) upgradeCluster(ctx context.Context, name string) error {
+	cluster := &clusterv1.Cluster{}
+	if err := g.client.Get(ctx, client.ObjectKey{Namespace: g.namespace, Name: name}, cluster); err != nil {
+		return errors.Wrap(err, "failed to get Cluster")
+	}
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Spec.Topology.Version = g.upgradeTo
+	if err := g.client.Patch(ctx, cluster, patch); err != nil {
+		return errors.Wrap(err, "failed to patch Cluster")
+	}
+
+	return g.waitFor(ctx, name, func(cluster *clusterv1.Cluster) (bool, error) {
+		if !isAvailable(cluster) || !v1beta2conditions.IsTrue(cluster, clusterv1.ClusterMachinesUpToDateV1Beta2Condition) {
+			return false, nil
+		}
+		machines := &clusterv1.MachineList{}
+		if err := g.client.List(ctx, machines, client.InNamespace(g.namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: name}); err != nil {
+			return false, errors.Wrap(err, "failed to list Machines")
+		}
+		for _, m := range machines.Items {
+			if m.Spec.Version == nil || *m.Spec.Version != g.upgradeTo {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// deleteCluster deletes a cluster and waits for it to be gone.
+func (g *generator) deleteCluster(ctx context.Context, name string) error {
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: g.namespace, Name: name}}
+	if err := g.client.Delete(ctx, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to delete Cluster")
+	}
+
+	return wait.PollUntilContextTimeout(ctx, g.pollInterval, g.timeout, false, func(ctx context.Context) (bool, error) {
+		if err := g.client.Get(ctx, client.ObjectKeyFromObject(cluster), cluster); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			klog.V(4).Infof("Failed to get Cluster %s: %v", klog.KObj(cluster), err)
+		}
+		return false, nil
+	})
+}
+
+// waitFor waits until the condition is true for a cluster.
+// Errors getting the Cluster are retried until the timeout expires.
+func (g *generator) waitFor(ctx context.Context, name string, condition func(cluster *clusterv1.Cluster) (bool, error)) error {
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, g.pollInterval, g.timeout, false, func(ctx context.Context) (bool, error) {
+		cluster := &clusterv1.Cluster{}
+		if err := g.client.Get(ctx, client.ObjectKey{Namespace: g.namespace, Name: name}, cluster); err != nil {
+			lastErr = err
+			return false, nil
+		}
+		done, err := condition(cluster)
+		lastErr = err
+		return done, nil
+	})
+	if err != nil && lastErr != nil {
+		return errors.Wrap(lastErr, err.Error())
+	}
+	return err
+}
+
+// isAvailable returns true if the Cluster is up to date with its spec and it is available.
+func isAvailable(cluster *clusterv1.Cluster) bool {
+	return cluster.Status.ObservedGeneration >= cluster.Generation &&
+		v1beta2conditions.IsTrue(cluster, clusterv1.ClusterAvailableV1Beta2Condition)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const clusterTemplateYAML = `apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: template
+spec:
+  topology:
+    class: in-memory-quick-start
+    version: v1.30.0
+    controlPlane:
+      replicas: 1
+`
+
+func TestReadClusterTemplate(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cluster.yaml")
+	g.Expect(os.WriteFile(path, []byte(clusterTemplateYAML), 0o600)).To(Succeed())
+
+	cluster, err := readClusterTemplate(path)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cluster.Spec.Topology).ToNot(BeNil())
+	g.Expect(cluster.Spec.Topology.Class).To(Equal("in-memory-quick-start"))
+
+	// Fails if the file does not contain exactly one Cluster.
+	g.Expect(os.WriteFile(path, []byte(clusterTemplateYAML+"---\n"+clusterTemplateYAML), 0o600)).To(Succeed())
+	_, err = readClusterTemplate(path)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestGeneratorRun(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cluster.yaml")
+	g.Expect(os.WriteFile(path, []byte(clusterTemplateYAML), 0o600)).To(Succeed())
+	template, err := readClusterTemplate(path)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	// Simulate the Cluster API controllers by making Clusters available as soon as they are created.
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if cluster, ok := obj.(*clusterv1.Cluster); ok {
+				cluster.Status.V1Beta2 = &clusterv1.ClusterV1Beta2Status{Conditions: []metav1.Condition{
+					{Type: clusterv1.ClusterAvailableV1Beta2Condition, Status: metav1.ConditionTrue, Reason: clusterv1.ClusterAvailableV1Beta2Reason},
+					{Type: clusterv1.ClusterMachinesUpToDateV1Beta2Condition, Status: metav1.ConditionTrue, Reason: clusterv1.ClusterMachinesUpToDateV1Beta2Reason},
+				}}
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+
+	gen := &generator{
+		client:       c,
+		template:     template,
+		namespace:    "loadgen",
+		namePrefix:   "test",
+		clusters:     12,
+		concurrency:  5,
+		upgradeTo:    "v1.31.0",
+		timeout:      10 * time.Second,
+		pollInterval: 10 * time.Millisecond,
+	}
+	r := newReport()
+	gen.run(context.Background(), r, false)
+
+	clusters := &clusterv1.ClusterList{}
+	g.Expect(c.List(context.Background(), clusters, client.InNamespace("loadgen"))).To(Succeed())
+	g.Expect(clusters.Items).To(HaveLen(12))
+	g.Expect(clusters.Items[0].Name).To(Equal("test-01"))
+	g.Expect(clusters.Items[0].Spec.Topology.Version).To(Equal("v1.31.0"))
+
+	gen.run(context.Background(), r, true)
+	g.Expect(c.List(context.Background(), clusters, client.InNamespace("loadgen"))).To(Succeed())
+	g.Expect(clusters.Items).To(BeEmpty())
+
+	// Clusters already existing in the second iteration are reported as failed creations.
+	summaries := r.summaries()
+	g.Expect(summaries).To(HaveLen(3))
+	g.Expect(summaries[0]).To(And(HaveField("Operation", operationCreate), HaveField("Count", 24), HaveField("Failures", 12)))
+	g.Expect(summaries[1]).To(And(HaveField("Operation", operationUpgrade), HaveField("Count", 24), HaveField("Failures", 0)))
+	g.Expect(summaries[2]).To(And(HaveField("Operation", operationDelete), HaveField("Count", 12), HaveField("Failures", 0)))
+}
+
+func TestReport(t *testing.T) {
+	g := NewWithT(t)
+
+	r := newReport()
+	for i := 1; i <= 100; i++ {
+		var err error
+		if i%10 == 0 {
+			err = os.ErrDeadlineExceeded
+		}
+		r.add(operationCreate, "cluster", time.Duration(i)*time.Second, err)
+	}
+	r.add(operationDelete, "cluster", time.Second, nil)
+
+	summaries := r.summaries()
+	g.Expect(summaries).To(Equal([]summary{
+		{
+			Operation: operationCreate,
+			Count:     100,
+			Failures:  10,
+			Min:       1 * time.Second,
+			Avg:       50*time.Second + 500*time.Millisecond,
+			P50:       50 * time.Second,
+			P90:       90 * time.Second,
+			P99:       99 * time.Second,
+			Max:       100 * time.Second,
+		},
+		{
+			Operation: operationDelete,
+			Count:     1,
+			Min:       time.Second,
+			Avg:       time.Second,
+			P50:       time.Second,
+			P90:       time.Second,
+			P99:       time.Second,
+			Max:       time.Second,
+		},
+	}))
+	g.Expect(r.failures()).To(Equal(10))
+
+	out := &bytes.Buffer{}
+	r.print(out)
+	g.Expect(out.String()).To(ContainSubstring("create     100    10"))
+	g.Expect(out.String()).To(ContainSubstring("create cluster: i/o timeout"))
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	g.Expect(r.write(path)).To(Succeed())
+	data, err := os.ReadFile(path) //nolint:gosec
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`"operation": "delete"`))
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// main is the main package for loadgen.
+package main
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+var (
+	clusterTemplate = flag.String("cluster-template", "", "Path to a YAML file containing the ClusterClass based Cluster to use as a template for the clusters")
+	namespace       = flag.String("namespace", "default", "Namespace to create the clusters in")
+	namePrefix      = flag.String("name-prefix", "loadgen", "Prefix of the names of the clusters")
+	clusters        = flag.Int("clusters", 100, "Number of clusters to create")
+	concurrency     = flag.Int("concurrency", 10, "Number of clusters to create, upgrade and delete concurrently")
+	iterations      = flag.Int("iterations", 1, "Number of times to repeat creating, upgrading and deleting the clusters, e.g. for soak tests")
+	upgradeTo       = flag.String("upgrade-to", "", "Kubernetes version to upgrade the clusters to after they are available; if empty, clusters are not upgraded")
+	skipDelete      = flag.Bool("skip-delete", false, "Do not delete the clusters at the end of the last iteration")
+	timeout         = flag.Duration("timeout", 30*time.Minute, "Timeout for each operation on a cluster")
+	pollInterval    = flag.Duration("poll-interval", 10*time.Second, "Interval for checking the status of the clusters")
+	reportFile      = flag.String("report", "", "Path to the file to write the JSON report to; if empty, only a summary is printed")
+)
+
+func main() {
+	// Parse flags and validate input.
+	// Note: the kubeconfig flag is registered by controller-runtime.
+	flag.Parse()
+	if *clusterTemplate == "" {
+		klog.Fatal("Expected flag \"cluster-template\" to be set")
+	}
+	if *clusters < 1 || *concurrency < 1 || *iterations < 1 {
+		klog.Fatal("Expected flags \"clusters\", \"concurrency\" and \"iterations\" to be greater than 0")
+	}
+
+	template, err := readClusterTemplate(*clusterTemplate)
+	if err != nil {
+		klog.Fatalf("Failed to read cluster template: %v", err)
+	}
+	if *upgradeTo != "" && template.Spec.Topology == nil {
+		klog.Fatal("Expected the cluster template to use a ClusterClass when flag \"upgrade-to\" is set")
+	}
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		klog.Fatalf("Failed to create client: %v", err)
+	}
+
+	g := &generator{
+		client:       c,
+		template:     template,
+		namespace:    *namespace,
+		namePrefix:   *namePrefix,
+		clusters:     *clusters,
+		concurrency:  *concurrency,
+		upgradeTo:    *upgradeTo,
+		timeout:      *timeout,
+		pollInterval: *pollInterval,
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+	r := newReport()
+	for i := range *iterations {
+		klog.Infof("Starting iteration %d of %d", i+1, *iterations)
+		g.run(ctx, r, !*skipDelete || i < *iterations-1)
+	}
+
+	r.print(os.Stdout)
+	if *reportFile != "" {
+		if err := r.write(*reportFile); err != nil {
+			klog.Fatalf("Failed to write report: %v", err)
+		}
+	}
+	if r.failures() > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// maxReportedErrors is the maximum number of errors printed for each operation.
+const maxReportedErrors = 10
+
+// result is the result of an operation on a cluster.
+type result struct {
+	Operation string        `json:"operation"`
+	Cluster   string        `json:"cluster"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// summary is the latency distribution and the number of failures of an operation across all the clusters.
+type summary struct {
+	Operation string        `json:"operation"`
+	Count     int           `json:"count"`
+	Failures  int           `json:"failures"`
+	Min       time.Duration `json:"min"`
+	Avg       time.Duration `json:"avg"`
+	P50       time.Duration `json:"p50"`
+	P90       time.Duration `json:"p90"`
+	P99       time.Duration `json:"p99"`
+	Max       time.Duration `json:"max"`
+}
+
+// report collects the results of the operations.
+type report struct {
+	lock       sync.Mutex
+	operations []string
+	results    map[string][]result
+}
+
+func newReport() *report {
+	return &report{results: map[string][]result{}}
+}
+
+// add records the result of an operation on a cluster; it is safe for concurrent use.
+func (r *report) add(operation, cluster string, duration time.Duration, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.results[operation]; !ok {
+		r.operations = append(r.operations, operation)
+	}
+	res := result{Operation: operation, Cluster: cluster, Duration: duration}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	r.results[operation] = append(r.results[operation], res)
+}
+
+// summaries returns the summary of each operation, in the order the operations have been first recorded.
+func (r *report) summaries() []summary {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	summaries := make([]summary, 0, len(r.operations))
+	for _, operation := range r.operations {
+		results := r.results[operation]
+		s := summary{Operation: operation, Count: len(results)}
+
+		durations := make([]time.Duration, 0, len(results))
+		var total time.Duration
+		for _, res := range results {
+			if res.Error != "" {
+				s.Failures++
+			}
+			durations = append(durations, res.Duration)
+			total += res.Duration
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+		percentile := func(p int) time.Duration {
+			return durations[(len(durations)-1)*p/100]
+		}
+		s.Min, s.Max = durations[0], durations[len(durations)-1]
+		s.Avg = total / time.Duration(len(durations))
+		s.P50, s.P90, s.P99 = percentile(50), percentile(90), percentile(99)
+		summaries = append(summaries, s)
+	}
+	return summaries
+}
+
+// failures returns the number of failed operations.
+func (r *report) failures() int {
+	failures := 0
+	for _, s := range r.summaries() {
+		failures += s.Failures
+	}
+	return failures
+}
+
+// print prints the summary of each operation and the first errors of each operation.
+func (r *report) print(out io.Writer) {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "OPERATION\tCOUNT\tFAILURES\tMIN\tAVG\tP50\tP90\tP99\tMAX")
+	for _, s := range r.summaries() {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n", s.Operation, s.Count, s.Failures,
+			s.Min.Round(time.Second), s.Avg.Round(time.Second), s.P50.Round(time.Second), s.P90.Round(time.Second), s.P99.Round(time.Second), s.Max.Round(time.Second))
+	}
+	_ = w.Flush()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, operation := range r.operations {
+		reported := 0
+		for _, res := range r.results[operation] {
+			if res.Error == "" {
+				continue
+			}
+			if reported == maxReportedErrors {
+				fmt.Fprintf(out, "... more %s errors omitted\n", operation)
+				break
+			}
+			fmt.Fprintf(out, "%s %s: %s\n", operation, res.Cluster, res.Error)
+			reported++
+		}
+	}
+}
+
+// write writes the summaries and the results of all the operations as JSON to a file.
+func (r *report) write(path string) error {
+	summaries := r.summaries()
+
+	r.lock.Lock()
+	results := []result{}
+	for _, operation := range r.operations {
+		results = append(results, r.results[operation]...)
+	}
+	r.lock.Unlock()
+
+	data, err := json.MarshalIndent(struct {
+		Summaries []summary `json:"summaries"`
+		Results   []result  `json:"results"`
+	}{Summaries: summaries, Results: results}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
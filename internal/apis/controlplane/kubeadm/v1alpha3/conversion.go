@@ -55,6 +55,9 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.MachineNamingStrategy = restored.Spec.MachineNamingStrategy
 	}
 
+	dst.Spec.PreUpgradeEtcdSnapshot = restored.Spec.PreUpgradeEtcdSnapshot
+	dst.Status.LastEtcdSnapshot = restored.Status.LastEtcdSnapshot
+
 	bootstrapv1alpha3.MergeRestoredKubeadmConfigSpec(&dst.Spec.KubeadmConfigSpec, &restored.Spec.KubeadmConfigSpec)
 
 	dst.Status.Version = restored.Status.Version
@@ -99,6 +102,7 @@ func Convert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha3_KubeadmControlPlaneSpec
 
 func Convert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha3_KubeadmControlPlaneStatus(in *controlplanev1.KubeadmControlPlaneStatus, out *KubeadmControlPlaneStatus, s apiconversion.Scope) error {
 	// NOTE: custom conversion func is required because status.Version does not exist in v1alpha3.
+	// .LastEtcdSnapshot was added in v1beta1.
 	// .V1Beta2 was added in v1beta1.
 	return autoConvert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha3_KubeadmControlPlaneStatus(in, out, s)
 }
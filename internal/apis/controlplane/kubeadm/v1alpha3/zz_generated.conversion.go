@@ -214,6 +214,7 @@ func autoConvert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha3_KubeadmControlPlane
 	out.RolloutStrategy = (*RolloutStrategy)(unsafe.Pointer(in.RolloutStrategy))
 	// WARNING: in.RemediationStrategy requires manual conversion: does not exist in peer-type
 	// WARNING: in.MachineNamingStrategy requires manual conversion: does not exist in peer-type
+	// WARNING: in.PreUpgradeEtcdSnapshot requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -251,6 +252,7 @@ func autoConvert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha3_KubeadmControlPla
 	out.ObservedGeneration = in.ObservedGeneration
 	out.Conditions = *(*corev1alpha3.Conditions)(unsafe.Pointer(&in.Conditions))
 	// WARNING: in.LastRemediation requires manual conversion: does not exist in peer-type
+	// WARNING: in.LastEtcdSnapshot requires manual conversion: does not exist in peer-type
 	// WARNING: in.V1Beta2 requires manual conversion: does not exist in peer-type
 	return nil
 }
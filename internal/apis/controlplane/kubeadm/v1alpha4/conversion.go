@@ -57,6 +57,9 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.MachineNamingStrategy = restored.Spec.MachineNamingStrategy
 	}
 
+	dst.Spec.PreUpgradeEtcdSnapshot = restored.Spec.PreUpgradeEtcdSnapshot
+	dst.Status.LastEtcdSnapshot = restored.Status.LastEtcdSnapshot
+
 	bootstrapv1alpha4.MergeRestoredKubeadmConfigSpec(&dst.Spec.KubeadmConfigSpec, &restored.Spec.KubeadmConfigSpec)
 	dst.Status.V1Beta2 = restored.Status.V1Beta2
 
@@ -123,6 +126,8 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Spec.MachineNamingStrategy = restored.Spec.Template.Spec.MachineNamingStrategy
 	}
 
+	dst.Spec.Template.Spec.PreUpgradeEtcdSnapshot = restored.Spec.Template.Spec.PreUpgradeEtcdSnapshot
+
 	bootstrapv1alpha4.MergeRestoredKubeadmConfigSpec(&dst.Spec.Template.Spec.KubeadmConfigSpec, &restored.Spec.Template.Spec.KubeadmConfigSpec)
 
 	return nil
@@ -215,11 +220,13 @@ func Convert_v1beta1_KubeadmControlPlaneMachineTemplate_To_v1alpha4_KubeadmContr
 func Convert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha4_KubeadmControlPlaneSpec(in *controlplanev1.KubeadmControlPlaneSpec, out *KubeadmControlPlaneSpec, scope apiconversion.Scope) error {
 	// .RolloutBefore was added in v1beta1.
 	// .RemediationStrategy was added in v1beta1.
+	// .PreUpgradeEtcdSnapshot was added in v1beta1.
 	return autoConvert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha4_KubeadmControlPlaneSpec(in, out, scope)
 }
 
 func Convert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha4_KubeadmControlPlaneStatus(in *controlplanev1.KubeadmControlPlaneStatus, out *KubeadmControlPlaneStatus, scope apiconversion.Scope) error {
 	// .LastRemediation was added in v1beta1.
+	// .LastEtcdSnapshot was added in v1beta1.
 	// .V1Beta2 was added in v1beta1.
 	return autoConvert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha4_KubeadmControlPlaneStatus(in, out, scope)
 }
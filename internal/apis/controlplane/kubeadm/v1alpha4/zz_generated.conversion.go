@@ -318,6 +318,7 @@ func autoConvert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha4_KubeadmControlPlane
 	out.RolloutStrategy = (*RolloutStrategy)(unsafe.Pointer(in.RolloutStrategy))
 	// WARNING: in.RemediationStrategy requires manual conversion: does not exist in peer-type
 	// WARNING: in.MachineNamingStrategy requires manual conversion: does not exist in peer-type
+	// WARNING: in.PreUpgradeEtcdSnapshot requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -356,6 +357,7 @@ func autoConvert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha4_KubeadmControlPla
 	out.ObservedGeneration = in.ObservedGeneration
 	out.Conditions = *(*corev1alpha4.Conditions)(unsafe.Pointer(&in.Conditions))
 	// WARNING: in.LastRemediation requires manual conversion: does not exist in peer-type
+	// WARNING: in.LastEtcdSnapshot requires manual conversion: does not exist in peer-type
 	// WARNING: in.V1Beta2 requires manual conversion: does not exist in peer-type
 	return nil
 }
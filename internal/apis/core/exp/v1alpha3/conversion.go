@@ -74,6 +74,7 @@ func (src *MachinePool) ConvertTo(dstRaw conversion.Hub) error {
 	}
 	dst.Spec.Template.Spec.ReadinessGates = restored.Spec.Template.Spec.ReadinessGates
 	dst.Spec.Template.Spec.NodeDeletionTimeout = restored.Spec.Template.Spec.NodeDeletionTimeout
+	dst.Spec.Template.Spec.Taints = restored.Spec.Template.Spec.Taints
 	dst.Spec.Template.Spec.NodeVolumeDetachTimeout = restored.Spec.Template.Spec.NodeVolumeDetachTimeout
 	dst.Status.V1Beta2 = restored.Status.V1Beta2
 
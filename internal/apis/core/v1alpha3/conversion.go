@@ -49,6 +49,7 @@ func (src *Cluster) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.Topology != nil {
 		dst.Spec.Topology = restored.Spec.Topology
 	}
+	dst.Status.APIServerVersion = restored.Status.APIServerVersion
 	dst.Status.V1Beta2 = restored.Status.V1Beta2
 
 	return nil
@@ -101,10 +102,13 @@ func (src *Machine) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.ReadinessGates = restored.Spec.ReadinessGates
 	dst.Spec.NodeDeletionTimeout = restored.Spec.NodeDeletionTimeout
+	dst.Spec.Taints = restored.Spec.Taints
 	dst.Spec.NodeVolumeDetachTimeout = restored.Spec.NodeVolumeDetachTimeout
 	dst.Status.NodeInfo = restored.Status.NodeInfo
 	dst.Status.CertificatesExpiryDate = restored.Status.CertificatesExpiryDate
 	dst.Status.Deletion = restored.Status.Deletion
+	dst.Status.Timeline = restored.Status.Timeline
+	dst.Status.Image = restored.Status.Image
 	dst.Status.V1Beta2 = restored.Status.V1Beta2
 
 	return nil
@@ -150,6 +154,7 @@ func (src *MachineSet) ConvertTo(dstRaw conversion.Hub) error {
 	}
 	dst.Spec.Template.Spec.ReadinessGates = restored.Spec.Template.Spec.ReadinessGates
 	dst.Spec.Template.Spec.NodeDeletionTimeout = restored.Spec.Template.Spec.NodeDeletionTimeout
+	dst.Spec.Template.Spec.Taints = restored.Spec.Template.Spec.Taints
 	dst.Spec.Template.Spec.NodeVolumeDetachTimeout = restored.Spec.Template.Spec.NodeVolumeDetachTimeout
 	dst.Status.Conditions = restored.Status.Conditions
 	dst.Status.V1Beta2 = restored.Status.V1Beta2
@@ -211,6 +216,7 @@ func (src *MachineDeployment) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.Template.Spec.ReadinessGates = restored.Spec.Template.Spec.ReadinessGates
 	dst.Spec.Template.Spec.NodeDeletionTimeout = restored.Spec.Template.Spec.NodeDeletionTimeout
+	dst.Spec.Template.Spec.Taints = restored.Spec.Template.Spec.Taints
 	dst.Spec.Template.Spec.NodeVolumeDetachTimeout = restored.Spec.Template.Spec.NodeVolumeDetachTimeout
 	dst.Spec.RolloutAfter = restored.Spec.RolloutAfter
 	dst.Status.Conditions = restored.Status.Conditions
@@ -554,6 +554,7 @@ func autoConvert_v1beta1_ClusterStatus_To_v1alpha3_ClusterStatus(in *v1beta1.Clu
 	out.ControlPlaneReady = in.ControlPlaneReady
 	out.Conditions = *(*Conditions)(unsafe.Pointer(&in.Conditions))
 	out.ObservedGeneration = in.ObservedGeneration
+	// WARNING: in.APIServerVersion requires manual conversion: does not exist in peer-type
 	// WARNING: in.V1Beta2 requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -1206,6 +1207,7 @@ func autoConvert_v1beta1_MachineSpec_To_v1alpha3_MachineSpec(in *v1beta1.Machine
 	out.NodeDrainTimeout = (*metav1.Duration)(unsafe.Pointer(in.NodeDrainTimeout))
 	// WARNING: in.NodeVolumeDetachTimeout requires manual conversion: does not exist in peer-type
 	// WARNING: in.NodeDeletionTimeout requires manual conversion: does not exist in peer-type
+	// WARNING: in.Taints requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1238,6 +1240,8 @@ func autoConvert_v1beta1_MachineStatus_To_v1alpha3_MachineStatus(in *v1beta1.Mac
 	out.ObservedGeneration = in.ObservedGeneration
 	out.Conditions = *(*Conditions)(unsafe.Pointer(&in.Conditions))
 	// WARNING: in.Deletion requires manual conversion: does not exist in peer-type
+	// WARNING: in.Timeline requires manual conversion: does not exist in peer-type
+	// WARNING: in.Image requires manual conversion: does not exist in peer-type
 	// WARNING: in.V1Beta2 requires manual conversion: does not exist in peer-type
 	return nil
 }
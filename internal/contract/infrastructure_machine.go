@@ -93,6 +93,13 @@ func (m *InfrastructureMachineContract) FailureDomain() *String {
 	}
 }
 
+// Image provides access to the status.image field in an InfrastructureMachine object. Note that this field is optional.
+func (m *InfrastructureMachineContract) Image() *String {
+	return &String{
+		path: []string{"status", "image"},
+	}
+}
+
 // MachineAddresses represents an accessor to a []clusterv1.MachineAddress path value.
 type MachineAddresses struct {
 	path Path
@@ -35,6 +35,13 @@ func InfrastructureMachineTemplate() *InfrastructureMachineTemplateContract {
 	return infrastructureMachineTemplate
 }
 
+// Image provides access to the status.image field in an InfrastructureMachineTemplate object. Note that this field is optional.
+func (c *InfrastructureMachineTemplateContract) Image() *String {
+	return &String{
+		path: []string{"status", "image"},
+	}
+}
+
 // Template provides access to the template.
 func (c *InfrastructureMachineTemplateContract) Template() *InfrastructureMachineTemplateTemplate {
 	return &InfrastructureMachineTemplateTemplate{}
@@ -116,4 +116,17 @@ func TestInfrastructureMachine(t *testing.T) {
 		g.Expect(got).ToNot(BeNil())
 		g.Expect(*got).To(Equal("fake-failure-domain"))
 	})
+	t.Run("Manages optional status.image", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(InfrastructureMachine().Image().Path()).To(Equal(Path{"status", "image"}))
+
+		err := InfrastructureMachine().Image().Set(obj, "fake-image")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		got, err := InfrastructureMachine().Image().Get(obj)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got).ToNot(BeNil())
+		g.Expect(*got).To(Equal("fake-image"))
+	})
 }
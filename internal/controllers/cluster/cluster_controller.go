@@ -30,8 +30,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -48,10 +50,11 @@ import (
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/hooks"
+	"sigs.k8s.io/cluster-api/internal/util/cache"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
-	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
 	"sigs.k8s.io/cluster-api/util/finalizers"
 	clog "sigs.k8s.io/cluster-api/util/log"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -63,6 +66,10 @@ const (
 	// deleteRequeueAfter is how long to wait before checking again to see if the cluster still has children during
 	// deletion.
 	deleteRequeueAfter = 5 * time.Second
+
+	// apiServerVersionDiscoveryInterval is the interval at which the version of the API server of a workload cluster
+	// is discovered.
+	apiServerVersionDiscoveryInterval = 5 * time.Minute
 )
 
 // Update permissions on /finalizers subresrouce is required on management clusters with 'OwnerReferencesPermissionEnforcement' plugin enabled.
@@ -80,6 +87,10 @@ type Reconciler struct {
 	APIReader    client.Reader
 	ClusterCache clustercache.ClusterCache
 
+	// SecretCachingClient is a client which reads Secrets with the cluster name label from the cache.
+	// If not set, Client is used instead.
+	SecretCachingClient client.Client
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
@@ -87,12 +98,19 @@ type Reconciler struct {
 
 	recorder        record.EventRecorder
 	externalTracker external.ObjectTracker
+
+	// apiServerVersionDiscoveryCache is used to store when the version of the API server of a workload cluster
+	// should be discovered again.
+	apiServerVersionDiscoveryCache cache.Cache[cache.ReconcileEntry]
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	if r.Client == nil || r.APIReader == nil || r.ClusterCache == nil || r.RemoteConnectionGracePeriod == time.Duration(0) {
 		return errors.New("Client, APIReader and ClusterCache must not be nil and RemoteConnectionGracePeriod must not be 0")
 	}
+	if r.SecretCachingClient == nil {
+		r.SecretCachingClient = r.Client
+	}
 
 	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "cluster")
 	c, err := ctrl.NewControllerManagedBy(mgr).
@@ -121,6 +139,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	}
 
 	r.recorder = mgr.GetEventRecorderFor("cluster-controller")
+	r.apiServerVersionDiscoveryCache = cache.New[cache.ReconcileEntry]()
 	r.externalTracker = external.ObjectTracker{
 		Controller:      c,
 		Cache:           mgr.GetCache(),
@@ -147,7 +166,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retRes ct
 	}
 
 	// Add finalizer first if not set to avoid the race condition between init and delete.
-	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, cluster, clusterv1.ClusterFinalizer); err != nil || finalizerAdded {
+	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, cluster, clusterv1.ClusterFinalizer, finalizers.WithEventRecorder(r.recorder)); err != nil || finalizerAdded {
 		return ctrl.Result{}, err
 	}
 
@@ -185,26 +204,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retRes ct
 	}()
 
 	lastProbeSuccessTime := r.ClusterCache.GetLastProbeSuccessTimestamp(ctx, client.ObjectKeyFromObject(cluster))
-	if time.Since(lastProbeSuccessTime) > r.RemoteConnectionGracePeriod {
-		var msg string
-		if lastProbeSuccessTime.IsZero() {
-			msg = "Remote connection probe failed"
-		} else {
-			msg = fmt.Sprintf("Remote connection probe failed, probe last succeeded at %s", lastProbeSuccessTime.Format(time.RFC3339))
-		}
-		v1beta2conditions.Set(cluster, metav1.Condition{
-			Type:    clusterv1.ClusterRemoteConnectionProbeV1Beta2Condition,
-			Status:  metav1.ConditionFalse,
-			Reason:  clusterv1.ClusterRemoteConnectionProbeFailedV1Beta2Reason,
-			Message: msg,
-		})
-	} else {
-		v1beta2conditions.Set(cluster, metav1.Condition{
-			Type:   clusterv1.ClusterRemoteConnectionProbeV1Beta2Condition,
-			Status: metav1.ConditionTrue,
-			Reason: clusterv1.ClusterRemoteConnectionProbeSucceededV1Beta2Reason,
-		})
+	var lastProbeSuccessDuration time.Duration
+	if probeDurationGetter, ok := r.ClusterCache.(clustercache.ProbeDurationGetter); ok {
+		lastProbeSuccessDuration = probeDurationGetter.GetLastProbeSuccessDuration(ctx, client.ObjectKeyFromObject(cluster))
 	}
+	setRemoteConnectionProbeCondition(ctx, cluster, time.Now(), lastProbeSuccessTime, lastProbeSuccessDuration, r.RemoteConnectionGracePeriod)
 
 	alwaysReconcile := []clusterReconcileFunc{
 		r.reconcileInfrastructure,
@@ -234,7 +238,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retRes ct
 	reconcileNormal := append(
 		alwaysReconcile,
 		r.reconcileKubeconfig,
+		r.reconcileSecretLabels,
 		r.reconcileControlPlaneInitialized,
+		r.reconcileAPIServerVersion,
 	)
 	return doReconcile(ctx, reconcileNormal, s)
 }
@@ -265,6 +271,7 @@ func patchCluster(ctx context.Context, patchHelper *patch.Helper, cluster *clust
 			clusterv1.ClusterMachinesReadyV1Beta2Condition,
 			clusterv1.ClusterMachinesUpToDateV1Beta2Condition,
 			clusterv1.ClusterRemoteConnectionProbeV1Beta2Condition,
+			clusterv1.ClusterVersionDriftV1Beta2Condition,
 			clusterv1.ClusterScalingUpV1Beta2Condition,
 			clusterv1.ClusterScalingDownV1Beta2Condition,
 			clusterv1.ClusterRemediatingV1Beta2Condition,
@@ -462,7 +469,19 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (reconcile.R
 			conditions.MarkFalse(cluster, clusterv1.InfrastructureReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 		}
 
-		if s.infraCluster != nil {
+		// Externally managed InfraClusters are not deleted by the Cluster controller; the external management system
+		// signals it released the cluster infrastructure by removing the Cluster controller reference from the InfraCluster.
+		if s.infraCluster != nil && annotations.IsExternallyManaged(s.infraCluster) {
+			if metav1.IsControlledBy(s.infraCluster, cluster) {
+				// Return here so we don't remove the finalizer yet.
+				s.deletingReason = clusterv1.ClusterDeletingWaitingForInfrastructureDeletionV1Beta2Reason
+				s.deletingMessage = fmt.Sprintf("Waiting for %s to be released by %s", s.infraCluster.GetKind(), s.infraCluster.GetAnnotations()[clusterv1.ManagedByAnnotation])
+
+				log.Info("Cluster still has descendants - need to requeue", "infrastructureRef", cluster.Spec.InfrastructureRef.Name)
+				return ctrl.Result{}, nil
+			}
+			conditions.MarkFalse(cluster, clusterv1.InfrastructureReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+		} else if s.infraCluster != nil {
 			if s.infraCluster.GetDeletionTimestamp().IsZero() {
 				// Issue a deletion request for the infrastructure object.
 				// Once it's been deleted, the cluster will get processed again.
@@ -508,10 +527,7 @@ type clusterDescendants struct {
 // objectsPendingDeleteCount returns the number of descendants pending delete.
 // Note: infrastructure cluster, control plane object and its controlled machines are not included.
 func (c *clusterDescendants) objectsPendingDeleteCount(cluster *clusterv1.Cluster) int {
-	n := len(c.machinePools.Items) +
-		len(c.machineDeployments.Items) +
-		len(c.machineSets.Items) +
-		len(c.workerMachines)
+	n := c.workerObjectsPendingDeleteCount()
 
 	if cluster.Spec.ControlPlaneRef == nil {
 		n += len(c.controlPlaneMachines)
@@ -520,6 +536,15 @@ func (c *clusterDescendants) objectsPendingDeleteCount(cluster *clusterv1.Cluste
 	return n
 }
 
+// workerObjectsPendingDeleteCount returns the number of MachinePools, MachineDeployments, MachineSets and worker
+// Machines pending delete.
+func (c *clusterDescendants) workerObjectsPendingDeleteCount() int {
+	return len(c.machinePools.Items) +
+		len(c.machineDeployments.Items) +
+		len(c.machineSets.Items) +
+		len(c.workerMachines)
+}
+
 // objectsPendingDeleteNames return the names of descendants pending delete.
 // Note: infrastructure cluster, control plane object and its controlled machines are not included.
 func (c *clusterDescendants) objectsPendingDeleteNames(cluster *clusterv1.Cluster) []string {
@@ -657,11 +682,10 @@ func (c *clusterDescendants) filterOwnedDescendants(cluster *clusterv1.Cluster)
 	// Note: Excluding machines controlled by a control plane object is an additional safeguard to ensure
 	// that the control plane is deleted only after all the workers machine are done.
 	// Note: Using stand-alone control plane machines is not yet officially deprecated, however this approach
-	// has well known limitations that have been address by the introduction of control plane objects. One of those
-	// limitation is about the deletion workflow, which is governed by this function.
-	// More specifically, when deleting a Cluster with stand-alone control plane machines, stand-alone control plane machines
-	// will be decommissioned in parallel with other machines, no matter of them being added as last in this list.
-	if cluster.Spec.ControlPlaneRef == nil {
+	// has well known limitations that have been address by the introduction of control plane objects.
+	// Stand-alone control plane machines are included only once all the worker descendants are gone, so they are
+	// decommissioned after the workers, which need the control plane to drain their Nodes.
+	if cluster.Spec.ControlPlaneRef == nil && c.workerObjectsPendingDeleteCount() == 0 {
 		lists = append(lists, toObjectList(c.controlPlaneMachines))
 	}
 
@@ -710,6 +734,46 @@ func (r *Reconciler) reconcileControlPlaneInitialized(ctx context.Context, s *sc
 	return ctrl.Result{}, nil
 }
 
+// reconcileAPIServerVersion periodically discovers the version of the API server of the workload cluster via the
+// remote connection and reports it in status.apiServerVersion.
+func (r *Reconciler) reconcileAPIServerVersion(ctx context.Context, s *scope) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	cluster := s.cluster
+
+	if !conditions.IsTrue(cluster, clusterv1.ControlPlaneInitializedCondition) {
+		return ctrl.Result{}, nil
+	}
+
+	// Check apiServerVersionDiscoveryCache to ensure we won't discover the version too frequently.
+	if cacheEntry, ok := r.apiServerVersionDiscoveryCache.Has(cache.NewReconcileEntryKey(cluster)); ok {
+		if requeueAfter, requeue := cacheEntry.ShouldRequeue(time.Now()); requeue {
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+
+	// Note: Connection problems are surfaced by the RemoteConnectionProbe condition, so they are not reported as errors here;
+	// the version will be discovered once the connection is available again.
+	restConfig, err := r.ClusterCache.GetRESTConfig(ctx, client.ObjectKeyFromObject(cluster))
+	if err != nil {
+		log.V(5).Info("Skipping API server version discovery", "reason", err.Error())
+		return ctrl.Result{}, nil
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create discovery client")
+	}
+	serverVersion, err := discoveryClient.ServerVersion()
+	if err != nil {
+		log.V(5).Info("Skipping API server version discovery", "reason", err.Error())
+		return ctrl.Result{}, nil
+	}
+	cluster.Status.APIServerVersion = serverVersion.GitVersion
+
+	// Add entry to the apiServerVersionDiscoveryCache so we won't discover the version again before apiServerVersionDiscoveryInterval.
+	r.apiServerVersionDiscoveryCache.Add(cache.NewReconcileEntry(cluster, time.Now().Add(apiServerVersionDiscoveryInterval)))
+	return ctrl.Result{RequeueAfter: apiServerVersionDiscoveryInterval}, nil
+}
+
 // controlPlaneMachineToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
 // for Cluster to update its status.controlPlaneInitialized field.
 func (r *Reconciler) controlPlaneMachineToCluster(ctx context.Context, o client.Object) []ctrl.Request {
@@ -738,6 +802,40 @@ func (r *Reconciler) controlPlaneMachineToCluster(ctx context.Context, o client.
 	}}
 }
 
+// externalObjectToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for the Clusters owning an external object or, if it is not owned by a Cluster yet, for the Clusters referencing it.
+func (r *Reconciler) externalObjectToCluster(ctx context.Context, o client.Object) []ctrl.Request {
+	requests := []ctrl.Request{}
+	for _, ref := range o.GetOwnerReferences() {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			continue
+		}
+		if ref.Kind == "Cluster" && gv.Group == clusterv1.GroupVersion.Group {
+			requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: ref.Name}})
+		}
+	}
+	if len(requests) > 0 {
+		return requests
+	}
+
+	clusters := &clusterv1.ClusterList{}
+	if err := r.Client.List(ctx, clusters, client.InNamespace(o.GetNamespace())); err != nil {
+		return nil
+	}
+	gk := o.GetObjectKind().GroupVersionKind().GroupKind()
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		for _, ref := range []*corev1.ObjectReference{cluster.Spec.InfrastructureRef, cluster.Spec.ControlPlaneRef} {
+			if ref != nil && ref.Name == o.GetName() && ref.GroupVersionKind().GroupKind() == gk {
+				requests = append(requests, ctrl.Request{NamespacedName: util.ObjectKey(cluster)})
+				break
+			}
+		}
+	}
+	return requests
+}
+
 // machineDeploymentToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
 // for Cluster to update when one of its own MachineDeployments gets updated.
 func (r *Reconciler) machineDeploymentToCluster(_ context.Context, o client.Object) []ctrl.Request {
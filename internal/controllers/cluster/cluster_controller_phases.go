@@ -19,11 +19,13 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -35,6 +37,8 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/external"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/certs"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
@@ -90,14 +94,24 @@ func (r *Reconciler) reconcileExternal(ctx context.Context, cluster *clusterv1.C
 		return nil, err
 	}
 
+	// Ensure we add a watcher to the external object.
+	// Note: The watch is added before getting the external object, so the Cluster is reconciled as soon as
+	// the external object is created if it doesn't exist yet.
+	watchObj := &unstructured.Unstructured{}
+	watchObj.SetGroupVersionKind(ref.GroupVersionKind())
+	if err := r.externalTracker.Watch(log, watchObj, handler.EnqueueRequestsFromMapFunc(r.externalObjectToCluster)); err != nil {
+		return nil, err
+	}
+
 	obj, err := external.Get(ctx, r.Client, ref, cluster.Namespace)
 	if err != nil {
 		return nil, err
 	}
 
-	// Ensure we add a watcher to the external object.
-	if err := r.externalTracker.Watch(log, obj, handler.EnqueueRequestForOwner(r.Client.Scheme(), r.Client.RESTMapper(), &clusterv1.Cluster{})); err != nil {
-		return nil, err
+	// When the Cluster is being deleted, do not restore the controller reference of externally managed objects;
+	// the external management system removes it to signal it released the object.
+	if !cluster.DeletionTimestamp.IsZero() && annotations.IsExternallyManaged(obj) {
+		return obj, nil
 	}
 
 	// Initialize the patch helper.
@@ -327,6 +341,66 @@ func (r *Reconciler) reconcileControlPlane(ctx context.Context, s *scope) (ctrl.
 	return ctrl.Result{}, nil
 }
 
+// reconcileSecretLabels copies the labels listed in the propagate-labels-to-secrets annotation from the Cluster
+// to the kubeconfig and certificate Secrets of the Cluster generated by this controller or by the control plane provider.
+// Note: Secrets are read from the cache, which only contains Secrets with the cluster name label, and Secrets
+// without owner references are not changed, because they are provided by the user.
+func (r *Reconciler) reconcileSecretLabels(ctx context.Context, s *scope) (ctrl.Result, error) {
+	cluster := s.cluster
+
+	labels := map[string]string{}
+	for _, key := range strings.Split(cluster.Annotations[clusterv1.ClusterPropagateLabelsToSecretsAnnotation], ",") {
+		key = strings.TrimSpace(key)
+		if value, ok := cluster.Labels[key]; ok && key != "" {
+			labels[key] = value
+		}
+	}
+	if len(labels) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	for _, purpose := range []secret.Purpose{secret.Kubeconfig, secret.ClusterCA, secret.EtcdCA, secret.ServiceAccount, secret.FrontProxyCA, secret.APIServerEtcdClient} {
+		clusterSecret, err := secret.Get(ctx, r.SecretCachingClient, util.ObjectKey(cluster), purpose)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve %s Secret for Cluster %s", purpose, klog.KObj(cluster))
+		}
+		// Skip Secrets which are not managed by Cluster API, e.g. provided by the user.
+		if clusterSecret.Labels[clusterv1.ClusterNameLabel] != cluster.Name || len(clusterSecret.OwnerReferences) == 0 {
+			continue
+		}
+
+		changed := false
+		for key, value := range labels {
+			if current, ok := clusterSecret.Labels[key]; !ok || current != value {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		patchHelper, err := patch.NewHelper(clusterSecret, r.Client)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		for key, value := range labels {
+			if clusterSecret.Labels == nil {
+				clusterSecret.Labels = map[string]string{}
+			}
+			clusterSecret.Labels[key] = value
+		}
+		if err := patchHelper.Patch(ctx, clusterSecret); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to propagate labels to Secret %s", klog.KObj(clusterSecret))
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
 func (r *Reconciler) reconcileKubeconfig(ctx context.Context, s *scope) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	cluster := s.cluster
@@ -342,7 +416,7 @@ func (r *Reconciler) reconcileKubeconfig(ctx context.Context, s *scope) (ctrl.Re
 		return ctrl.Result{}, nil
 	}
 
-	_, err := secret.Get(ctx, r.Client, util.ObjectKey(cluster), secret.Kubeconfig)
+	configSecret, err := secret.Get(ctx, r.Client, util.ObjectKey(cluster), secret.Kubeconfig)
 	switch {
 	case apierrors.IsNotFound(err):
 		if err := kubeconfig.CreateSecret(ctx, r.Client, cluster); err != nil {
@@ -352,9 +426,36 @@ func (r *Reconciler) reconcileKubeconfig(ctx context.Context, s *scope) (ctrl.Re
 			}
 			return ctrl.Result{}, err
 		}
+		// always return if we have just created in order to skip rotation checks
+		return ctrl.Result{}, nil
 	case err != nil:
 		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve Kubeconfig Secret for Cluster %q in namespace %q", cluster.Name, cluster.Namespace)
 	}
 
+	// only do rotation on secrets generated by this controller; user provided secrets are left untouched.
+	if !util.HasOwnerRef(configSecret.OwnerReferences, metav1.OwnerReference{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "Cluster",
+		Name:       cluster.Name,
+	}) {
+		return ctrl.Result{}, nil
+	}
+
+	needsRotation, err := kubeconfig.NeedsClientCertRotation(configSecret, certs.ClientCertificateRenewalDuration)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if needsRotation {
+		log.Info("Rotating kubeconfig secret", "Secret", klog.KObj(configSecret))
+		if err := kubeconfig.RegenerateSecret(ctx, r.Client, configSecret); err != nil {
+			if err == kubeconfig.ErrDependentCertificateNotFound {
+				log.Info("Could not find secret for cluster, requeuing", "Secret", secret.ClusterCA)
+				return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+			}
+			return ctrl.Result{}, errors.Wrap(err, "failed to regenerate kubeconfig")
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
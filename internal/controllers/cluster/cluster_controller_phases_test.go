@@ -17,6 +17,12 @@ limitations under the License.
 package cluster
 
 import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
 	"testing"
 	"time"
 
@@ -25,19 +31,25 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	externalfake "sigs.k8s.io/cluster-api/controllers/external/fake"
 	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/certs"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/secret"
 	"sigs.k8s.io/cluster-api/util/test/builder"
 )
 
@@ -656,6 +668,223 @@ func TestClusterReconcileKubeConfig(t *testing.T) {
 	}
 }
 
+func TestClusterReconcileKubeConfigRotation(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{
+				Host: "1.2.3.4",
+				Port: 8443,
+			},
+		},
+	}
+	clusterOwner := metav1.OwnerReference{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "Cluster",
+		Name:       cluster.Name,
+	}
+
+	tests := []struct {
+		name          string
+		owner         *metav1.OwnerReference
+		expiresIn     time.Duration
+		expectRotated bool
+	}{
+		{
+			name:          "rotates an owned kubeconfig with a client certificate about to expire",
+			owner:         &clusterOwner,
+			expiresIn:     time.Hour,
+			expectRotated: true,
+		},
+		{
+			name:          "does not rotate an owned kubeconfig with a valid client certificate",
+			owner:         &clusterOwner,
+			expiresIn:     certs.DefaultCertDuration,
+			expectRotated: false,
+		},
+		{
+			name:          "does not rotate a user provided kubeconfig",
+			expiresIn:     time.Hour,
+			expectRotated: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ca := &secret.Certificate{Purpose: secret.ClusterCA}
+			g.Expect(ca.Generate()).To(Succeed())
+			caSecret := ca.AsSecret(util.ObjectKey(cluster), clusterOwner)
+
+			caCert, err := certs.DecodeCertPEM(ca.KeyPair.Cert)
+			g.Expect(err).ToNot(HaveOccurred())
+			caKey, err := certs.DecodePrivateKeyPEM(ca.KeyPair.Key)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			kubeconfigSecret := newTestKubeconfigSecret(g, cluster, caCert, caKey, tt.expiresIn)
+			if tt.owner != nil {
+				kubeconfigSecret.OwnerReferences = []metav1.OwnerReference{*tt.owner}
+			}
+			oldData := kubeconfigSecret.Data[secret.KubeconfigDataName]
+
+			c := fake.NewClientBuilder().
+				WithObjects(cluster, caSecret, kubeconfigSecret).
+				Build()
+			r := &Reconciler{
+				Client:   c,
+				recorder: record.NewFakeRecorder(32),
+			}
+
+			_, err = r.reconcileKubeconfig(ctx, &scope{cluster: cluster})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got := &corev1.Secret{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(kubeconfigSecret), got)).To(Succeed())
+			if tt.expectRotated {
+				g.Expect(got.Data[secret.KubeconfigDataName]).ToNot(Equal(oldData))
+				needsRotation, err := kubeconfig.NeedsClientCertRotation(got, certs.ClientCertificateRenewalDuration)
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(needsRotation).To(BeFalse())
+			} else {
+				g.Expect(got.Data[secret.KubeconfigDataName]).To(Equal(oldData))
+			}
+		})
+	}
+}
+
+func TestClusterReconcileSecretLabels(t *testing.T) {
+	newSecret := func(name string, labels map[string]string, owned bool) *corev1.Secret {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				Labels:    labels,
+			},
+		}
+		if owned {
+			s.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "Cluster",
+				Name:       "test-cluster",
+				UID:        "test-cluster-uid",
+			}}
+		}
+		return s
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantLabels  map[string]string
+		wantPatches int
+	}{
+		{
+			name:        "does not change the Secrets without the annotation",
+			wantLabels:  map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+			wantPatches: 0,
+		},
+		{
+			name:        "copies the listed labels existing on the Cluster",
+			annotations: map[string]string{clusterv1.ClusterPropagateLabelsToSecretsAnnotation: "env, team,missing"},
+			wantLabels: map[string]string{
+				clusterv1.ClusterNameLabel: "test-cluster",
+				"env":                      "prod",
+				"team":                     "a",
+			},
+			wantPatches: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-cluster",
+					Namespace:   metav1.NamespaceDefault,
+					Labels:      map[string]string{"env": "prod", "team": "a", "other": "value"},
+					Annotations: tt.annotations,
+				},
+			}
+			kubeconfigSecret := newSecret("test-cluster-kubeconfig", map[string]string{clusterv1.ClusterNameLabel: "test-cluster"}, true)
+			caSecret := newSecret("test-cluster-ca", map[string]string{clusterv1.ClusterNameLabel: "test-cluster"}, true)
+			// Secrets provided by the user or without the cluster name label must not be changed.
+			userProvidedSecret := newSecret("test-cluster-etcd", map[string]string{clusterv1.ClusterNameLabel: "test-cluster"}, false)
+			unlabeledSecret := newSecret("test-cluster-sa", nil, true)
+
+			patches := 0
+			c := interceptor.NewClient(fake.NewClientBuilder().
+				WithObjects(cluster, kubeconfigSecret, caSecret, userProvidedSecret, unlabeledSecret).
+				Build(), interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					patches++
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			})
+			r := &Reconciler{
+				Client:              c,
+				SecretCachingClient: c,
+			}
+
+			_, err := r.reconcileSecretLabels(ctx, &scope{cluster: cluster})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			for _, s := range []*corev1.Secret{kubeconfigSecret, caSecret} {
+				got := &corev1.Secret{}
+				g.Expect(c.Get(ctx, client.ObjectKeyFromObject(s), got)).To(Succeed())
+				g.Expect(got.Labels).To(Equal(tt.wantLabels))
+			}
+			for _, s := range []*corev1.Secret{userProvidedSecret, unlabeledSecret} {
+				got := &corev1.Secret{}
+				g.Expect(c.Get(ctx, client.ObjectKeyFromObject(s), got)).To(Succeed())
+				g.Expect(got.Labels).To(Equal(s.Labels))
+			}
+			g.Expect(patches).To(Equal(tt.wantPatches))
+
+			// Secrets which already have the labels must not be patched again.
+			_, err = r.reconcileSecretLabels(ctx, &scope{cluster: cluster})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(patches).To(Equal(tt.wantPatches))
+		})
+	}
+}
+
+// newTestKubeconfigSecret returns a kubeconfig Secret for the given Cluster whose client certificate expires after expiresIn.
+func newTestKubeconfigSecret(g *WithT, cluster *clusterv1.Cluster, caCert *x509.Certificate, caKey crypto.Signer, expiresIn time.Duration) *corev1.Secret {
+	config, err := kubeconfig.New(cluster.Name, "https://"+cluster.Spec.ControlPlaneEndpoint.String(), caCert, caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	clientKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kubernetes-admin", Organization: []string{"system:masters"}},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(expiresIn),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, clientKey.Public(), caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+	clientCert, err := x509.ParseCertificate(der)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	for _, authInfo := range config.AuthInfos {
+		authInfo.ClientCertificateData = certs.EncodeCertPEM(clientCert)
+		authInfo.ClientKeyData = certs.EncodePrivateKeyPEM(clientKey)
+	}
+
+	data, err := clientcmd.Write(*config)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	s := kubeconfig.GenerateSecret(cluster, data)
+	s.OwnerReferences = nil
+	return s
+}
+
 func TestClusterReconciler_reconcilePhase(t *testing.T) {
 	cluster := &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -32,6 +33,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/external"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/util/drift"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/collections"
 	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
@@ -56,6 +58,7 @@ func (r *Reconciler) updateStatus(ctx context.Context, s *scope) {
 	setScalingUpCondition(ctx, s.cluster, s.controlPlane, s.descendants.machinePools, s.descendants.machineDeployments, s.descendants.machineSets, s.controlPlaneIsNotFound, s.getDescendantsSucceeded)
 	setScalingDownCondition(ctx, s.cluster, s.controlPlane, s.descendants.machinePools, s.descendants.machineDeployments, s.descendants.machineSets, s.controlPlaneIsNotFound, s.getDescendantsSucceeded)
 	setRemediatingCondition(ctx, s.cluster, s.descendants.machinesToBeRemediated, s.descendants.unhealthyMachines, s.getDescendantsSucceeded)
+	setVersionDriftCondition(ctx, s.cluster, s.controlPlane)
 	setDeletingCondition(ctx, s.cluster, s.deletingReason, s.deletingMessage)
 	setAvailableCondition(ctx, s.cluster)
 }
@@ -874,6 +877,53 @@ func setScalingDownCondition(ctx context.Context, cluster *clusterv1.Cluster, co
 	v1beta2conditions.Set(cluster, *scalingDownCondition)
 }
 
+func setVersionDriftCondition(_ context.Context, cluster *clusterv1.Cluster, controlPlane *unstructured.Unstructured) {
+	// The declared version is spec.topology.version, or the control plane's spec.version for Clusters not using a ClusterClass.
+	var declaredVersion string
+	if cluster.Spec.Topology != nil {
+		declaredVersion = cluster.Spec.Topology.Version
+	} else if controlPlane != nil {
+		if version, err := contract.ControlPlane().Version().Get(controlPlane); err == nil {
+			declaredVersion = *version
+		}
+	}
+
+	if declaredVersion == "" {
+		v1beta2conditions.Set(cluster, metav1.Condition{
+			Type:   clusterv1.ClusterVersionDriftV1Beta2Condition,
+			Status: metav1.ConditionFalse,
+			Reason: clusterv1.ClusterNoVersionDriftV1Beta2Reason,
+		})
+		return
+	}
+
+	if cluster.Status.APIServerVersion == "" {
+		v1beta2conditions.Set(cluster, metav1.Condition{
+			Type:    clusterv1.ClusterVersionDriftV1Beta2Condition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  clusterv1.ClusterVersionDriftAPIServerVersionUnknownV1Beta2Reason,
+			Message: "Waiting for the API server version to be discovered",
+		})
+		return
+	}
+
+	if drift.Version(declaredVersion, cluster.Status.APIServerVersion) {
+		v1beta2conditions.Set(cluster, metav1.Condition{
+			Type:    clusterv1.ClusterVersionDriftV1Beta2Condition,
+			Status:  metav1.ConditionTrue,
+			Reason:  clusterv1.ClusterVersionDriftV1Beta2Reason,
+			Message: fmt.Sprintf("API server is running %s, expected %s", cluster.Status.APIServerVersion, declaredVersion),
+		})
+		return
+	}
+
+	v1beta2conditions.Set(cluster, metav1.Condition{
+		Type:   clusterv1.ClusterVersionDriftV1Beta2Condition,
+		Status: metav1.ConditionFalse,
+		Reason: clusterv1.ClusterNoVersionDriftV1Beta2Reason,
+	})
+}
+
 func setDeletingCondition(_ context.Context, cluster *clusterv1.Cluster, deletingReason, deletingMessage string) {
 	if cluster.DeletionTimestamp.IsZero() {
 		v1beta2conditions.Set(cluster, metav1.Condition{
@@ -892,6 +942,46 @@ func setDeletingCondition(_ context.Context, cluster *clusterv1.Cluster, deletin
 	})
 }
 
+// remoteConnectionProbeSlowThreshold is the latency of the health probe against the workload cluster apiserver
+// above which the RemoteConnectionProbe condition reports a slow connection.
+const remoteConnectionProbeSlowThreshold = 1 * time.Second
+
+func setRemoteConnectionProbeCondition(_ context.Context, cluster *clusterv1.Cluster, now, lastProbeSuccessTime time.Time, lastProbeSuccessDuration, remoteConnectionGracePeriod time.Duration) {
+	if now.Sub(lastProbeSuccessTime) > remoteConnectionGracePeriod {
+		var msg string
+		if lastProbeSuccessTime.IsZero() {
+			msg = "Remote connection probe failed"
+		} else {
+			msg = fmt.Sprintf("Remote connection probe failed, probe last succeeded at %s", lastProbeSuccessTime.Format(time.RFC3339))
+		}
+		v1beta2conditions.Set(cluster, metav1.Condition{
+			Type:    clusterv1.ClusterRemoteConnectionProbeV1Beta2Condition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.ClusterRemoteConnectionProbeFailedV1Beta2Reason,
+			Message: msg,
+		})
+		return
+	}
+
+	// Note: The message intentionally does not contain the actual latency, so the condition does not change on every
+	// probe; the latency is reported by the capi_cluster_cache_health_probe_duration_seconds metric.
+	if lastProbeSuccessDuration > remoteConnectionProbeSlowThreshold {
+		v1beta2conditions.Set(cluster, metav1.Condition{
+			Type:    clusterv1.ClusterRemoteConnectionProbeV1Beta2Condition,
+			Status:  metav1.ConditionTrue,
+			Reason:  clusterv1.ClusterRemoteConnectionProbeSlowV1Beta2Reason,
+			Message: fmt.Sprintf("Remote connection probe succeeded, but it took more than %s", remoteConnectionProbeSlowThreshold),
+		})
+		return
+	}
+
+	v1beta2conditions.Set(cluster, metav1.Condition{
+		Type:   clusterv1.ClusterRemoteConnectionProbeV1Beta2Condition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1.ClusterRemoteConnectionProbeSucceededV1Beta2Reason,
+	})
+}
+
 type clusterConditionCustomMergeStrategy struct {
 	cluster                        *clusterv1.Cluster
 	negativePolarityConditionTypes []string
@@ -17,6 +17,7 @@ limitations under the License.
 package cluster
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -1680,6 +1681,161 @@ func TestDeletingCondition(t *testing.T) {
 	}
 }
 
+func TestSetRemoteConnectionProbeCondition(t *testing.T) {
+	now := time.Now()
+	gracePeriod := 50 * time.Second
+
+	testCases := []struct {
+		name                     string
+		lastProbeSuccessTime     time.Time
+		lastProbeSuccessDuration time.Duration
+		expectCondition          metav1.Condition
+	}{
+		{
+			name:                 "probe never succeeded",
+			lastProbeSuccessTime: time.Time{},
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.ClusterRemoteConnectionProbeV1Beta2Condition,
+				Status:  metav1.ConditionFalse,
+				Reason:  clusterv1.ClusterRemoteConnectionProbeFailedV1Beta2Reason,
+				Message: "Remote connection probe failed",
+			},
+		},
+		{
+			name:                 "probe last succeeded before the grace period",
+			lastProbeSuccessTime: now.Add(-2 * gracePeriod),
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.ClusterRemoteConnectionProbeV1Beta2Condition,
+				Status:  metav1.ConditionFalse,
+				Reason:  clusterv1.ClusterRemoteConnectionProbeFailedV1Beta2Reason,
+				Message: fmt.Sprintf("Remote connection probe failed, probe last succeeded at %s", now.Add(-2*gracePeriod).Format(time.RFC3339)),
+			},
+		},
+		{
+			name:                     "probe succeeded",
+			lastProbeSuccessTime:     now.Add(-10 * time.Second),
+			lastProbeSuccessDuration: 100 * time.Millisecond,
+			expectCondition: metav1.Condition{
+				Type:   clusterv1.ClusterRemoteConnectionProbeV1Beta2Condition,
+				Status: metav1.ConditionTrue,
+				Reason: clusterv1.ClusterRemoteConnectionProbeSucceededV1Beta2Reason,
+			},
+		},
+		{
+			name:                     "probe succeeded but slow",
+			lastProbeSuccessTime:     now.Add(-10 * time.Second),
+			lastProbeSuccessDuration: 3 * time.Second,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.ClusterRemoteConnectionProbeV1Beta2Condition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.ClusterRemoteConnectionProbeSlowV1Beta2Reason,
+				Message: "Remote connection probe succeeded, but it took more than 1s",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := fakeCluster("c")
+			setRemoteConnectionProbeCondition(ctx, cluster, now, tc.lastProbeSuccessTime, tc.lastProbeSuccessDuration, gracePeriod)
+
+			condition := v1beta2conditions.Get(cluster, clusterv1.ClusterRemoteConnectionProbeV1Beta2Condition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(*condition).To(v1beta2conditions.MatchCondition(tc.expectCondition, v1beta2conditions.IgnoreLastTransitionTime(true)))
+		})
+	}
+}
+
+func TestSetVersionDriftCondition(t *testing.T) {
+	controlPlaneWithVersion := func(version string) *unstructured.Unstructured {
+		cp := fakeControlPlane("cp")
+		_ = unstructured.SetNestedField(cp.Object, version, "spec", "version")
+		return cp
+	}
+
+	testCases := []struct {
+		name             string
+		topologyVersion  string
+		controlPlane     *unstructured.Unstructured
+		apiServerVersion string
+		expectCondition  metav1.Condition
+	}{
+		{
+			name:             "cluster without a declared version",
+			controlPlane:     fakeControlPlane("cp"),
+			apiServerVersion: "v1.31.0",
+			expectCondition: metav1.Condition{
+				Type:   clusterv1.ClusterVersionDriftV1Beta2Condition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.ClusterNoVersionDriftV1Beta2Reason,
+			},
+		},
+		{
+			name:             "API server version not discovered yet",
+			topologyVersion:  "v1.31.0",
+			apiServerVersion: "",
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.ClusterVersionDriftV1Beta2Condition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.ClusterVersionDriftAPIServerVersionUnknownV1Beta2Reason,
+				Message: "Waiting for the API server version to be discovered",
+			},
+		},
+		{
+			name:             "API server running the topology version",
+			topologyVersion:  "v1.31.0",
+			apiServerVersion: "v1.31.0+vendor.1",
+			expectCondition: metav1.Condition{
+				Type:   clusterv1.ClusterVersionDriftV1Beta2Condition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.ClusterNoVersionDriftV1Beta2Reason,
+			},
+		},
+		{
+			name:             "API server running a version different from the topology version",
+			topologyVersion:  "v1.31.0",
+			controlPlane:     controlPlaneWithVersion("v1.30.2"),
+			apiServerVersion: "v1.30.2",
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.ClusterVersionDriftV1Beta2Condition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.ClusterVersionDriftV1Beta2Reason,
+				Message: "API server is running v1.30.2, expected v1.31.0",
+			},
+		},
+		{
+			name:             "API server running a version different from the control plane version",
+			controlPlane:     controlPlaneWithVersion("v1.31.0"),
+			apiServerVersion: "v1.30.2",
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.ClusterVersionDriftV1Beta2Condition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.ClusterVersionDriftV1Beta2Reason,
+				Message: "API server is running v1.30.2, expected v1.31.0",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := fakeCluster("c")
+			if tc.topologyVersion != "" {
+				cluster.Spec.Topology = &clusterv1.Topology{Version: tc.topologyVersion}
+			}
+			cluster.Status.APIServerVersion = tc.apiServerVersion
+			setVersionDriftCondition(ctx, cluster, tc.controlPlane)
+
+			condition := v1beta2conditions.Get(cluster, clusterv1.ClusterVersionDriftV1Beta2Condition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(*condition).To(v1beta2conditions.MatchCondition(tc.expectCondition, v1beta2conditions.IgnoreLastTransitionTime(true)))
+		})
+	}
+}
+
 func TestSetAvailableCondition(t *testing.T) {
 	testCases := []struct {
 		name            string
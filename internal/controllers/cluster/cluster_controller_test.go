@@ -17,23 +17,30 @@ limitations under the License.
 package cluster
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/util/cache"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -476,6 +483,65 @@ func TestClusterReconciler_reconcileDelete(t *testing.T) {
 	}
 }
 
+func TestClusterReconciler_reconcileDeleteExternallyManagedInfrastructure(t *testing.T) {
+	tests := []struct {
+		name                string
+		controlledByCluster bool
+		wantFinalizer       bool
+		wantDeletingMessage string
+	}{
+		{
+			name:                "waits for the external system to release the InfraCluster",
+			controlledByCluster: true,
+			wantFinalizer:       true,
+			wantDeletingMessage: "Waiting for GenericInfrastructureCluster to be released by external-system",
+		},
+		{
+			name:                "completes deletion once the external system released the InfraCluster",
+			controlledByCluster: false,
+			wantFinalizer:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			infraCluster := builder.InfrastructureCluster("test-ns", "test-cluster").Build()
+			infraCluster.SetAnnotations(map[string]string{clusterv1.ManagedByAnnotation: "external-system"})
+			cluster := builder.Cluster("test-ns", "test-cluster").WithInfrastructureCluster(infraCluster).Build()
+			cluster.SetUID("test-cluster-uid")
+			cluster.SetFinalizers([]string{clusterv1.ClusterFinalizer})
+			if tt.controlledByCluster {
+				infraCluster.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind("Cluster"))})
+			}
+
+			fakeClient := fake.NewClientBuilder().WithObjects(infraCluster, cluster).Build()
+			r := &Reconciler{
+				Client:    fakeClient,
+				APIReader: fakeClient,
+				recorder:  record.NewFakeRecorder(1),
+			}
+
+			s := &scope{
+				cluster:                 cluster,
+				infraCluster:            infraCluster,
+				getDescendantsSucceeded: true,
+			}
+			_, err := r.reconcileDelete(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(controllerutil.ContainsFinalizer(cluster, clusterv1.ClusterFinalizer)).To(Equal(tt.wantFinalizer))
+			if tt.wantFinalizer {
+				g.Expect(s.deletingReason).To(Equal(clusterv1.ClusterDeletingWaitingForInfrastructureDeletionV1Beta2Reason))
+				g.Expect(s.deletingMessage).To(Equal(tt.wantDeletingMessage))
+			}
+
+			// The InfraCluster is never deleted by the Cluster controller.
+			g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(infraCluster), builder.InfrastructureCluster("", "").Build())).To(Succeed())
+		})
+	}
+}
+
 func TestClusterReconcilerNodeRef(t *testing.T) {
 	t.Run("machine to cluster", func(t *testing.T) {
 		cluster := &clusterv1.Cluster{
@@ -801,6 +867,7 @@ func TestFilterOwnedDescendants(t *testing.T) {
 		actual, err := d.filterOwnedDescendants(&c)
 		g.Expect(err).ToNot(HaveOccurred())
 
+		// Control plane machines are not included until all the worker descendants are gone.
 		g.Expect(actual).To(ConsistOf(
 			&mp2OwnedByCluster,
 			&mp4OwnedByCluster,
@@ -810,6 +877,20 @@ func TestFilterOwnedDescendants(t *testing.T) {
 			&ms4OwnedByCluster,
 			&m2OwnedByCluster,
 			&m5OwnedByCluster,
+		))
+	})
+
+	t.Run("Without a control plane object and without worker descendants", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dWithoutWorkers := clusterDescendants{
+			controlPlaneMachines: d.controlPlaneMachines,
+		}
+
+		actual, err := dWithoutWorkers.filterOwnedDescendants(&c)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(actual).To(ConsistOf(
 			&m3ControlPlaneOwnedByCluster,
 			&m6ControlPlaneOwnedByCluster,
 		))
@@ -924,3 +1005,121 @@ func TestReconcileControlPlaneInitializedControlPlaneRef(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(conditions.Has(c, clusterv1.ControlPlaneInitializedCondition)).To(BeFalse())
 }
+
+func TestReconcileAPIServerVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"major":"1","minor":"31","gitVersion":"v1.31.0"}`))
+	}))
+	defer server.Close()
+
+	t.Run("does not discover the version if the control plane is not initialized", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &Reconciler{
+			ClusterCache:                   &fakeClusterCache{restConfig: &rest.Config{Host: server.URL}},
+			apiServerVersionDiscoveryCache: cache.New[cache.ReconcileEntry](),
+		}
+		c := fakeCluster("c")
+
+		res, err := r.reconcileAPIServerVersion(ctx, &scope{cluster: c})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(c.Status.APIServerVersion).To(BeEmpty())
+	})
+	t.Run("does not discover the version if the workload cluster is not connected", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &Reconciler{
+			ClusterCache:                   &fakeClusterCache{err: clustercache.ErrClusterNotConnected},
+			apiServerVersionDiscoveryCache: cache.New[cache.ReconcileEntry](),
+		}
+		c := fakeCluster("c")
+		conditions.MarkTrue(c, clusterv1.ControlPlaneInitializedCondition)
+
+		res, err := r.reconcileAPIServerVersion(ctx, &scope{cluster: c})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(c.Status.APIServerVersion).To(BeEmpty())
+	})
+	t.Run("discovers the version and then waits for the discovery interval", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &Reconciler{
+			ClusterCache:                   &fakeClusterCache{restConfig: &rest.Config{Host: server.URL}},
+			apiServerVersionDiscoveryCache: cache.New[cache.ReconcileEntry](),
+		}
+		c := fakeCluster("c")
+		conditions.MarkTrue(c, clusterv1.ControlPlaneInitializedCondition)
+
+		res, err := r.reconcileAPIServerVersion(ctx, &scope{cluster: c})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(apiServerVersionDiscoveryInterval))
+		g.Expect(c.Status.APIServerVersion).To(Equal("v1.31.0"))
+
+		c.Status.APIServerVersion = ""
+		res, err = r.reconcileAPIServerVersion(ctx, &scope{cluster: c})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+		g.Expect(c.Status.APIServerVersion).To(BeEmpty())
+	})
+}
+
+type fakeClusterCache struct {
+	clustercache.ClusterCache
+	restConfig *rest.Config
+	err        error
+}
+
+func (c *fakeClusterCache) GetRESTConfig(_ context.Context, _ client.ObjectKey) (*rest.Config, error) {
+	return c.restConfig, c.err
+}
+
+func TestExternalObjectToCluster(t *testing.T) {
+	cluster1 := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+		WithInfrastructureCluster(builder.InfrastructureCluster(metav1.NamespaceDefault, "infra1").Build()).
+		Build()
+	cluster2 := builder.Cluster(metav1.NamespaceDefault, "cluster2").
+		WithInfrastructureCluster(builder.InfrastructureCluster(metav1.NamespaceDefault, "infra2").Build()).
+		Build()
+
+	tests := []struct {
+		name string
+		obj  client.Object
+		want []ctrl.Request
+	}{
+		{
+			name: "enqueues the Cluster which owns the external object",
+			obj: func() client.Object {
+				obj := builder.InfrastructureCluster(metav1.NamespaceDefault, "infra1").Build()
+				obj.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(cluster2, clusterv1.GroupVersion.WithKind("Cluster"))})
+				return obj
+			}(),
+			want: []ctrl.Request{{NamespacedName: util.ObjectKey(cluster2)}},
+		},
+		{
+			name: "enqueues the Cluster which references the external object if it is not owned",
+			obj:  builder.InfrastructureCluster(metav1.NamespaceDefault, "infra1").Build(),
+			want: []ctrl.Request{{NamespacedName: util.ObjectKey(cluster1)}},
+		},
+		{
+			name: "does not enqueue Clusters referencing an object with the same name but of a different kind",
+			obj:  builder.ControlPlane(metav1.NamespaceDefault, "infra1").Build(),
+			want: []ctrl.Request{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().WithObjects(cluster1, cluster2).Build(),
+			}
+			g.Expect(r.externalObjectToCluster(ctx, tt.obj)).To(Equal(tt.want))
+		})
+	}
+}
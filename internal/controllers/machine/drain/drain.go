@@ -81,6 +81,29 @@ func (d *Helper) CordonNode(ctx context.Context, node *corev1.Node) error {
 	return nil
 }
 
+// ExcludeNodeFromLoadBalancers adds the node.kubernetes.io/exclude-from-external-load-balancers label to a Node,
+// so the service controller removes it from the backends of the load balancers of Services of type LoadBalancer.
+func (d *Helper) ExcludeNodeFromLoadBalancers(ctx context.Context, node *corev1.Node) error {
+	if _, ok := node.Labels[corev1.LabelNodeExcludeBalancers]; ok {
+		// Node is already excluded, nothing to do.
+		return nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("Excluding Node from external load balancers")
+
+	patch := client.MergeFrom(node.DeepCopy())
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+	node.Labels[corev1.LabelNodeExcludeBalancers] = ""
+	if err := d.RemoteClient.Patch(ctx, node, patch); err != nil {
+		return errors.Wrapf(err, "failed to exclude Node from external load balancers")
+	}
+
+	return nil
+}
+
 // GetPodsForEviction gets Pods running on a Node and then filters and returns them as PodDeleteList,
 // or error if it cannot list Pods or get DaemonSets. All Pods that have to go away can be obtained with .Pods().
 func (d *Helper) GetPodsForEviction(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine, nodeName string) (*PodDeleteList, error) {
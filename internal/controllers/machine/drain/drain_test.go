@@ -86,6 +86,51 @@ func TestRunCordonOrUncordon(t *testing.T) {
 	}
 }
 
+func TestExcludeNodeFromLoadBalancers(t *testing.T) {
+	tests := []struct {
+		name string
+		node *corev1.Node
+	}{
+		{
+			name: "Node should be excluded",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node-1",
+				},
+			},
+		},
+		{
+			name: "Excluded Node should stay excluded",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node-1",
+					Labels: map[string]string{
+						corev1.LabelNodeExcludeBalancers: "true",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := fake.NewClientBuilder().WithObjects(tt.node).Build()
+
+			drainer := &Helper{
+				RemoteClient: fakeClient,
+			}
+
+			g.Expect(drainer.ExcludeNodeFromLoadBalancers(context.Background(), tt.node)).To(Succeed())
+
+			gotNode := tt.node.DeepCopy()
+			g.Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(gotNode), gotNode)).To(Succeed())
+			g.Expect(gotNode.Labels).To(HaveKey(corev1.LabelNodeExcludeBalancers))
+		})
+	}
+}
+
 func TestGetPodsForEviction(t *testing.T) {
 	mdrBehaviorDrain := &clusterv1.MachineDrainRule{
 		ObjectMeta: metav1.ObjectMeta{
@@ -202,7 +247,7 @@ func TestGetPodsForEviction(t *testing.T) {
 							Namespace: metav1.NamespaceDefault,
 						},
 					},
-					// Skip this Pod because deletionTimestamp is > SkipWaitForDeleteTimeoutSeconds (=10s) ago.
+					// Skip this Pod because deletionTimestamp is > SkipWaitForDeleteTimeoutSeconds (=10s) + clock skew tolerance (=10s) ago.
 					Status: PodDeleteStatus{
 						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorSkip,
 						Reason:        PodDeleteStatusTypeSkip,
@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/util/clockskew"
 )
 
 // Note: This file is still mostly kept in sync with: https://github.com/kubernetes/kubernetes/blob/v1.31.0/staging/src/k8s.io/kubectl/pkg/drain/filters.go
@@ -254,10 +255,12 @@ func (d *Helper) unreplicatedFilter(_ context.Context, pod *corev1.Pod) PodDelet
 	return MakePodDeleteStatusWithWarning(clusterv1.MachineDrainRuleDrainBehaviorDrain, unmanagedWarning)
 }
 
+// shouldSkipPod returns true if the Pod has been deleted more than skipDeletedTimeoutSeconds ago.
+// Note: The deletionTimestamp is set using the clock of the workload cluster, so clock skew is tolerated.
 func shouldSkipPod(pod *corev1.Pod, skipDeletedTimeoutSeconds int) bool {
 	return skipDeletedTimeoutSeconds > 0 &&
 		!pod.ObjectMeta.DeletionTimestamp.IsZero() &&
-		int(time.Since(pod.ObjectMeta.GetDeletionTimestamp().Time).Seconds()) > skipDeletedTimeoutSeconds
+		clockskew.Expired(pod.ObjectMeta.GetDeletionTimestamp().Time, time.Duration(skipDeletedTimeoutSeconds)*time.Second, time.Now())
 }
 
 func (d *Helper) skipDeletedFilter(ctx context.Context, pod *corev1.Pod) PodDeleteStatus {
@@ -35,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -52,6 +53,7 @@ import (
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/controllers/machine/drain"
 	"sigs.k8s.io/cluster-api/internal/util/cache"
+	"sigs.k8s.io/cluster-api/internal/util/circuitbreaker"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
@@ -98,6 +100,11 @@ type Reconciler struct {
 
 	RemoteConditionsGracePeriod time.Duration
 
+	// ReconcileFailureThreshold is the number of consecutive failed reconciles after which a Machine is
+	// parked with the ReconcileStuck condition instead of being requeued with exponential backoff.
+	// If not greater than 0, Machines are never parked.
+	ReconcileFailureThreshold int
+
 	controller      controller.Controller
 	recorder        record.EventRecorder
 	externalTracker external.ObjectTracker
@@ -111,6 +118,10 @@ type Reconciler struct {
 	// specific time for a specific Request. This is used to implement rate-limiting to avoid
 	// e.g. spamming workload clusters with eviction requests during Node drain.
 	reconcileDeleteCache cache.Cache[cache.ReconcileEntry]
+
+	// clock is used to evaluate time-based logic like node drain, volume detach and node deletion timeouts.
+	// If not set, the real clock is used; tests can inject a fake clock to advance time deterministically.
+	clock clock.PassiveClock
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -163,7 +174,14 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 			&clusterv1.MachineDeployment{},
 			handler.EnqueueRequestsFromMapFunc(mdToMachines),
 		).
-		Build(r)
+		Build(&circuitbreaker.Reconciler{
+			Client:     mgr.GetClient(),
+			Recorder:   mgr.GetEventRecorderFor("machine-controller"),
+			Reconciler: r,
+			NewObject:  func() circuitbreaker.Object { return &clusterv1.Machine{} },
+			Threshold:  r.ReconcileFailureThreshold,
+			Name:       "machine",
+		})
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
@@ -199,7 +217,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	ctx = ctrl.LoggerInto(ctx, log)
 
 	// Add finalizer first if not set to avoid the race condition between init and delete.
-	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, m, clusterv1.MachineFinalizer); err != nil || finalizerAdded {
+	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, m, clusterv1.MachineFinalizer, finalizers.WithEventRecorder(r.recorder)); err != nil || finalizerAdded {
 		return ctrl.Result{}, err
 	}
 
@@ -210,6 +228,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	// Add the InfrastructureMachine and the BootstrapConfig to the logger, so the logs of the Machine controller can be
+	// correlated with the logs of the infrastructure and bootstrap providers, which are adding the Machine to their logger.
+	if m.Spec.InfrastructureRef.Name != "" {
+		log = log.WithValues(m.Spec.InfrastructureRef.Kind, klog.KRef(m.Spec.InfrastructureRef.Namespace, m.Spec.InfrastructureRef.Name))
+	}
+	if m.Spec.Bootstrap.ConfigRef != nil {
+		log = log.WithValues(m.Spec.Bootstrap.ConfigRef.Kind, klog.KRef(m.Spec.Bootstrap.ConfigRef.Namespace, m.Spec.Bootstrap.ConfigRef.Name))
+	}
+	ctx = ctrl.LoggerInto(ctx, log)
+
 	cluster, err := util.GetClusterByName(ctx, r.Client, m.ObjectMeta.Namespace, m.Spec.ClusterName)
 	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to get cluster %q for machine %q in namespace %q",
@@ -231,6 +259,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	// Capture the provisioning milestones before reconcile, so the metrics are only recorded once the
+	// Machine has been successfully patched.
+	observer := newMachineObserver(m)
+
 	defer func() {
 		r.updateStatus(ctx, s)
 
@@ -242,7 +274,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		}
 		if err := patchMachine(ctx, patchHelper, m, patchOpts...); err != nil {
 			reterr = kerrors.NewAggregate([]error{reterr, err})
+			return
 		}
+		observer.observe(m, r.now())
 	}()
 
 	alwaysReconcile := []machineReconcileFunc{
@@ -318,6 +352,7 @@ func patchMachine(ctx context.Context, patchHelper *patch.Helper, machine *clust
 			clusterv1.MachineInfrastructureReadyV1Beta2Condition,
 			clusterv1.MachineNodeReadyV1Beta2Condition,
 			clusterv1.MachineNodeHealthyV1Beta2Condition,
+			clusterv1.MachineVersionDriftV1Beta2Condition,
 			clusterv1.MachineDeletingV1Beta2Condition,
 		}},
 	)
@@ -458,12 +493,7 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 		// pre-drain.delete lifecycle hook
 		// Return early without error, will requeue if/when the hook owner removes the annotation.
 		if annotations.HasWithPrefix(clusterv1.PreDrainDeleteHookAnnotationPrefix, m.ObjectMeta.Annotations) {
-			var hooks []string
-			for key := range m.ObjectMeta.Annotations {
-				if strings.HasPrefix(key, clusterv1.PreDrainDeleteHookAnnotationPrefix) {
-					hooks = append(hooks, key)
-				}
-			}
+			hooks := deleteHooks(clusterv1.PreDrainDeleteHookAnnotationPrefix, m.ObjectMeta.Annotations)
 			log.Info("Waiting for pre-drain hooks to succeed", "hooks", strings.Join(hooks, ","))
 			conditions.MarkFalse(m, clusterv1.PreDrainDeleteHookSucceededCondition, clusterv1.WaitingExternalHookReason, clusterv1.ConditionSeverityInfo, "")
 			s.deletingReason = clusterv1.MachineDeletingWaitingForPreDrainHookV1Beta2Reason
@@ -491,6 +521,7 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 			// The DrainingSucceededCondition never exists before the node is drained for the first time.
 			if conditions.Get(m, clusterv1.DrainingSucceededCondition) == nil {
 				conditions.MarkFalse(m, clusterv1.DrainingSucceededCondition, clusterv1.DrainingReason, clusterv1.ConditionSeverityInfo, "Draining the node before deletion")
+				r.recorder.Eventf(m, corev1.EventTypeNormal, "DrainingNode", "Draining Machine's node %q", m.Status.NodeRef.Name)
 			}
 			s.deletingReason = clusterv1.MachineDeletingDrainingNodeV1Beta2Reason
 			s.deletingMessage = fmt.Sprintf("Drain not completed yet (started at %s):", m.Status.Deletion.NodeDrainStartTime.Format(time.RFC3339))
@@ -516,6 +547,8 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 
 			conditions.MarkTrue(m, clusterv1.DrainingSucceededCondition)
 			r.recorder.Eventf(m, corev1.EventTypeNormal, "SuccessfulDrainNode", "success draining Machine's node %q", m.Status.NodeRef.Name)
+		} else {
+			log.V(3).Info("Skipping Node drain, the Machine has the exclude-node-draining annotation or nodeDrainTimeout is exceeded", "Node", klog.KRef("", m.Status.NodeRef.Name))
 		}
 
 		// After node draining is completed, and if isNodeVolumeDetachingAllowed returns True, make sure all
@@ -548,18 +581,15 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 			}
 			conditions.MarkTrue(m, clusterv1.VolumeDetachSucceededCondition)
 			r.recorder.Eventf(m, corev1.EventTypeNormal, "NodeVolumesDetached", "success waiting for node volumes detaching Machine's node %q", m.Status.NodeRef.Name)
+		} else {
+			log.V(3).Info("Skipping wait for Node volumes detach, the Machine has the exclude-wait-for-node-volume-detach annotation or nodeVolumeDetachTimeout is exceeded", "Node", klog.KRef("", m.Status.NodeRef.Name))
 		}
 	}
 
 	// pre-term.delete lifecycle hook
 	// Return early without error, will requeue if/when the hook owner removes the annotation.
 	if annotations.HasWithPrefix(clusterv1.PreTerminateDeleteHookAnnotationPrefix, m.ObjectMeta.Annotations) {
-		var hooks []string
-		for key := range m.ObjectMeta.Annotations {
-			if strings.HasPrefix(key, clusterv1.PreTerminateDeleteHookAnnotationPrefix) {
-				hooks = append(hooks, key)
-			}
-		}
+		hooks := deleteHooks(clusterv1.PreTerminateDeleteHookAnnotationPrefix, m.ObjectMeta.Annotations)
 		log.Info("Waiting for pre-terminate hooks to succeed", "hooks", strings.Join(hooks, ","))
 		conditions.MarkFalse(m, clusterv1.PreTerminateDeleteHookSucceededCondition, clusterv1.WaitingExternalHookReason, clusterv1.ConditionSeverityInfo, "")
 		s.deletingReason = clusterv1.MachineDeletingWaitingForPreTerminateHookV1Beta2Reason
@@ -575,9 +605,13 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 		return ctrl.Result{}, err
 	}
 	if !infrastructureDeleted {
-		log.Info("Waiting for infrastructure to be deleted", m.Spec.InfrastructureRef.Kind, klog.KRef(m.Spec.InfrastructureRef.Namespace, m.Spec.InfrastructureRef.Name))
+		log.Info("Waiting for infrastructure to be deleted")
 		s.deletingReason = clusterv1.MachineDeletingWaitingForInfrastructureDeletionV1Beta2Reason
 		s.deletingMessage = fmt.Sprintf("Waiting for %s to be deleted", m.Spec.InfrastructureRef.Kind)
+		if s.infraMachine != nil && annotations.IsExternallyManaged(s.infraMachine) {
+			// Surface that the InfraMachine is only released once the external system managing it completes deletion.
+			s.deletingMessage = fmt.Sprintf("Waiting for %s to be released by %s", m.Spec.InfrastructureRef.Kind, s.infraMachine.GetAnnotations()[clusterv1.ManagedByAnnotation])
+		}
 		return ctrl.Result{}, nil
 	}
 
@@ -589,7 +623,7 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 			return ctrl.Result{}, err
 		}
 		if !bootstrapDeleted {
-			log.Info("Waiting for bootstrap to be deleted", m.Spec.Bootstrap.ConfigRef.Kind, klog.KRef(m.Spec.Bootstrap.ConfigRef.Namespace, m.Spec.Bootstrap.ConfigRef.Name))
+			log.Info("Waiting for bootstrap to be deleted")
 			s.deletingReason = clusterv1.MachineDeletingWaitingForBootstrapDeletionV1Beta2Reason
 			s.deletingMessage = fmt.Sprintf("Waiting for %s to be deleted", m.Spec.Bootstrap.ConfigRef.Kind)
 			return ctrl.Result{}, nil
@@ -614,7 +648,7 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 			r.recorder.Eventf(m, corev1.EventTypeWarning, "FailedDeleteNode", "error deleting Machine's node: %v", deleteNodeErr)
 
 			// If the node deletion timeout is not expired yet, requeue the Machine for reconciliation.
-			if m.Spec.NodeDeletionTimeout == nil || m.Spec.NodeDeletionTimeout.Nanoseconds() == 0 || m.DeletionTimestamp.Add(m.Spec.NodeDeletionTimeout.Duration).After(time.Now()) {
+			if m.Spec.NodeDeletionTimeout == nil || m.Spec.NodeDeletionTimeout.Nanoseconds() == 0 || m.DeletionTimestamp.Add(m.Spec.NodeDeletionTimeout.Duration).After(r.now()) {
 				s.deletingReason = clusterv1.MachineDeletingDeletingNodeV1Beta2Reason
 				s.deletingMessage = "Error deleting Node, please check controller logs for errors"
 				return ctrl.Result{}, deleteNodeErr
@@ -630,6 +664,21 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 	return ctrl.Result{}, nil
 }
 
+// deleteHooks returns the sorted list of annotations with the given delete hook prefix.
+// Sorting ensures the hooks are reported in a stable order in logs and conditions.
+func deleteHooks(prefix string, annotations map[string]string) []string {
+	var hooks []string
+	for key := range annotations {
+		if strings.HasPrefix(key, prefix) {
+			hooks = append(hooks, key)
+		}
+	}
+	slices.Sort(hooks)
+	return hooks
+}
+
+// isNodeDrainAllowed returns False if either ExcludeNodeDrainingAnnotation annotation is set OR
+// nodeDrainTimeoutExceeded timeout is exceeded, otherwise returns True.
 func (r *Reconciler) isNodeDrainAllowed(m *clusterv1.Machine) bool {
 	if _, exists := m.ObjectMeta.Annotations[clusterv1.ExcludeNodeDrainingAnnotation]; exists {
 		return false
@@ -656,6 +705,29 @@ func (r *Reconciler) isNodeVolumeDetachingAllowed(m *clusterv1.Machine) bool {
 	return true
 }
 
+// now returns the current time according to the Reconciler's clock.
+func (r *Reconciler) now() time.Time {
+	if r.clock == nil {
+		return time.Now()
+	}
+	return r.clock.Now()
+}
+
+// nodeDeregistrationWait returns the duration to wait before evicting Pods as defined by the
+// WaitForNodeDeregistrationAnnotation, if set to a valid duration.
+func nodeDeregistrationWait(machine *clusterv1.Machine) (time.Duration, bool) {
+	value, ok := machine.Annotations[clusterv1.WaitForNodeDeregistrationAnnotation]
+	if !ok {
+		return 0, false
+	}
+	// Note: Invalid values are rejected by the Machine webhook.
+	wait, err := time.ParseDuration(value)
+	if err != nil || wait <= 0 {
+		return 0, false
+	}
+	return wait, true
+}
+
 func (r *Reconciler) nodeDrainTimeoutExceeded(machine *clusterv1.Machine) bool {
 	// if the NodeDrainTimeout type is not set by user
 	if machine.Status.Deletion == nil || machine.Spec.NodeDrainTimeout == nil || machine.Spec.NodeDrainTimeout.Seconds() <= 0 {
@@ -667,8 +739,7 @@ func (r *Reconciler) nodeDrainTimeoutExceeded(machine *clusterv1.Machine) bool {
 		return false
 	}
 
-	now := time.Now()
-	diff := now.Sub(machine.Status.Deletion.NodeDrainStartTime.Time)
+	diff := r.now().Sub(machine.Status.Deletion.NodeDrainStartTime.Time)
 	return diff.Seconds() >= machine.Spec.NodeDrainTimeout.Seconds()
 }
 
@@ -686,8 +757,7 @@ func (r *Reconciler) nodeVolumeDetachTimeoutExceeded(machine *clusterv1.Machine)
 		return false
 	}
 
-	now := time.Now()
-	diff := now.Sub(machine.Status.Deletion.WaitForNodeVolumeDetachStartTime.Time)
+	diff := r.now().Sub(machine.Status.Deletion.WaitForNodeVolumeDetachStartTime.Time)
 	return diff.Seconds() >= machine.Spec.NodeVolumeDetachTimeout.Seconds()
 }
 
@@ -842,6 +912,26 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 		return ctrl.Result{}, errors.Wrapf(err, "failed to cordon Node %s", node.Name)
 	}
 
+	// If requested, exclude the Node from external load balancers and give load balancers health checks time to fail over
+	// before evicting Pods, e.g. for Services with externalTrafficPolicy=Local.
+	// Note: This is skipped for unreachable Nodes, because their Pods are not serving anymore.
+	if wait, ok := nodeDeregistrationWait(machine); ok && !noderefutil.IsNodeUnreachable(node) {
+		if err := drainer.ExcludeNodeFromLoadBalancers(ctx, node); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to exclude Node %s from external load balancers", node.Name)
+		}
+
+		if machine.Status.Deletion != nil && machine.Status.Deletion.NodeDrainStartTime != nil {
+			if remaining := wait - r.now().Sub(machine.Status.Deletion.NodeDrainStartTime.Time); remaining > 0 {
+				message := fmt.Sprintf("Waiting %s for the Node to be deregistered from load balancers before evicting Pods (started at %s)", wait, machine.Status.Deletion.NodeDrainStartTime.Format(time.RFC3339))
+				conditions.MarkFalse(machine, clusterv1.DrainingSucceededCondition, clusterv1.DrainingReason, clusterv1.ConditionSeverityInfo, message)
+				s.deletingReason = clusterv1.MachineDeletingDrainingNodeV1Beta2Reason
+				s.deletingMessage = message
+				log.Info(fmt.Sprintf("Waiting for the Node to be deregistered from load balancers, requeuing in %s", remaining))
+				return ctrl.Result{RequeueAfter: remaining}, nil
+			}
+		}
+	}
+
 	podDeleteList, err := drainer.GetPodsForEviction(ctx, cluster, machine, nodeName)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -897,6 +987,12 @@ func (r *Reconciler) shouldWaitForNodeVolumes(ctx context.Context, s *scope) (ct
 
 	remoteClient, err := r.ClusterCache.GetClient(ctx, util.ObjectKey(cluster))
 	if err != nil {
+		if errors.Is(err, clustercache.ErrClusterNotConnected) {
+			log.V(5).Info("Requeuing wait for Node volumes to be detached because connection to the workload cluster is down")
+			s.deletingReason = clusterv1.MachineDeletingWaitingForVolumeDetachV1Beta2Reason
+			s.deletingMessage = "Requeuing wait for Node volumes to be detached because connection to the workload cluster is down"
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
 		return ctrl.Result{}, err
 	}
 
@@ -1006,6 +1102,16 @@ func (r *Reconciler) reconcileDeleteInfrastructure(ctx context.Context, s *scope
 		return true, nil
 	}
 
+	// Externally managed InfraMachines are not deleted by the Machine controller; the external management system
+	// signals it released the machine infrastructure by removing the Machine controller reference from the InfraMachine.
+	if s.infraMachine != nil && annotations.IsExternallyManaged(s.infraMachine) {
+		if !metav1.IsControlledBy(s.infraMachine, s.machine) {
+			conditions.MarkFalse(s.machine, clusterv1.InfrastructureReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+			return true, nil
+		}
+		return false, nil
+	}
+
 	if s.infraMachine != nil && s.infraMachine.GetDeletionTimestamp().IsZero() {
 		if err := r.Client.Delete(ctx, s.infraMachine); err != nil && !apierrors.IsNotFound(err) {
 			return false, errors.Wrapf(err,
@@ -1067,11 +1173,13 @@ func (r *Reconciler) nodeToMachine(ctx context.Context, o client.Object) []recon
 	}
 
 	var filters []client.ListOption
-	// Match by clusterName when the node has the annotation.
-	if clusterName, ok := node.GetAnnotations()[clusterv1.ClusterNameAnnotation]; ok {
-		filters = append(filters, client.MatchingLabels{
-			clusterv1.ClusterNameLabel: clusterName,
-		})
+	// matchingFields returns the field selector for the given field, matching by clusterName when the node has the annotation.
+	matchingFields := func(field, value string) client.MatchingFields {
+		fields := client.MatchingFields{field: value}
+		if clusterName, ok := node.GetAnnotations()[clusterv1.ClusterNameAnnotation]; ok {
+			fields[index.MachineClusterNameField] = clusterName
+		}
+		return fields
 	}
 
 	// Match by namespace when the node has the annotation.
@@ -1084,7 +1192,8 @@ func (r *Reconciler) nodeToMachine(ctx context.Context, o client.Object) []recon
 	if err := r.Client.List(
 		ctx,
 		machineList,
-		append(filters, client.MatchingFields{index.MachineNodeNameField: node.Name})...); err != nil {
+		append(filters, matchingFields(index.MachineNodeNameField, node.Name))...); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list Machines by Node name", "Node", klog.KObj(node))
 		return nil
 	}
 
@@ -1102,7 +1211,8 @@ func (r *Reconciler) nodeToMachine(ctx context.Context, o client.Object) []recon
 	if err := r.Client.List(
 		ctx,
 		machineList,
-		append(filters, client.MatchingFields{index.MachineProviderIDField: node.Spec.ProviderID})...); err != nil {
+		append(filters, matchingFields(index.MachineProviderIDField, node.Spec.ProviderID))...); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list Machines by providerID", "Node", klog.KObj(node), "providerID", node.Spec.ProviderID)
 		return nil
 	}
 
@@ -19,7 +19,10 @@ package machine
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -28,12 +31,14 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/api/v1beta1/index"
 	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/internal/util/drift"
 	"sigs.k8s.io/cluster-api/internal/util/taints"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
@@ -45,6 +50,19 @@ var (
 	ErrNodeNotFound = errors.New("cannot find node with matching ProviderID")
 )
 
+// nodeProvisioningTimeout is the time after which a Machine still waiting for a Node with a matching ProviderID
+// surfaces that the Node is taking too long to appear.
+const nodeProvisioningTimeout = 10 * time.Minute
+
+// nodeProvisioningStartTime returns when the Machine started waiting for its Node, i.e. when the infrastructure became
+// ready or, if this is not known, when the Machine has been created.
+func nodeProvisioningStartTime(machine *clusterv1.Machine) time.Time {
+	if c := conditions.Get(machine, clusterv1.InfrastructureReadyCondition); c != nil && c.Status == corev1.ConditionTrue {
+		return c.LastTransitionTime.Time
+	}
+	return machine.CreationTimestamp.Time
+}
+
 func (r *Reconciler) reconcileNode(ctx context.Context, s *scope) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	cluster := s.cluster
@@ -59,7 +77,7 @@ func (r *Reconciler) reconcileNode(ctx context.Context, s *scope) (ctrl.Result,
 
 	// Check that the Machine has a valid ProviderID.
 	if machine.Spec.ProviderID == nil || *machine.Spec.ProviderID == "" {
-		log.Info("Waiting for infrastructure provider to report spec.providerID", machine.Spec.InfrastructureRef.Kind, klog.KRef(machine.Spec.InfrastructureRef.Namespace, machine.Spec.InfrastructureRef.Name))
+		log.Info("Waiting for infrastructure provider to report spec.providerID")
 		conditions.MarkFalse(machine, clusterv1.MachineNodeHealthyCondition, clusterv1.WaitingForNodeRefReason, clusterv1.ConditionSeverityInfo, "")
 		return ctrl.Result{}, nil
 	}
@@ -85,18 +103,53 @@ func (r *Reconciler) reconcileNode(ctx context.Context, s *scope) (ctrl.Result,
 				conditions.MarkFalse(machine, clusterv1.MachineNodeHealthyCondition, clusterv1.NodeNotFoundReason, clusterv1.ConditionSeverityError, "")
 				return ctrl.Result{}, errors.Wrapf(err, "no matching Node for Machine %q in namespace %q", machine.Name, machine.Namespace)
 			}
-			conditions.MarkFalse(machine, clusterv1.MachineNodeHealthyCondition, clusterv1.NodeProvisioningReason, clusterv1.ConditionSeverityWarning, "Waiting for a node with matching ProviderID to exist")
-			log.Info("Infrastructure provider reporting spec.providerID, matching Kubernetes node is not yet available", machine.Spec.InfrastructureRef.Kind, klog.KRef(machine.Spec.InfrastructureRef.Namespace, machine.Spec.InfrastructureRef.Name), "providerID", *machine.Spec.ProviderID)
+			waitingFor := r.now().Sub(nodeProvisioningStartTime(machine))
+			if waitingFor < nodeProvisioningTimeout {
+				conditions.MarkFalse(machine, clusterv1.MachineNodeHealthyCondition, clusterv1.NodeProvisioningReason, clusterv1.ConditionSeverityWarning, "Waiting for a node with matching ProviderID to exist")
+				log.Info("Infrastructure provider reporting spec.providerID, matching Kubernetes node is not yet available", "providerID", *machine.Spec.ProviderID)
+				// Nodes emit an event that triggers reconciliation, requeue only to surface when the timeout is exceeded.
+				return ctrl.Result{RequeueAfter: nodeProvisioningTimeout - waitingFor}, nil
+			}
+
+			// Surface that the Node is taking too long to appear, which usually means that the kubelet is
+			// not reporting the same ProviderID as the infrastructure provider, or that it failed to join the cluster.
+			conditions.MarkFalse(machine, clusterv1.MachineNodeHealthyCondition, clusterv1.NodeProvisioningReason, clusterv1.ConditionSeverityWarning,
+				"No node with ProviderID %s found for more than %s, check that the kubelet joined the cluster and reports the same ProviderID", *machine.Spec.ProviderID, nodeProvisioningTimeout)
+			log.Info(fmt.Sprintf("Infrastructure provider reporting spec.providerID, matching Kubernetes node is not available after %s", nodeProvisioningTimeout), "providerID", *machine.Spec.ProviderID)
 			// No need to requeue here. Nodes emit an event that triggers reconciliation.
 			return ctrl.Result{}, nil
 		}
 		s.nodeGetError = err
-		r.recorder.Event(machine, corev1.EventTypeWarning, "Failed to retrieve Node by ProviderID", err.Error())
+		r.recorder.Event(machine, corev1.EventTypeWarning, "FailedGetNode", err.Error())
 		conditions.MarkUnknown(machine, clusterv1.MachineNodeHealthyCondition, clusterv1.NodeInspectionFailedReason, "Failed to get the Node for this Machine by ProviderID")
 		return ctrl.Result{}, err
 	}
+
+	// Do not associate a Node which is already associated to another Machine, e.g. when a Machine is created
+	// for a pre-existing Node while the Machine originally created for the same Node still exists.
+	if machine.Status.NodeRef == nil {
+		otherMachine, err := r.getOtherMachineForNode(ctx, machine, node)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if otherMachine != "" {
+			log.Info("Matching Kubernetes node is already associated to another Machine, waiting for it to be deleted", "Node", klog.KObj(node), "otherMachine", otherMachine)
+			conditions.MarkFalse(machine, clusterv1.MachineNodeHealthyCondition, clusterv1.NodeAlreadyAssociatedReason, clusterv1.ConditionSeverityWarning, "Node %s is already associated to Machine %s", node.Name, otherMachine)
+			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+	}
 	s.node = node
 
+	// Propagate the delete-machine annotation from the Node to the Machine, so users and tools which only have access
+	// to the workload cluster, e.g. spot termination handlers, can mark the Machine to be given priority for deletion.
+	// NOTE: the annotation is never removed from the Machine, because it could have been set on the Machine directly.
+	if value, ok := s.node.Annotations[clusterv1.DeleteMachineAnnotation]; ok {
+		if _, ok := machine.Annotations[clusterv1.DeleteMachineAnnotation]; !ok {
+			log.Info(fmt.Sprintf("Node is annotated with %s, propagating the annotation to the Machine", clusterv1.DeleteMachineAnnotation), "Node", klog.KObj(s.node))
+			annotations.AddAnnotations(machine, map[string]string{clusterv1.DeleteMachineAnnotation: value})
+		}
+	}
+
 	// Set the Machine NodeRef.
 	if machine.Status.NodeRef == nil {
 		machine.Status.NodeRef = &corev1.ObjectReference{
@@ -105,8 +158,18 @@ func (r *Reconciler) reconcileNode(ctx context.Context, s *scope) (ctrl.Result,
 			Name:       s.node.Name,
 			UID:        s.node.UID,
 		}
-		log.Info("Infrastructure provider reporting spec.providerID, Kubernetes node is now available", machine.Spec.InfrastructureRef.Kind, klog.KRef(machine.Spec.InfrastructureRef.Namespace, machine.Spec.InfrastructureRef.Name), "providerID", *machine.Spec.ProviderID, "Node", klog.KRef("", machine.Status.NodeRef.Name))
+		log.Info("Infrastructure provider reporting spec.providerID, Kubernetes node is now available", "providerID", *machine.Spec.ProviderID, "Node", klog.KRef("", machine.Status.NodeRef.Name))
 		r.recorder.Event(machine, corev1.EventTypeNormal, "SuccessfulSetNodeRef", machine.Status.NodeRef.Name)
+		machineTimeline(machine).NodeJoinedAt = ptr.To(metav1.NewTime(r.now()))
+	}
+
+	// Surface a warning when the kubelet version reported by the Node differs from the version declared on the Machine;
+	// only do this when the reported kubelet version changes, to avoid emitting the same event on every reconcile.
+	if machine.Status.NodeInfo == nil || machine.Status.NodeInfo.KubeletVersion != s.node.Status.NodeInfo.KubeletVersion {
+		if drift.KubeletVersion(machine, &s.node.Status.NodeInfo) {
+			log.Info("Kubelet version reported by the Node does not match the Machine version", "Node", klog.KObj(s.node), "kubeletVersion", s.node.Status.NodeInfo.KubeletVersion, "version", *machine.Spec.Version)
+			r.recorder.Eventf(machine, corev1.EventTypeWarning, "KubeletVersionDrift", "Node %s is running kubelet %s, expected %s", s.node.Name, s.node.Status.NodeInfo.KubeletVersion, *machine.Spec.Version)
+		}
 	}
 
 	// Set the NodeSystemInfo.
@@ -129,6 +192,16 @@ func (r *Reconciler) reconcileNode(ctx context.Context, s *scope) (ctrl.Result,
 	// NOTE: Once we reconcile node labels for the first time, the NodeUninitializedTaint is removed from the node.
 	nodeLabels := getManagedLabels(machine.Labels)
 
+	// Ensure the Nodes of control plane Machines have the standard control plane role label, also if the control
+	// plane provider does not set it; roles of worker Nodes can be set with node-role.kubernetes.io labels on Machines.
+	if util.IsControlPlaneMachine(machine) {
+		nodeLabels[clusterv1.NodeRoleControlPlaneLabel] = ""
+	}
+
+	// Compute annotations to be propagated from Machines to nodes.
+	// NOTE: only annotations in the node.cluster.x-k8s.io domain are propagated, everything else should be preserved.
+	nodeAnnotationsFromMachine := getManagedAnnotations(machine.Annotations)
+
 	// Get interruptible instance status from the infrastructure provider and set the interruptible label on the node.
 	interruptible := false
 	found := false
@@ -143,10 +216,24 @@ func (r *Reconciler) reconcileNode(ctx context.Context, s *scope) (ctrl.Result,
 		}
 	}
 
+	// Set the interruptible label on the Machine too, so e.g. MachineHealthChecks can select Machines running
+	// on interruptible instances.
+	// NOTE: the label is not in a managed domain, so it is not propagated from the Machine to the Node.
+	// NOTE: the label is only removed if the InfraMachine reports status.interruptible, so it is not removed
+	// when set by users with InfraMachines not supporting it.
+	if interruptible {
+		if machine.Labels == nil {
+			machine.Labels = map[string]string{}
+		}
+		machine.Labels[clusterv1.InterruptibleLabel] = ""
+	} else if found {
+		delete(machine.Labels, clusterv1.InterruptibleLabel)
+	}
+
 	_, nodeHadInterruptibleLabel := s.node.Labels[clusterv1.InterruptibleLabel]
 
 	// Reconcile node taints
-	if err := r.patchNode(ctx, remoteClient, s.node, nodeLabels, nodeAnnotations, machine); err != nil {
+	if err := r.patchNode(ctx, remoteClient, s.node, nodeLabels, nodeAnnotations, nodeAnnotationsFromMachine, machine); err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile Node %s", klog.KObj(s.node))
 	}
 	if !nodeHadInterruptibleLabel && interruptible {
@@ -176,6 +263,25 @@ func (r *Reconciler) reconcileNode(ctx context.Context, s *scope) (ctrl.Result,
 	return ctrl.Result{}, nil
 }
 
+// getOtherMachineForNode returns the name of the Machine the Node is associated to, as tracked by the annotations
+// set by this controller on Nodes, if it is a different Machine which still exists.
+func (r *Reconciler) getOtherMachineForNode(ctx context.Context, machine *clusterv1.Machine, node *corev1.Node) (string, error) {
+	name := node.Annotations[clusterv1.MachineAnnotation]
+	namespace := node.Annotations[clusterv1.ClusterNamespaceAnnotation]
+	if name == "" || (name == machine.Name && namespace == machine.Namespace) {
+		return "", nil
+	}
+
+	otherMachine := &clusterv1.Machine{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, otherMachine); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get Machine %s associated to Node %s", klog.KRef(namespace, name), klog.KObj(node))
+	}
+	return klog.KObj(otherMachine).String(), nil
+}
+
 // getManagedLabels gets a map[string]string and returns another map[string]string
 // filtering out labels not managed by CAPI.
 func getManagedLabels(labels map[string]string) map[string]string {
@@ -196,6 +302,20 @@ func getManagedLabels(labels map[string]string) map[string]string {
 	return managedLabels
 }
 
+// getManagedAnnotations gets a map[string]string and returns another map[string]string
+// filtering out annotations not managed by CAPI.
+func getManagedAnnotations(annotations map[string]string) map[string]string {
+	managedAnnotations := make(map[string]string)
+	for key, value := range annotations {
+		dnsSubdomainOrName := strings.Split(key, "/")[0]
+		if dnsSubdomainOrName == clusterv1.ManagedNodeLabelDomain || strings.HasSuffix(dnsSubdomainOrName, "."+clusterv1.ManagedNodeLabelDomain) {
+			managedAnnotations[key] = value
+		}
+	}
+
+	return managedAnnotations
+}
+
 // summarizeNodeConditions summarizes a Node's conditions and returns the summary of condition statuses and concatenate failed condition messages:
 // if there is at least 1 semantically-negative condition, summarized status = False;
 // if there is at least 1 semantically-positive condition when there is 0 semantically negative condition, summarized status = True;
@@ -274,7 +394,7 @@ func (r *Reconciler) getNode(ctx context.Context, c client.Reader, providerID st
 
 // PatchNode is required to workaround an issue on Node.Status.Address which is incorrectly annotated as patchStrategy=merge
 // and this causes SSA patch to fail in case there are two addresses with the same key https://github.com/kubernetes-sigs/cluster-api/issues/8417
-func (r *Reconciler) patchNode(ctx context.Context, remoteClient client.Client, node *corev1.Node, newLabels, newAnnotations map[string]string, m *clusterv1.Machine) error {
+func (r *Reconciler) patchNode(ctx context.Context, remoteClient client.Client, node *corev1.Node, newLabels, newAnnotations, annotationsFromMachine map[string]string, m *clusterv1.Machine) error {
 	newNode := node.DeepCopy()
 
 	// Adds the annotations CAPI sets on the node.
@@ -287,29 +407,31 @@ func (r *Reconciler) patchNode(ctx context.Context, remoteClient client.Client,
 	if newNode.Labels == nil {
 		newNode.Labels = make(map[string]string)
 	}
-	hasLabelChanges := false
-	labelsFromPreviousReconcile := strings.Split(newNode.Annotations[clusterv1.LabelsFromMachineAnnotation], ",")
-	if len(labelsFromPreviousReconcile) == 1 && labelsFromPreviousReconcile[0] == "" {
-		labelsFromPreviousReconcile = []string{}
+	labelsFromCurrentReconcile, hasLabelChanges := syncMetadataFromMachine(newNode.Labels, newNode.Annotations[clusterv1.LabelsFromMachineAnnotation], newLabels)
+
+	// Adds the annotations from the Machine, tracking them the same way as labels.
+	if newNode.Annotations == nil {
+		newNode.Annotations = make(map[string]string)
 	}
-	labelsFromCurrentReconcile := []string{}
-	for k, v := range newLabels {
-		if cur, ok := newNode.Labels[k]; !ok || cur != v {
-			newNode.Labels[k] = v
-			hasLabelChanges = true
-		}
-		labelsFromCurrentReconcile = append(labelsFromCurrentReconcile, k)
+	annotationsFromCurrentReconcile, hasMachineAnnotationChanges := syncMetadataFromMachine(newNode.Annotations, newNode.Annotations[clusterv1.AnnotationsFromMachineAnnotation], annotationsFromMachine)
+	hasAnnotationChanges = hasAnnotationChanges || hasMachineAnnotationChanges
+
+	annotations.AddAnnotations(newNode, map[string]string{clusterv1.LabelsFromMachineAnnotation: labelsFromCurrentReconcile})
+	if annotationsFromCurrentReconcile != "" || newNode.Annotations[clusterv1.AnnotationsFromMachineAnnotation] != "" {
+		hasAnnotationChanges = annotations.AddAnnotations(newNode, map[string]string{clusterv1.AnnotationsFromMachineAnnotation: annotationsFromCurrentReconcile}) || hasAnnotationChanges
 	}
-	for _, k := range labelsFromPreviousReconcile {
-		if _, ok := newLabels[k]; !ok {
-			delete(newNode.Labels, k)
-			hasLabelChanges = true
-		}
+
+	// Adds the taints from the Machine, tracking them the same way as labels.
+	taintsFromCurrentReconcile, hasMachineTaintChanges := syncTaintsFromMachine(newNode, newNode.Annotations[clusterv1.TaintsFromMachineAnnotation], m.Spec.Taints)
+	if taintsFromCurrentReconcile != "" || newNode.Annotations[clusterv1.TaintsFromMachineAnnotation] != "" {
+		hasAnnotationChanges = annotations.AddAnnotations(newNode, map[string]string{clusterv1.TaintsFromMachineAnnotation: taintsFromCurrentReconcile}) || hasAnnotationChanges
 	}
-	annotations.AddAnnotations(newNode, map[string]string{clusterv1.LabelsFromMachineAnnotation: strings.Join(labelsFromCurrentReconcile, ",")})
+
+	// Cordon or uncordon the node as requested on the Machine.
+	cordonChange := syncNodeCordon(newNode, m)
 
 	// Drop the NodeUninitializedTaint taint on the node given that we are reconciling labels.
-	hasTaintChanges := taints.RemoveNodeTaint(newNode, clusterv1.NodeUninitializedTaint)
+	hasTaintChanges := taints.RemoveNodeTaint(newNode, clusterv1.NodeUninitializedTaint) || hasMachineTaintChanges
 
 	// Set Taint to a node in an old MachineSet and unset Taint from a node in a new MachineSet
 	isOutdated, notFound, err := shouldNodeHaveOutdatedTaint(ctx, r.Client, m)
@@ -328,11 +450,115 @@ func (r *Reconciler) patchNode(ctx context.Context, remoteClient client.Client,
 		}
 	}
 
-	if !hasAnnotationChanges && !hasLabelChanges && !hasTaintChanges {
+	if !hasAnnotationChanges && !hasLabelChanges && !hasTaintChanges && cordonChange == "" {
 		return nil
 	}
 
-	return remoteClient.Patch(ctx, newNode, client.StrategicMergeFrom(node))
+	if err := remoteClient.Patch(ctx, newNode, client.StrategicMergeFrom(node)); err != nil {
+		return err
+	}
+	if cordonChange != "" {
+		r.recorder.Event(m, corev1.EventTypeNormal, cordonChange, node.Name)
+	}
+	return nil
+}
+
+// syncNodeCordon cordons the node if the Machine has the CordonNodeAnnotation and uncordons it once the annotation is removed.
+// It returns the reason of the event to be recorded if the node has been changed, an empty string otherwise.
+// NOTE: a node is only uncordoned if it has been cordoned this way, as tracked by the CordonedByMachineAnnotation, so
+// nodes cordoned by users are preserved. Also nodes of deleting Machines are never uncordoned, because they are cordoned
+// when they are drained.
+func syncNodeCordon(node *corev1.Node, m *clusterv1.Machine) string {
+	_, cordon := m.Annotations[clusterv1.CordonNodeAnnotation]
+	_, cordonedByMachine := node.Annotations[clusterv1.CordonedByMachineAnnotation]
+
+	if cordon {
+		if node.Spec.Unschedulable {
+			return ""
+		}
+		node.Spec.Unschedulable = true
+		annotations.AddAnnotations(node, map[string]string{clusterv1.CordonedByMachineAnnotation: ""})
+		return "SuccessfulCordonNode"
+	}
+
+	if !cordonedByMachine || !m.DeletionTimestamp.IsZero() {
+		return ""
+	}
+	node.Spec.Unschedulable = false
+	delete(node.Annotations, clusterv1.CordonedByMachineAnnotation)
+	return "SuccessfulUncordonNode"
+}
+
+// syncMetadataFromMachine sets the desired key/value pairs on current and removes the keys which have been
+// set from the Machine in a previous reconcile (as recorded in previous, a comma separated list) but are not desired anymore.
+// It returns the sorted, comma separated list of keys set from the Machine and whether current has been changed.
+func syncMetadataFromMachine(current map[string]string, previous string, desired map[string]string) (string, bool) {
+	hasChanges := false
+	keys := make([]string, 0, len(desired))
+	for k, v := range desired {
+		if cur, ok := current[k]; !ok || cur != v {
+			current[k] = v
+			hasChanges = true
+		}
+		keys = append(keys, k)
+	}
+	if previous != "" {
+		for _, k := range strings.Split(previous, ",") {
+			if _, ok := desired[k]; ok {
+				continue
+			}
+			if _, ok := current[k]; ok {
+				delete(current, k)
+				hasChanges = true
+			}
+		}
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ","), hasChanges
+}
+
+// syncTaintsFromMachine sets the desired taints on the node and removes the taints which have been set from the Machine
+// in a previous reconcile (as recorded in previous, a comma separated list of key:effect) but are not desired anymore.
+// It returns the sorted, comma separated list of taints set from the Machine and whether the node has been changed.
+// NOTE: taints are identified by key and effect, the same way the Node API does; the value of a desired taint
+// is updated if it has been changed on the node.
+func syncTaintsFromMachine(node *corev1.Node, previous string, desired []corev1.Taint) (string, bool) {
+	hasChanges := false
+	keys := make([]string, 0, len(desired))
+	for _, taint := range desired {
+		keys = append(keys, fmt.Sprintf("%s:%s", taint.Key, taint.Effect))
+
+		found := false
+		for i := range node.Spec.Taints {
+			if !node.Spec.Taints[i].MatchTaint(&taint) {
+				continue
+			}
+			found = true
+			if node.Spec.Taints[i].Value != taint.Value {
+				node.Spec.Taints[i].Value = taint.Value
+				hasChanges = true
+			}
+			break
+		}
+		if !found {
+			node.Spec.Taints = append(node.Spec.Taints, taint)
+			hasChanges = true
+		}
+	}
+	if previous != "" {
+		for _, k := range strings.Split(previous, ",") {
+			if slices.Contains(keys, k) {
+				continue
+			}
+			key, effect, _ := strings.Cut(k, ":")
+			if taints.HasTaint(node.Spec.Taints, corev1.Taint{Key: key, Effect: corev1.TaintEffect(effect)}) {
+				taints.RemoveNodeTaint(node, corev1.Taint{Key: key, Effect: corev1.TaintEffect(effect)})
+				hasChanges = true
+			}
+		}
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ","), hasChanges
 }
 
 // shouldNodeHaveOutdatedTaint tries to compare the revision of the owning MachineSet to the MachineDeployment.
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,6 +42,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/internal/topology/ownerrefs"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
 	"sigs.k8s.io/cluster-api/util/test/builder"
 )
@@ -66,14 +68,19 @@ func TestReconcileNode(t *testing.T) {
 		},
 	}
 
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
 	testCases := []struct {
 		name               string
 		machine            *clusterv1.Machine
 		node               *corev1.Node
+		infraMachine       *unstructured.Unstructured
+		objs               []client.Object
 		nodeGetErr         bool
 		expectResult       ctrl.Result
 		expectError        bool
 		expected           func(g *WithT, m *clusterv1.Machine)
+		expectedNodeLabels map[string]string
 		expectNodeGetError bool
 	}{
 		{
@@ -101,6 +108,49 @@ func TestReconcileNode(t *testing.T) {
 			expectResult: ctrl.Result{},
 			expectError:  false,
 		},
+		{
+			name: "waiting for the node to exist, requeue until the timeout is exceeded",
+			machine: func() *clusterv1.Machine {
+				m := defaultMachine.DeepCopy()
+				m.Status.Conditions = clusterv1.Conditions{
+					{
+						Type:               clusterv1.InfrastructureReadyCondition,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-1 * time.Minute)),
+					},
+				}
+				return m
+			}(),
+			node:         nil,
+			nodeGetErr:   false,
+			expectResult: ctrl.Result{RequeueAfter: 9 * time.Minute},
+			expectError:  false,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(conditions.GetMessage(m, clusterv1.MachineNodeHealthyCondition)).To(Equal("Waiting for a node with matching ProviderID to exist"))
+			},
+		},
+		{
+			name: "waiting for the node to exist, timeout exceeded",
+			machine: func() *clusterv1.Machine {
+				m := defaultMachine.DeepCopy()
+				m.Status.Conditions = clusterv1.Conditions{
+					{
+						Type:               clusterv1.InfrastructureReadyCondition,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-1 * time.Hour)),
+					},
+				}
+				return m
+			}(),
+			node:         nil,
+			nodeGetErr:   false,
+			expectResult: ctrl.Result{},
+			expectError:  false,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(conditions.GetReason(m, clusterv1.MachineNodeHealthyCondition)).To(Equal(clusterv1.NodeProvisioningReason))
+				g.Expect(conditions.GetMessage(m, clusterv1.MachineNodeHealthyCondition)).To(Equal("No node with ProviderID aws://us-east-1/test-node-1 found for more than 10m0s, check that the kubelet joined the cluster and reports the same ProviderID"))
+			},
+		},
 		{
 			name:    "node found, should surface info",
 			machine: defaultMachine.DeepCopy(),
@@ -137,6 +187,60 @@ func TestReconcileNode(t *testing.T) {
 				g.Expect(m.Status.NodeInfo.MachineID).To(Equal("foo"))
 			},
 		},
+		{
+			name:    "node associated to another existing machine, should wait",
+			machine: defaultMachine.DeepCopy(),
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node-1",
+					Annotations: map[string]string{
+						clusterv1.MachineAnnotation:          "other-machine",
+						clusterv1.ClusterNamespaceAnnotation: metav1.NamespaceDefault,
+					},
+				},
+				Spec: corev1.NodeSpec{
+					ProviderID: "aws://us-east-1/test-node-1",
+				},
+			},
+			objs: []client.Object{
+				&clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "other-machine",
+						Namespace: metav1.NamespaceDefault,
+					},
+				},
+			},
+			nodeGetErr:   false,
+			expectResult: ctrl.Result{RequeueAfter: 30 * time.Second},
+			expectError:  false,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Status.NodeRef).To(BeNil())
+				g.Expect(conditions.GetReason(m, clusterv1.MachineNodeHealthyCondition)).To(Equal(clusterv1.NodeAlreadyAssociatedReason))
+			},
+		},
+		{
+			name:    "node associated to a machine which does not exist anymore, should surface info",
+			machine: defaultMachine.DeepCopy(),
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node-1",
+					Annotations: map[string]string{
+						clusterv1.MachineAnnotation:          "other-machine",
+						clusterv1.ClusterNamespaceAnnotation: metav1.NamespaceDefault,
+					},
+				},
+				Spec: corev1.NodeSpec{
+					ProviderID: "aws://us-east-1/test-node-1",
+				},
+			},
+			nodeGetErr:   false,
+			expectResult: ctrl.Result{},
+			expectError:  false,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Status.NodeRef).ToNot(BeNil())
+				g.Expect(m.Status.NodeRef.Name).To(Equal("test-node-1"))
+			},
+		},
 		{
 			name: "node not found when already seen, should error",
 			machine: &clusterv1.Machine{
@@ -163,6 +267,94 @@ func TestReconcileNode(t *testing.T) {
 			expectResult: ctrl.Result{},
 			expectError:  true,
 		},
+		{
+			name:    "node found with the delete-machine annotation, should propagate it to the machine",
+			machine: defaultMachine.DeepCopy(),
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node-1",
+					Annotations: map[string]string{
+						clusterv1.DeleteMachineAnnotation: "",
+					},
+				},
+				Spec: corev1.NodeSpec{
+					ProviderID: "aws://us-east-1/test-node-1",
+				},
+			},
+			nodeGetErr:   false,
+			expectResult: ctrl.Result{},
+			expectError:  false,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Annotations).To(HaveKey(clusterv1.DeleteMachineAnnotation))
+			},
+		},
+		{
+			name: "node found for a control plane machine, should set the control plane role label on the node",
+			machine: func() *clusterv1.Machine {
+				m := defaultMachine.DeepCopy()
+				m.Labels[clusterv1.MachineControlPlaneLabel] = ""
+				return m
+			}(),
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node-1",
+				},
+				Spec: corev1.NodeSpec{
+					ProviderID: "aws://us-east-1/test-node-1",
+				},
+			},
+			nodeGetErr:         false,
+			expectResult:       ctrl.Result{},
+			expectError:        false,
+			expectedNodeLabels: map[string]string{clusterv1.NodeRoleControlPlaneLabel: ""},
+		},
+		{
+			name:    "node found with an interruptible InfraMachine, should set the interruptible label on the machine",
+			machine: defaultMachine.DeepCopy(),
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node-1",
+				},
+				Spec: corev1.NodeSpec{
+					ProviderID: "aws://us-east-1/test-node-1",
+				},
+			},
+			infraMachine: &unstructured.Unstructured{Object: map[string]interface{}{
+				"status": map[string]interface{}{"interruptible": true},
+			}},
+			nodeGetErr:   false,
+			expectResult: ctrl.Result{},
+			expectError:  false,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Labels).To(HaveKey(clusterv1.InterruptibleLabel))
+			},
+		},
+		{
+			name: "node found with an InfraMachine which is not interruptible, should remove the interruptible label from the machine",
+			machine: func() *clusterv1.Machine {
+				m := defaultMachine.DeepCopy()
+				m.Labels[clusterv1.InterruptibleLabel] = ""
+				return m
+			}(),
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node-1",
+				},
+				Spec: corev1.NodeSpec{
+					ProviderID: "aws://us-east-1/test-node-1",
+				},
+			},
+			infraMachine: &unstructured.Unstructured{Object: map[string]interface{}{
+				"status": map[string]interface{}{"interruptible": false},
+			}},
+			nodeGetErr:   false,
+			expectResult: ctrl.Result{},
+			expectError:  false,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Labels).ToNot(HaveKey(clusterv1.InterruptibleLabel))
+				g.Expect(m.Labels).To(HaveKey(clusterv1.ClusterNameLabel))
+			},
+		},
 		{
 			name: "node not found is tolerated when machine is deleting",
 			machine: &clusterv1.Machine{
@@ -197,9 +389,9 @@ func TestReconcileNode(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			c := fake.NewClientBuilder().WithObjects(tc.machine).WithIndex(&corev1.Node{}, "spec.providerID", index.NodeByProviderID).Build()
+			c := fake.NewClientBuilder().WithObjects(tc.machine).WithObjects(tc.objs...).WithIndex(&corev1.Node{}, "spec.providerID", index.NodeByProviderID).Build()
 			if tc.nodeGetErr {
-				c = fake.NewClientBuilder().WithObjects(tc.machine).Build() // No Index
+				c = fake.NewClientBuilder().WithObjects(tc.machine).WithObjects(tc.objs...).Build() // No Index
 			}
 
 			if tc.node != nil {
@@ -211,8 +403,9 @@ func TestReconcileNode(t *testing.T) {
 				ClusterCache: clustercache.NewFakeClusterCache(c, client.ObjectKeyFromObject(defaultCluster)),
 				Client:       c,
 				recorder:     record.NewFakeRecorder(10),
+				clock:        clocktesting.NewFakePassiveClock(now),
 			}
-			s := &scope{cluster: defaultCluster, machine: tc.machine}
+			s := &scope{cluster: defaultCluster, machine: tc.machine, infraMachine: tc.infraMachine}
 			result, err := r.reconcileNode(ctx, s)
 			g.Expect(result).To(BeComparableTo(tc.expectResult))
 			if tc.expectError {
@@ -225,6 +418,14 @@ func TestReconcileNode(t *testing.T) {
 				tc.expected(g, tc.machine)
 			}
 
+			if tc.expectedNodeLabels != nil {
+				node := &corev1.Node{}
+				g.Expect(c.Get(ctx, client.ObjectKeyFromObject(tc.node), node)).To(Succeed())
+				for k, v := range tc.expectedNodeLabels {
+					g.Expect(node.Labels).To(HaveKeyWithValue(k, v))
+				}
+			}
+
 			g.Expect(s.nodeGetError != nil).To(Equal(tc.expectNodeGetError))
 		})
 	}
@@ -568,6 +769,11 @@ func TestNodeLabelSync(t *testing.T) {
 			// Interruptible label should be set on the node.
 			g.Expect(node.Labels).To(HaveKey(clusterv1.InterruptibleLabel))
 
+			// Interruptible label should be set on the machine.
+			m := &clusterv1.Machine{}
+			g.Expect(env.Get(ctx, client.ObjectKeyFromObject(machine), m)).To(Succeed())
+			g.Expect(m.Labels).To(HaveKey(clusterv1.InterruptibleLabel))
+
 			// Unmanaged Machine labels should not have been synced to the Node.
 			for k, v := range unmanagedMachineLabels {
 				g.Expect(node.Labels).ToNot(HaveKeyWithValue(k, v))
@@ -611,6 +817,11 @@ func TestNodeLabelSync(t *testing.T) {
 			// Interruptible label should not be on node.
 			g.Expect(node.Labels).NotTo(HaveKey(clusterv1.InterruptibleLabel))
 
+			// Interruptible label should not be on machine.
+			m := &clusterv1.Machine{}
+			g.Expect(env.Get(ctx, client.ObjectKeyFromObject(machine), m)).To(Succeed())
+			g.Expect(m.Labels).NotTo(HaveKey(clusterv1.InterruptibleLabel))
+
 			// Unmanaged Machine labels should not have been synced at all to the Node.
 			for k, v := range unmanagedMachineLabels {
 				g.Expect(node.Labels).ToNot(HaveKeyWithValue(k, v))
@@ -729,6 +940,283 @@ func TestGetManagedLabels(t *testing.T) {
 	g.Expect(got).To(BeEquivalentTo(managedLabels))
 }
 
+func TestGetManagedAnnotations(t *testing.T) {
+	// Create managedAnnotations map from known managed domains.
+	managedAnnotations := map[string]string{
+		clusterv1.ManagedNodeLabelDomain:                                  "",
+		"custom-prefix." + clusterv1.ManagedNodeLabelDomain:               "",
+		clusterv1.ManagedNodeLabelDomain + "/anything":                    "",
+		"custom-prefix." + clusterv1.ManagedNodeLabelDomain + "/anything": "",
+	}
+
+	// Append arbitrary annotations.
+	allAnnotations := map[string]string{
+		"foo":                               "",
+		"company.xyz/node.cluster.x-k8s.io": "not-managed",
+		"gpu-node.cluster.x-k8s.io":         "not-managed",
+		clusterv1.NodeRoleLabelPrefix + "/anyRole":    "not-managed",
+		clusterv1.NodeRestrictionLabelDomain + "/foo": "not-managed",
+		clusterv1.PreDrainDeleteHookAnnotationPrefix:  "not-managed",
+	}
+	for k, v := range managedAnnotations {
+		allAnnotations[k] = v
+	}
+
+	g := NewWithT(t)
+	got := getManagedAnnotations(allAnnotations)
+	g.Expect(got).To(BeEquivalentTo(managedAnnotations))
+}
+
+func TestSyncMetadataFromMachine(t *testing.T) {
+	tests := []struct {
+		name            string
+		current         map[string]string
+		previous        string
+		desired         map[string]string
+		expected        map[string]string
+		expectedTracked string
+		expectedChanges bool
+	}{
+		{
+			name:            "no changes if nothing is desired and nothing was tracked",
+			current:         map[string]string{"not-from-machine": "foo"},
+			expected:        map[string]string{"not-from-machine": "foo"},
+			expectedTracked: "",
+			expectedChanges: false,
+		},
+		{
+			name:            "adds desired keys and preserves the others",
+			current:         map[string]string{"not-from-machine": "foo"},
+			desired:         map[string]string{"b": "2", "a": "1"},
+			expected:        map[string]string{"not-from-machine": "foo", "a": "1", "b": "2"},
+			expectedTracked: "a,b",
+			expectedChanges: true,
+		},
+		{
+			name:            "corrects drift of desired keys",
+			current:         map[string]string{"a": "changed-on-node"},
+			previous:        "a",
+			desired:         map[string]string{"a": "1"},
+			expected:        map[string]string{"a": "1"},
+			expectedTracked: "a",
+			expectedChanges: true,
+		},
+		{
+			name:            "no changes if desired keys are already set",
+			current:         map[string]string{"a": "1"},
+			previous:        "a",
+			desired:         map[string]string{"a": "1"},
+			expected:        map[string]string{"a": "1"},
+			expectedTracked: "a",
+			expectedChanges: false,
+		},
+		{
+			name:            "removes keys previously set from the Machine",
+			current:         map[string]string{"a": "1", "b": "2", "not-from-machine": "foo"},
+			previous:        "a,b",
+			desired:         map[string]string{"a": "1"},
+			expected:        map[string]string{"a": "1", "not-from-machine": "foo"},
+			expectedTracked: "a",
+			expectedChanges: true,
+		},
+		{
+			name:            "no changes if keys previously set from the Machine are already gone",
+			current:         map[string]string{"not-from-machine": "foo"},
+			previous:        "a",
+			expected:        map[string]string{"not-from-machine": "foo"},
+			expectedTracked: "",
+			expectedChanges: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			tracked, changed := syncMetadataFromMachine(tt.current, tt.previous, tt.desired)
+			g.Expect(tt.current).To(BeEquivalentTo(tt.expected))
+			g.Expect(tracked).To(Equal(tt.expectedTracked))
+			g.Expect(changed).To(Equal(tt.expectedChanges))
+		})
+	}
+}
+
+func TestSyncTaintsFromMachine(t *testing.T) {
+	notFromMachine := corev1.Taint{Key: "not-from-machine", Effect: corev1.TaintEffectNoSchedule}
+
+	tests := []struct {
+		name            string
+		current         []corev1.Taint
+		previous        string
+		desired         []corev1.Taint
+		expected        []corev1.Taint
+		expectedTracked string
+		expectedChanges bool
+	}{
+		{
+			name:            "no changes if nothing is desired and nothing was tracked",
+			current:         []corev1.Taint{notFromMachine},
+			expected:        []corev1.Taint{notFromMachine},
+			expectedTracked: "",
+			expectedChanges: false,
+		},
+		{
+			name:    "adds desired taints and preserves the others",
+			current: []corev1.Taint{notFromMachine},
+			desired: []corev1.Taint{
+				{Key: "b", Value: "2", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "a", Value: "1", Effect: corev1.TaintEffectNoExecute},
+			},
+			expected: []corev1.Taint{
+				notFromMachine,
+				{Key: "b", Value: "2", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "a", Value: "1", Effect: corev1.TaintEffectNoExecute},
+			},
+			expectedTracked: "a:NoExecute,b:NoSchedule",
+			expectedChanges: true,
+		},
+		{
+			name:            "corrects drift of the value of desired taints",
+			current:         []corev1.Taint{{Key: "a", Value: "changed-on-node", Effect: corev1.TaintEffectNoSchedule}},
+			previous:        "a:NoSchedule",
+			desired:         []corev1.Taint{{Key: "a", Value: "1", Effect: corev1.TaintEffectNoSchedule}},
+			expected:        []corev1.Taint{{Key: "a", Value: "1", Effect: corev1.TaintEffectNoSchedule}},
+			expectedTracked: "a:NoSchedule",
+			expectedChanges: true,
+		},
+		{
+			name:            "no changes if desired taints are already set",
+			current:         []corev1.Taint{{Key: "a", Value: "1", Effect: corev1.TaintEffectNoSchedule}},
+			previous:        "a:NoSchedule",
+			desired:         []corev1.Taint{{Key: "a", Value: "1", Effect: corev1.TaintEffectNoSchedule}},
+			expected:        []corev1.Taint{{Key: "a", Value: "1", Effect: corev1.TaintEffectNoSchedule}},
+			expectedTracked: "a:NoSchedule",
+			expectedChanges: false,
+		},
+		{
+			name: "removes taints previously set from the Machine",
+			current: []corev1.Taint{
+				{Key: "a", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "a", Effect: corev1.TaintEffectNoExecute},
+				notFromMachine,
+			},
+			previous:        "a:NoExecute,a:NoSchedule",
+			desired:         []corev1.Taint{{Key: "a", Effect: corev1.TaintEffectNoSchedule}},
+			expected:        []corev1.Taint{{Key: "a", Effect: corev1.TaintEffectNoSchedule}, notFromMachine},
+			expectedTracked: "a:NoSchedule",
+			expectedChanges: true,
+		},
+		{
+			name:            "no changes if taints previously set from the Machine are already gone",
+			current:         []corev1.Taint{notFromMachine},
+			previous:        "a:NoSchedule",
+			expected:        []corev1.Taint{notFromMachine},
+			expectedTracked: "",
+			expectedChanges: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			node := &corev1.Node{Spec: corev1.NodeSpec{Taints: tt.current}}
+			tracked, changed := syncTaintsFromMachine(node, tt.previous, tt.desired)
+			g.Expect(node.Spec.Taints).To(Equal(tt.expected))
+			g.Expect(tracked).To(Equal(tt.expectedTracked))
+			g.Expect(changed).To(Equal(tt.expectedChanges))
+		})
+	}
+}
+func TestSyncNodeCordon(t *testing.T) {
+	tests := []struct {
+		name                  string
+		node                  *corev1.Node
+		machine               *clusterv1.Machine
+		expectedUnschedulable bool
+		expectedAnnotations   map[string]string
+		expectedChange        string
+	}{
+		{
+			name:                  "no changes if cordon is not requested",
+			node:                  &corev1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}},
+			machine:               &clusterv1.Machine{},
+			expectedUnschedulable: false,
+			expectedAnnotations:   map[string]string{},
+			expectedChange:        "",
+		},
+		{
+			name: "cordons the node if requested",
+			node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}},
+			machine: &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{clusterv1.CordonNodeAnnotation: ""},
+			}},
+			expectedUnschedulable: true,
+			expectedAnnotations:   map[string]string{clusterv1.CordonedByMachineAnnotation: ""},
+			expectedChange:        "SuccessfulCordonNode",
+		},
+		{
+			name: "no changes if cordon is requested and the node is already cordoned",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+				Spec:       corev1.NodeSpec{Unschedulable: true},
+			},
+			machine: &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{clusterv1.CordonNodeAnnotation: ""},
+			}},
+			expectedUnschedulable: true,
+			expectedAnnotations:   map[string]string{},
+			expectedChange:        "",
+		},
+		{
+			name: "uncordons the node if it has been cordoned by the Machine and cordon is not requested anymore",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{clusterv1.CordonedByMachineAnnotation: ""}},
+				Spec:       corev1.NodeSpec{Unschedulable: true},
+			},
+			machine:               &clusterv1.Machine{},
+			expectedUnschedulable: false,
+			expectedAnnotations:   map[string]string{},
+			expectedChange:        "SuccessfulUncordonNode",
+		},
+		{
+			name: "does not uncordon the node if it has not been cordoned by the Machine",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+				Spec:       corev1.NodeSpec{Unschedulable: true},
+			},
+			machine:               &clusterv1.Machine{},
+			expectedUnschedulable: true,
+			expectedAnnotations:   map[string]string{},
+			expectedChange:        "",
+		},
+		{
+			name: "does not uncordon the node if the Machine is being deleted",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{clusterv1.CordonedByMachineAnnotation: ""}},
+				Spec:       corev1.NodeSpec{Unschedulable: true},
+			},
+			machine: &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{
+				DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			}},
+			expectedUnschedulable: true,
+			expectedAnnotations:   map[string]string{clusterv1.CordonedByMachineAnnotation: ""},
+			expectedChange:        "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			change := syncNodeCordon(tt.node, tt.machine)
+			g.Expect(change).To(Equal(tt.expectedChange))
+			g.Expect(tt.node.Spec.Unschedulable).To(Equal(tt.expectedUnschedulable))
+			g.Expect(tt.node.Annotations).To(BeEquivalentTo(tt.expectedAnnotations))
+		})
+	}
+}
+
 func TestPatchNode(t *testing.T) {
 	clusterName := "test-cluster"
 
@@ -1182,7 +1670,7 @@ func TestPatchNode(t *testing.T) {
 				_ = env.CleanupAndWait(ctx, oldNode, machine, ms, md)
 			})
 
-			err := r.patchNode(ctx, env, oldNode, tc.newLabels, tc.newAnnotations, tc.machine)
+			err := r.patchNode(ctx, env, oldNode, tc.newLabels, tc.newAnnotations, nil, tc.machine)
 			g.Expect(err).ToNot(HaveOccurred())
 
 			g.Eventually(func(g Gomega) {
@@ -30,6 +30,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 
@@ -37,6 +38,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/external"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -93,6 +95,12 @@ func (r *Reconciler) ensureExternalOwnershipAndWatch(ctx context.Context, cluste
 		return nil, err
 	}
 
+	// When the Machine is being deleted, do not restore the controller reference of externally managed objects;
+	// the external management system removes it to signal it released the object.
+	if !m.DeletionTimestamp.IsZero() && annotations.IsExternallyManaged(obj) {
+		return obj, nil
+	}
+
 	// Initialize the patch helper.
 	patchHelper, err := patch.NewHelper(obj, r.Client)
 	if err != nil {
@@ -132,9 +140,14 @@ func (r *Reconciler) reconcileBootstrap(ctx context.Context, s *scope) (ctrl.Res
 	cluster := s.cluster
 	m := s.machine
 
-	// If the Bootstrap ref is nil (and so the machine should use user generated data secret), return.
+	// If the Bootstrap ref is nil, the machine uses a user generated data secret, e.g. with images which do not
+	// require a bootstrap provider; in this case there is no bootstrap config to wait for.
 	if m.Spec.Bootstrap.ConfigRef == nil {
-		return ctrl.Result{}, nil
+		if m.Spec.Bootstrap.DataSecretName == nil {
+			// Note: MachinePool Machines have neither a bootstrap configRef nor a data secret.
+			return ctrl.Result{}, nil
+		}
+		return r.reconcileBootstrapDataSecret(ctx, m)
 	}
 
 	// Call generic external reconciler if we have an external reference.
@@ -148,7 +161,7 @@ func (r *Reconciler) reconcileBootstrap(ctx context.Context, s *scope) (ctrl.Res
 				// TODO: we can also relax this and tolerate the absence of the bootstrap ref way before, e.g. after node ref is set
 				return ctrl.Result{}, nil
 			}
-			log.Info("Could not find bootstrap config object, requeuing", m.Spec.Bootstrap.ConfigRef.Kind, klog.KRef(m.Spec.Bootstrap.ConfigRef.Namespace, m.Spec.Bootstrap.ConfigRef.Name))
+			log.Info("Could not find bootstrap config object, requeuing")
 			// TODO: we can make this smarter and requeue only if we are before node ref is set
 			return ctrl.Result{RequeueAfter: externalReadyWait}, nil
 		}
@@ -158,9 +171,7 @@ func (r *Reconciler) reconcileBootstrap(ctx context.Context, s *scope) (ctrl.Res
 
 	// If the bootstrap data is populated, set ready and return.
 	if m.Spec.Bootstrap.DataSecretName != nil {
-		m.Status.BootstrapReady = true
-		conditions.MarkTrue(m, clusterv1.BootstrapReadyCondition)
-		return ctrl.Result{}, nil
+		return r.reconcileBootstrapDataSecret(ctx, m)
 	}
 
 	// Determine if the bootstrap provider is ready.
@@ -185,7 +196,7 @@ func (r *Reconciler) reconcileBootstrap(ctx context.Context, s *scope) (ctrl.Res
 
 	// If the bootstrap provider is not ready, return.
 	if !ready {
-		log.Info("Waiting for bootstrap provider to generate data secret and report status.ready", s.bootstrapConfig.GetKind(), klog.KObj(s.bootstrapConfig))
+		log.Info("Waiting for bootstrap provider to generate data secret and report status.ready")
 		return ctrl.Result{}, nil
 	}
 
@@ -198,12 +209,62 @@ func (r *Reconciler) reconcileBootstrap(ctx context.Context, s *scope) (ctrl.Res
 	}
 	m.Spec.Bootstrap.DataSecretName = ptr.To(secretName)
 	if !m.Status.BootstrapReady {
-		log.Info("Bootstrap provider generated data secret and reports status.ready", s.bootstrapConfig.GetKind(), klog.KObj(s.bootstrapConfig), "Secret", klog.KRef(m.Namespace, secretName))
+		log.Info("Bootstrap provider generated data secret and reports status.ready", "Secret", klog.KRef(m.Namespace, secretName))
+		machineTimeline(m).BootstrapDataGeneratedAt = ptr.To(metav1.NewTime(r.now()))
 	}
 	m.Status.BootstrapReady = true
 	return ctrl.Result{}, nil
 }
 
+// reconcileBootstrapDataSecret sets bootstrap ready for a Machine with a populated bootstrap data secret,
+// reporting if the secret has been deleted before the infrastructure was provisioned.
+func (r *Reconciler) reconcileBootstrapDataSecret(ctx context.Context, m *clusterv1.Machine) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	found, err := r.bootstrapDataSecretExists(ctx, m)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !found {
+		log.Info("Bootstrap data secret not found, requeuing", "Secret", klog.KRef(m.Namespace, *m.Spec.Bootstrap.DataSecretName))
+		conditions.MarkFalse(m, clusterv1.BootstrapReadyCondition, clusterv1.BootstrapDataSecretNotFoundReason, clusterv1.ConditionSeverityWarning,
+			"Bootstrap data secret %s does not exist", *m.Spec.Bootstrap.DataSecretName)
+		return ctrl.Result{RequeueAfter: externalReadyWait}, nil
+	}
+
+	if !m.Status.BootstrapReady {
+		machineTimeline(m).BootstrapDataGeneratedAt = ptr.To(metav1.NewTime(r.now()))
+	}
+	m.Status.BootstrapReady = true
+	conditions.MarkTrue(m, clusterv1.BootstrapReadyCondition)
+	return ctrl.Result{}, nil
+}
+
+// bootstrapDataSecretExists checks if the bootstrap data secret of a Machine still exists.
+// The check is only performed until the infrastructure is provisioned, because afterwards the bootstrap data
+// is not required anymore; it can also be disabled with the MachineBootstrapDataSecretMissingPolicyAnnotation.
+func (r *Reconciler) bootstrapDataSecretExists(ctx context.Context, m *clusterv1.Machine) (bool, error) {
+	if m.Status.InfrastructureReady || !m.DeletionTimestamp.IsZero() {
+		return true, nil
+	}
+	if m.Annotations[clusterv1.MachineBootstrapDataSecretMissingPolicyAnnotation] == clusterv1.MachineBootstrapDataSecretMissingPolicyIgnore {
+		return true, nil
+	}
+
+	// Note: The default client of the manager does not use the cache for Secrets, so use a metadata-only
+	// live read to avoid fetching the bootstrap data on every reconcile.
+	key := client.ObjectKey{Namespace: m.Namespace, Name: *m.Spec.Bootstrap.DataSecretName}
+	secret := &metav1.PartialObjectMetadata{}
+	secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	if err := r.APIReader.Get(ctx, key, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get bootstrap data secret %s", klog.KRef(key.Namespace, key.Name))
+	}
+	return true, nil
+}
+
 // reconcileInfrastructure reconciles the Spec.InfrastructureRef object on a Machine.
 func (r *Reconciler) reconcileInfrastructure(ctx context.Context, s *scope) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
@@ -229,7 +290,7 @@ func (r *Reconciler) reconcileInfrastructure(ctx context.Context, s *scope) (ctr
 					m.Spec.InfrastructureRef.GroupVersionKind(), m.Spec.InfrastructureRef.Name))
 				return ctrl.Result{}, errors.Errorf("could not find %v %q for Machine %q in namespace %q", m.Spec.InfrastructureRef.GroupVersionKind().String(), m.Spec.InfrastructureRef.Name, m.Name, m.Namespace)
 			}
-			log.Info("Could not find infrastructure machine, requeuing", m.Spec.InfrastructureRef.Kind, klog.KRef(m.Spec.InfrastructureRef.Namespace, m.Spec.InfrastructureRef.Name))
+			log.Info("Could not find infrastructure machine, requeuing")
 			return ctrl.Result{RequeueAfter: externalReadyWait}, nil
 		}
 		return ctrl.Result{}, err
@@ -242,7 +303,7 @@ func (r *Reconciler) reconcileInfrastructure(ctx context.Context, s *scope) (ctr
 		return ctrl.Result{}, err
 	}
 	if ready && !m.Status.InfrastructureReady {
-		log.Info("Infrastructure provider has completed machine infrastructure provisioning and reports status.ready", s.infraMachine.GetKind(), klog.KObj(s.infraMachine))
+		log.Info("Infrastructure provider has completed machine infrastructure provisioning and reports status.ready")
 	}
 
 	// Report a summary of current status of the infrastructure object defined for this machine.
@@ -261,7 +322,7 @@ func (r *Reconciler) reconcileInfrastructure(ctx context.Context, s *scope) (ctr
 
 	// If the infrastructure provider is not ready (and it wasn't ready before), return early.
 	if !ready && !m.Status.InfrastructureReady {
-		log.Info("Waiting for infrastructure provider to create machine infrastructure and report status.ready", s.infraMachine.GetKind(), klog.KObj(s.infraMachine))
+		log.Info("Waiting for infrastructure provider to create machine infrastructure and report status.ready")
 		return ctrl.Result{}, nil
 	}
 
@@ -279,6 +340,12 @@ func (r *Reconciler) reconcileInfrastructure(ctx context.Context, s *scope) (ctr
 		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve addresses from infrastructure provider for Machine %q in namespace %q", m.Name, m.Namespace)
 	}
 
+	// Get and set Status.Image from the infrastructure provider.
+	err = util.UnstructuredUnmarshalField(s.infraMachine, &m.Status.Image, "status", "image")
+	if err != nil && err != util.ErrUnstructuredFieldNotFound {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve image from infrastructure provider for Machine %q in namespace %q", m.Name, m.Namespace)
+	}
+
 	// Get and set the failure domain from the infrastructure provider.
 	var failureDomain string
 	err = util.UnstructuredUnmarshalField(s.infraMachine, &failureDomain, "spec", "failureDomain")
@@ -294,6 +361,9 @@ func (r *Reconciler) reconcileInfrastructure(ctx context.Context, s *scope) (ctr
 	// - the infra machine is reporting ready for the first time
 	// - the infra machine already reported ready (and thus m.Status.InfrastructureReady is already true and it should not flip back)
 	m.Spec.ProviderID = ptr.To(providerID)
+	if !m.Status.InfrastructureReady {
+		machineTimeline(m).InfrastructureProvisionedAt = ptr.To(metav1.NewTime(r.now()))
+	}
 	m.Status.InfrastructureReady = true
 	return ctrl.Result{}, nil
 }
@@ -345,6 +415,7 @@ func (r *Reconciler) reconcileCertificateExpiry(_ context.Context, s *scope) (ct
 }
 
 // removeOnCreateOwnerRefs will remove any MachineSet or control plane owner references from passed objects.
+// Other owner references, e.g. added by backup operators or policy engines, are preserved.
 func removeOnCreateOwnerRefs(cluster *clusterv1.Cluster, m *clusterv1.Machine, obj *unstructured.Unstructured) error {
 	cpGVK := getControlPlaneGVKForMachine(cluster, m)
 	for _, owner := range obj.GetOwnerReferences() {
@@ -373,3 +444,11 @@ func getControlPlaneGVKForMachine(cluster *clusterv1.Cluster, machine *clusterv1
 	}
 	return nil
 }
+
+// machineTimeline returns the timeline of the Machine, initializing it if necessary.
+func machineTimeline(m *clusterv1.Machine) *clusterv1.MachineTimeline {
+	if m.Status.Timeline == nil {
+		m.Status.Timeline = &clusterv1.MachineTimeline{}
+	}
+	return m.Status.Timeline
+}
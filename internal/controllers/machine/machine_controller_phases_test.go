@@ -36,6 +36,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	externalfake "sigs.k8s.io/cluster-api/controllers/external/fake"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/test/builder"
 )
 
@@ -87,6 +88,52 @@ func TestReconcileBootstrap(t *testing.T) {
 			expectResult:            ctrl.Result{},
 			expectError:             false,
 		},
+		{
+			name: "bootstrap config ref is not set and the data secret is provided by the user, it should set bootstrap ready",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bootstrap-test-user-provided-secret",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To("secret-data"),
+					},
+				},
+			},
+			bootstrapConfig:         nil,
+			bootstrapConfigGetError: nil,
+			expectResult:            ctrl.Result{},
+			expectError:             false,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Status.BootstrapReady).To(BeTrue())
+				g.Expect(conditions.IsTrue(m, clusterv1.BootstrapReadyCondition)).To(BeTrue())
+			},
+		},
+		{
+			name: "bootstrap config ref is not set and the data secret provided by the user does not exist, it should requeue",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bootstrap-test-user-provided-secret-missing",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To("secret-data-missing"),
+					},
+				},
+			},
+			bootstrapConfig:         nil,
+			bootstrapConfigGetError: nil,
+			expectResult:            ctrl.Result{RequeueAfter: externalReadyWait},
+			expectError:             false,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Status.BootstrapReady).To(BeFalse())
+				g.Expect(m.Status.Timeline).To(BeNil())
+				g.Expect(conditions.IsFalse(m, clusterv1.BootstrapReadyCondition)).To(BeTrue())
+				g.Expect(conditions.GetReason(m, clusterv1.BootstrapReadyCondition)).To(Equal(clusterv1.BootstrapDataSecretNotFoundReason))
+			},
+		},
 		{
 			name:                    "err reading bootstrap config (something different than not found), it should return error",
 			machine:                 defaultMachine.DeepCopy(),
@@ -153,6 +200,8 @@ func TestReconcileBootstrap(t *testing.T) {
 				g.Expect(m.Status.BootstrapReady).To(BeTrue())
 				g.Expect(m.Spec.Bootstrap.DataSecretName).NotTo(BeNil())
 				g.Expect(*m.Spec.Bootstrap.DataSecretName).To(Equal("secret-data"))
+				g.Expect(m.Status.Timeline).ToNot(BeNil())
+				g.Expect(m.Status.Timeline.BootstrapDataGeneratedAt).ToNot(BeNil())
 			},
 		},
 		{
@@ -246,6 +295,139 @@ func TestReconcileBootstrap(t *testing.T) {
 			expected: func(g *WithT, m *clusterv1.Machine) {
 				g.Expect(m.Status.BootstrapReady).To(BeTrue())
 				g.Expect(*m.Spec.Bootstrap.DataSecretName).To(Equal("secret-data"))
+				// The bootstrap data has been generated before, so the timeline must not be updated.
+				g.Expect(m.Status.Timeline).To(BeNil())
+			},
+		},
+		{
+			name: "bootstrap data secret deleted before the infrastructure is provisioned should be reported",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bootstrap-test-missing-secret",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &corev1.ObjectReference{
+							APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+							Kind:       "GenericBootstrapConfig",
+							Name:       "bootstrap-config1",
+						},
+						DataSecretName: ptr.To("secret-data-deleted"),
+					},
+				},
+				Status: clusterv1.MachineStatus{
+					BootstrapReady: true,
+				},
+			},
+			bootstrapConfig: map[string]interface{}{
+				"kind":       "GenericBootstrapConfig",
+				"apiVersion": "bootstrap.cluster.x-k8s.io/v1beta1",
+				"metadata": map[string]interface{}{
+					"name":      "bootstrap-config1",
+					"namespace": metav1.NamespaceDefault,
+				},
+				"spec": map[string]interface{}{},
+				"status": map[string]interface{}{
+					"ready":          true,
+					"dataSecretName": "secret-data-deleted",
+				},
+			},
+			bootstrapConfigGetError: nil,
+			expectResult:            ctrl.Result{RequeueAfter: externalReadyWait},
+			expectError:             false,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Status.BootstrapReady).To(BeTrue())
+				g.Expect(conditions.IsFalse(m, clusterv1.BootstrapReadyCondition)).To(BeTrue())
+				g.Expect(conditions.GetReason(m, clusterv1.BootstrapReadyCondition)).To(Equal(clusterv1.BootstrapDataSecretNotFoundReason))
+			},
+		},
+		{
+			name: "bootstrap data secret deleted is ignored if the policy annotation is set to Ignore",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bootstrap-test-missing-secret-ignored",
+					Namespace: metav1.NamespaceDefault,
+					Annotations: map[string]string{
+						clusterv1.MachineBootstrapDataSecretMissingPolicyAnnotation: clusterv1.MachineBootstrapDataSecretMissingPolicyIgnore,
+					},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &corev1.ObjectReference{
+							APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+							Kind:       "GenericBootstrapConfig",
+							Name:       "bootstrap-config1",
+						},
+						DataSecretName: ptr.To("secret-data-deleted"),
+					},
+				},
+				Status: clusterv1.MachineStatus{
+					BootstrapReady: true,
+				},
+			},
+			bootstrapConfig: map[string]interface{}{
+				"kind":       "GenericBootstrapConfig",
+				"apiVersion": "bootstrap.cluster.x-k8s.io/v1beta1",
+				"metadata": map[string]interface{}{
+					"name":      "bootstrap-config1",
+					"namespace": metav1.NamespaceDefault,
+				},
+				"spec": map[string]interface{}{},
+				"status": map[string]interface{}{
+					"ready":          true,
+					"dataSecretName": "secret-data-deleted",
+				},
+			},
+			bootstrapConfigGetError: nil,
+			expectResult:            ctrl.Result{},
+			expectError:             false,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Status.BootstrapReady).To(BeTrue())
+				g.Expect(conditions.IsTrue(m, clusterv1.BootstrapReadyCondition)).To(BeTrue())
+			},
+		},
+		{
+			name: "bootstrap data secret deleted is ignored after the infrastructure is provisioned",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bootstrap-test-missing-secret-provisioned",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &corev1.ObjectReference{
+							APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+							Kind:       "GenericBootstrapConfig",
+							Name:       "bootstrap-config1",
+						},
+						DataSecretName: ptr.To("secret-data-deleted"),
+					},
+				},
+				Status: clusterv1.MachineStatus{
+					BootstrapReady:      true,
+					InfrastructureReady: true,
+				},
+			},
+			bootstrapConfig: map[string]interface{}{
+				"kind":       "GenericBootstrapConfig",
+				"apiVersion": "bootstrap.cluster.x-k8s.io/v1beta1",
+				"metadata": map[string]interface{}{
+					"name":      "bootstrap-config1",
+					"namespace": metav1.NamespaceDefault,
+				},
+				"spec": map[string]interface{}{},
+				"status": map[string]interface{}{
+					"ready":          true,
+					"dataSecretName": "secret-data-deleted",
+				},
+			},
+			bootstrapConfigGetError: nil,
+			expectResult:            ctrl.Result{},
+			expectError:             false,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Status.BootstrapReady).To(BeTrue())
+				g.Expect(conditions.IsTrue(m, clusterv1.BootstrapReadyCondition)).To(BeTrue())
 			},
 		},
 		{
@@ -292,8 +474,14 @@ func TestReconcileBootstrap(t *testing.T) {
 				bootstrapConfig = &unstructured.Unstructured{Object: tc.bootstrapConfig}
 			}
 
+			dataSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "secret-data",
+					Namespace: metav1.NamespaceDefault,
+				},
+			}
 			c := fake.NewClientBuilder().
-				WithObjects(tc.machine).Build()
+				WithObjects(tc.machine, dataSecret).Build()
 
 			if tc.bootstrapConfigGetError == nil {
 				g.Expect(c.Create(ctx, builder.GenericBootstrapConfigCRD.DeepCopy())).To(Succeed())
@@ -304,7 +492,8 @@ func TestReconcileBootstrap(t *testing.T) {
 			}
 
 			r := &Reconciler{
-				Client: c,
+				Client:    c,
+				APIReader: c,
 				externalTracker: external.ObjectTracker{
 					Controller:      externalfake.Controller{},
 					Cache:           &informertest.FakeInformers{},
@@ -528,6 +717,7 @@ func TestReconcileInfrastructure(t *testing.T) {
 				},
 				"status": map[string]interface{}{
 					"ready": true,
+					"image": "fake-image",
 					"addresses": []interface{}{
 						map[string]interface{}{
 							"type":    "InternalIP",
@@ -548,6 +738,7 @@ func TestReconcileInfrastructure(t *testing.T) {
 				g.Expect(ptr.Deref(m.Spec.ProviderID, "")).To(Equal("test://id-1"))
 				g.Expect(ptr.Deref(m.Spec.FailureDomain, "")).To(Equal("foo"))
 				g.Expect(m.Status.Addresses).To(HaveLen(2))
+				g.Expect(m.Status.Image).To(Equal("fake-image"))
 			},
 		},
 		{
@@ -886,6 +1077,88 @@ func TestReconcileInfrastructure(t *testing.T) {
 	}
 }
 
+func TestRemoveOnCreateOwnerRefs(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef: &corev1.ObjectReference{
+				APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+				Kind:       "GenericControlPlane",
+				Name:       "test-cp",
+			},
+		},
+	}
+
+	machineSetOwner := metav1.OwnerReference{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "MachineSet",
+		Name:       "test-ms",
+		UID:        "ms-uid",
+	}
+	controlPlaneOwner := metav1.OwnerReference{
+		APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+		Kind:       "GenericControlPlane",
+		Name:       "test-cp",
+		UID:        "cp-uid",
+	}
+	otherOwner := metav1.OwnerReference{
+		APIVersion: "backup.example.com/v1",
+		Kind:       "Backup",
+		Name:       "nightly",
+		UID:        "backup-uid",
+	}
+	otherMachineSetOwner := metav1.OwnerReference{
+		APIVersion: "example.com/v1",
+		Kind:       "MachineSet",
+		Name:       "test-ms",
+		UID:        "other-ms-uid",
+	}
+
+	tests := []struct {
+		name    string
+		machine *clusterv1.Machine
+		owners  []metav1.OwnerReference
+		want    []metav1.OwnerReference
+	}{
+		{
+			name:    "should remove the MachineSet owner",
+			machine: &clusterv1.Machine{},
+			owners:  []metav1.OwnerReference{machineSetOwner, otherOwner},
+			want:    []metav1.OwnerReference{otherOwner},
+		},
+		{
+			name: "should remove the control plane owner of control plane Machines",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{clusterv1.MachineControlPlaneLabel: ""},
+				},
+			},
+			owners: []metav1.OwnerReference{controlPlaneOwner, otherOwner},
+			want:   []metav1.OwnerReference{otherOwner},
+		},
+		{
+			name:    "should preserve owner references added by other tooling",
+			machine: &clusterv1.Machine{},
+			owners:  []metav1.OwnerReference{controlPlaneOwner, otherOwner, otherMachineSetOwner},
+			want:    []metav1.OwnerReference{controlPlaneOwner, otherOwner, otherMachineSetOwner},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := &unstructured.Unstructured{}
+			obj.SetOwnerReferences(tt.owners)
+
+			g.Expect(removeOnCreateOwnerRefs(cluster, tt.machine, obj)).To(Succeed())
+			g.Expect(obj.GetOwnerReferences()).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestReconcileCertificateExpiry(t *testing.T) {
 	fakeTimeString := "2020-01-01T00:00:00Z"
 	fakeTime, _ := time.Parse(time.RFC3339, fakeTimeString)
@@ -33,6 +33,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/util/drift"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
 )
@@ -68,6 +69,8 @@ func (r *Reconciler) updateStatus(ctx context.Context, s *scope) {
 
 	// TODO: Set the uptodate condition for standalone pods
 
+	setVersionDriftCondition(ctx, s.machine)
+
 	setDeletingCondition(ctx, s.machine, s.reconcileDeleteExecuted, s.deletingReason, s.deletingMessage)
 
 	setReadyCondition(ctx, s.machine)
@@ -79,6 +82,16 @@ func (r *Reconciler) updateStatus(ctx context.Context, s *scope) {
 
 func setBootstrapReadyCondition(_ context.Context, machine *clusterv1.Machine, bootstrapConfig *unstructured.Unstructured, bootstrapConfigIsNotFound bool) {
 	if machine.Spec.Bootstrap.ConfigRef == nil {
+		// NOTE: the existence of the bootstrap data secret is checked by reconcileBootstrap.
+		if conditions.GetReason(machine, clusterv1.BootstrapReadyCondition) == clusterv1.BootstrapDataSecretNotFoundReason {
+			v1beta2conditions.Set(machine, metav1.Condition{
+				Type:    clusterv1.MachineBootstrapConfigReadyV1Beta2Condition,
+				Status:  metav1.ConditionFalse,
+				Reason:  clusterv1.MachineBootstrapDataSecretNotFoundV1Beta2Reason,
+				Message: fmt.Sprintf("Bootstrap data secret %s does not exist", ptr.Deref(machine.Spec.Bootstrap.DataSecretName, "")),
+			})
+			return
+		}
 		v1beta2conditions.Set(machine, metav1.Condition{
 			Type:   clusterv1.MachineBootstrapConfigReadyV1Beta2Condition,
 			Status: metav1.ConditionTrue,
@@ -486,6 +499,43 @@ func (c machineConditionCustomMergeStrategy) Merge(conditions []v1beta2condition
 	).Merge(conditions, conditionTypes)
 }
 
+func setVersionDriftCondition(_ context.Context, machine *clusterv1.Machine) {
+	if machine.Spec.Version == nil {
+		v1beta2conditions.Set(machine, metav1.Condition{
+			Type:   clusterv1.MachineVersionDriftV1Beta2Condition,
+			Status: metav1.ConditionFalse,
+			Reason: clusterv1.MachineNoVersionDriftV1Beta2Reason,
+		})
+		return
+	}
+
+	if machine.Status.NodeInfo == nil || machine.Status.NodeInfo.KubeletVersion == "" {
+		v1beta2conditions.Set(machine, metav1.Condition{
+			Type:    clusterv1.MachineVersionDriftV1Beta2Condition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  clusterv1.MachineVersionDriftNodeVersionUnknownV1Beta2Reason,
+			Message: "Waiting for a Node to report the kubelet version",
+		})
+		return
+	}
+
+	if drift.KubeletVersion(machine, machine.Status.NodeInfo) {
+		v1beta2conditions.Set(machine, metav1.Condition{
+			Type:    clusterv1.MachineVersionDriftV1Beta2Condition,
+			Status:  metav1.ConditionTrue,
+			Reason:  clusterv1.MachineVersionDriftV1Beta2Reason,
+			Message: fmt.Sprintf("Node is running kubelet %s, expected %s", machine.Status.NodeInfo.KubeletVersion, *machine.Spec.Version),
+		})
+		return
+	}
+
+	v1beta2conditions.Set(machine, metav1.Condition{
+		Type:   clusterv1.MachineVersionDriftV1Beta2Condition,
+		Status: metav1.ConditionFalse,
+		Reason: clusterv1.MachineNoVersionDriftV1Beta2Reason,
+	})
+}
+
 func setDeletingCondition(_ context.Context, machine *clusterv1.Machine, reconcileDeleteExecuted bool, deletingReason, deletingMessage string) {
 	if machine.DeletionTimestamp.IsZero() {
 		v1beta2conditions.Set(machine, metav1.Condition{
@@ -717,13 +767,14 @@ func setAvailableCondition(ctx context.Context, machine *clusterv1.Machine) {
 	})
 }
 
+// setMachinePhaseAndLastUpdated computes the phase of the Machine from its current status, so the phase
+// always reflects the current state of the Machine, e.g. it goes back to "provisioned" if the Node is gone,
+// or it is not "failed" anymore once the failure has been cleared.
 func setMachinePhaseAndLastUpdated(_ context.Context, m *clusterv1.Machine) {
 	originalPhase := m.Status.Phase
 
-	// Set the phase to "pending" if nil.
-	if m.Status.Phase == "" {
-		m.Status.SetTypedPhase(clusterv1.MachinePhasePending)
-	}
+	// Set the phase to "pending" by default.
+	m.Status.SetTypedPhase(clusterv1.MachinePhasePending)
 
 	// Set the phase to "provisioning" if bootstrap is ready and the infrastructure isn't.
 	if m.Status.BootstrapReady && !m.Status.InfrastructureReady {
@@ -31,6 +31,7 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
@@ -85,6 +86,24 @@ func TestSetBootstrapReadyCondition(t *testing.T) {
 				Reason: clusterv1.MachineBootstrapDataSecretProvidedV1Beta2Reason,
 			},
 		},
+		{
+			name: "boostrap data secret provided by user/operator does not exist",
+			machine: func() *clusterv1.Machine {
+				m := defaultMachine.DeepCopy()
+				m.Spec.Bootstrap.ConfigRef = nil
+				m.Spec.Bootstrap.DataSecretName = ptr.To("foo")
+				conditions.MarkFalse(m, clusterv1.BootstrapReadyCondition, clusterv1.BootstrapDataSecretNotFoundReason, clusterv1.ConditionSeverityWarning, "Bootstrap data secret foo does not exist")
+				return m
+			}(),
+			bootstrapConfig:           nil,
+			bootstrapConfigIsNotFound: false,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineBootstrapConfigReadyV1Beta2Condition,
+				Status:  metav1.ConditionFalse,
+				Reason:  clusterv1.MachineBootstrapDataSecretNotFoundV1Beta2Reason,
+				Message: "Bootstrap data secret foo does not exist",
+			},
+		},
 		{
 			name:    "mirror Ready condition from bootstrap config",
 			machine: defaultMachine.DeepCopy(),
@@ -1034,6 +1053,77 @@ func TestSetNodeHealthyAndReadyConditions(t *testing.T) {
 	}
 }
 
+func TestSetVersionDriftCondition(t *testing.T) {
+	testCases := []struct {
+		name            string
+		version         *string
+		nodeInfo        *corev1.NodeSystemInfo
+		expectCondition metav1.Condition
+	}{
+		{
+			name:     "machine without a version",
+			version:  nil,
+			nodeInfo: &corev1.NodeSystemInfo{KubeletVersion: "v1.31.0"},
+			expectCondition: metav1.Condition{
+				Type:   clusterv1.MachineVersionDriftV1Beta2Condition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.MachineNoVersionDriftV1Beta2Reason,
+			},
+		},
+		{
+			name:     "node not reporting the kubelet version yet",
+			version:  ptr.To("v1.31.0"),
+			nodeInfo: nil,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineVersionDriftV1Beta2Condition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.MachineVersionDriftNodeVersionUnknownV1Beta2Reason,
+				Message: "Waiting for a Node to report the kubelet version",
+			},
+		},
+		{
+			name:     "node running the declared version",
+			version:  ptr.To("v1.31.0"),
+			nodeInfo: &corev1.NodeSystemInfo{KubeletVersion: "v1.31.0+vendor.1"},
+			expectCondition: metav1.Condition{
+				Type:   clusterv1.MachineVersionDriftV1Beta2Condition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.MachineNoVersionDriftV1Beta2Reason,
+			},
+		},
+		{
+			name:     "node running a different version",
+			version:  ptr.To("v1.31.0"),
+			nodeInfo: &corev1.NodeSystemInfo{KubeletVersion: "v1.30.2"},
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineVersionDriftV1Beta2Condition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.MachineVersionDriftV1Beta2Reason,
+				Message: "Node is running kubelet v1.30.2, expected v1.31.0",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := &clusterv1.Machine{
+				Spec: clusterv1.MachineSpec{
+					Version: tc.version,
+				},
+				Status: clusterv1.MachineStatus{
+					NodeInfo: tc.nodeInfo,
+				},
+			}
+			setVersionDriftCondition(ctx, machine)
+
+			condition := v1beta2conditions.Get(machine, clusterv1.MachineVersionDriftV1Beta2Condition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(*condition).To(v1beta2conditions.MatchCondition(tc.expectCondition, v1beta2conditions.IgnoreLastTransitionTime(true)))
+		})
+	}
+}
+
 func TestDeletingCondition(t *testing.T) {
 	testCases := []struct {
 		name                    string
@@ -1730,6 +1820,101 @@ func TestAvailableCondition(t *testing.T) {
 	}
 }
 
+func TestSetMachinePhaseAndLastUpdated(t *testing.T) {
+	tests := []struct {
+		name          string
+		machine       *clusterv1.Machine
+		expectedPhase clusterv1.MachinePhase
+	}{
+		{
+			name:          "pending if bootstrap is not ready",
+			machine:       &clusterv1.Machine{},
+			expectedPhase: clusterv1.MachinePhasePending,
+		},
+		{
+			name: "provisioning if bootstrap is ready and infrastructure is not",
+			machine: &clusterv1.Machine{
+				Status: clusterv1.MachineStatus{BootstrapReady: true},
+			},
+			expectedPhase: clusterv1.MachinePhaseProvisioning,
+		},
+		{
+			name: "provisioned if the provider ID is set",
+			machine: &clusterv1.Machine{
+				Spec:   clusterv1.MachineSpec{ProviderID: ptr.To("test://id-1")},
+				Status: clusterv1.MachineStatus{BootstrapReady: true, InfrastructureReady: true},
+			},
+			expectedPhase: clusterv1.MachinePhaseProvisioned,
+		},
+		{
+			name: "running if the node ref is set and infrastructure is ready",
+			machine: &clusterv1.Machine{
+				Spec: clusterv1.MachineSpec{ProviderID: ptr.To("test://id-1")},
+				Status: clusterv1.MachineStatus{
+					BootstrapReady:      true,
+					InfrastructureReady: true,
+					NodeRef:             &corev1.ObjectReference{Name: "node-1"},
+				},
+			},
+			expectedPhase: clusterv1.MachinePhaseRunning,
+		},
+		{
+			name: "back to provisioned if the node ref is gone",
+			machine: &clusterv1.Machine{
+				Spec: clusterv1.MachineSpec{ProviderID: ptr.To("test://id-1")},
+				Status: clusterv1.MachineStatus{
+					Phase:               string(clusterv1.MachinePhaseRunning),
+					BootstrapReady:      true,
+					InfrastructureReady: true,
+				},
+			},
+			expectedPhase: clusterv1.MachinePhaseProvisioned,
+		},
+		{
+			name: "failed if the failure reason is set",
+			machine: &clusterv1.Machine{
+				Status: clusterv1.MachineStatus{
+					BootstrapReady: true,
+					FailureReason:  ptr.To(capierrors.InvalidConfigurationMachineError),
+				},
+			},
+			expectedPhase: clusterv1.MachinePhaseFailed,
+		},
+		{
+			name: "not failed anymore once the failure has been cleared",
+			machine: &clusterv1.Machine{
+				Status: clusterv1.MachineStatus{
+					Phase:          string(clusterv1.MachinePhaseFailed),
+					BootstrapReady: true,
+				},
+			},
+			expectedPhase: clusterv1.MachinePhaseProvisioning,
+		},
+		{
+			name: "deleting if the deletion timestamp is set",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: ptr.To(metav1.Now())},
+				Status: clusterv1.MachineStatus{
+					FailureReason: ptr.To(capierrors.InvalidConfigurationMachineError),
+				},
+			},
+			expectedPhase: clusterv1.MachinePhaseDeleting,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			originalPhase := tt.machine.Status.Phase
+			setMachinePhaseAndLastUpdated(ctx, tt.machine)
+			g.Expect(tt.machine.Status.GetTypedPhase()).To(Equal(tt.expectedPhase))
+			if originalPhase != string(tt.expectedPhase) {
+				g.Expect(tt.machine.Status.LastUpdated).ToNot(BeNil())
+			}
+		})
+	}
+}
+
 func TestReconcileMachinePhases(t *testing.T) {
 	var defaultKubeconfigSecret *corev1.Secret
 	defaultCluster := &clusterv1.Cluster{
@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/record"
 	utilfeature "k8s.io/component-base/featuregate/testing"
+	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
@@ -1315,6 +1316,85 @@ func TestRemoveMachineFinalizerAfterDeleteReconcile(t *testing.T) {
 	g.Expect(actual.ObjectMeta.Finalizers).To(Equal([]string{"test"}))
 }
 
+func TestReconcileDeleteInfrastructure(t *testing.T) {
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-machine",
+			Namespace: metav1.NamespaceDefault,
+			UID:       "test-machine-uid",
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "test-cluster",
+			InfrastructureRef: corev1.ObjectReference{
+				APIVersion: builder.InfrastructureGroupVersion.String(),
+				Kind:       builder.GenericInfrastructureMachineKind,
+				Name:       "infra-machine",
+			},
+		},
+	}
+	infraMachine := func(managedBy string, controlledByMachine bool) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(builder.InfrastructureGroupVersion.String())
+		obj.SetKind(builder.GenericInfrastructureMachineKind)
+		obj.SetNamespace(metav1.NamespaceDefault)
+		obj.SetName("infra-machine")
+		if managedBy != "" {
+			obj.SetAnnotations(map[string]string{clusterv1.ManagedByAnnotation: managedBy})
+		}
+		if controlledByMachine {
+			obj.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(machine, clusterv1.GroupVersion.WithKind("Machine"))})
+		}
+		return obj
+	}
+
+	tests := []struct {
+		name         string
+		infraMachine *unstructured.Unstructured
+		wantDeleted  bool
+		wantGone     bool
+	}{
+		{
+			name:         "deletes the InfraMachine",
+			infraMachine: infraMachine("", true),
+			wantDeleted:  false,
+			wantGone:     true,
+		},
+		{
+			name:         "does not delete an externally managed InfraMachine and waits for it to be released",
+			infraMachine: infraMachine("external-system", true),
+			wantDeleted:  false,
+			wantGone:     false,
+		},
+		{
+			name:         "completes once the external system released the InfraMachine",
+			infraMachine: infraMachine("external-system", false),
+			wantDeleted:  true,
+			wantGone:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithObjects(tt.infraMachine).Build()
+			r := &Reconciler{Client: c}
+			s := &scope{
+				machine:      machine.DeepCopy(),
+				infraMachine: tt.infraMachine,
+			}
+
+			deleted, err := r.reconcileDeleteInfrastructure(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(deleted).To(Equal(tt.wantDeleted))
+
+			got := &unstructured.Unstructured{}
+			got.SetGroupVersionKind(tt.infraMachine.GroupVersionKind())
+			err = c.Get(ctx, client.ObjectKeyFromObject(tt.infraMachine), got)
+			g.Expect(apierrors.IsNotFound(err)).To(Equal(tt.wantGone))
+		})
+	}
+}
+
 func TestIsNodeDrainedAllowed(t *testing.T) {
 	testCluster := &clusterv1.Cluster{
 		TypeMeta:   metav1.TypeMeta{Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String()},
@@ -1429,6 +1509,52 @@ func TestIsNodeDrainedAllowed(t *testing.T) {
 	}
 }
 
+func TestDeleteHooks(t *testing.T) {
+	g := NewWithT(t)
+
+	annotations := map[string]string{
+		clusterv1.PreDrainDeleteHookAnnotationPrefix + "/zz":     "",
+		clusterv1.PreDrainDeleteHookAnnotationPrefix + "/aa":     "",
+		clusterv1.PreTerminateDeleteHookAnnotationPrefix + "/mm": "",
+		"other-annotation": "",
+	}
+
+	g.Expect(deleteHooks(clusterv1.PreDrainDeleteHookAnnotationPrefix, annotations)).To(Equal([]string{
+		clusterv1.PreDrainDeleteHookAnnotationPrefix + "/aa",
+		clusterv1.PreDrainDeleteHookAnnotationPrefix + "/zz",
+	}))
+	g.Expect(deleteHooks(clusterv1.PreTerminateDeleteHookAnnotationPrefix, annotations)).To(Equal([]string{
+		clusterv1.PreTerminateDeleteHookAnnotationPrefix + "/mm",
+	}))
+	g.Expect(deleteHooks(clusterv1.PreDrainDeleteHookAnnotationPrefix, nil)).To(BeEmpty())
+}
+
+func TestNodeDrainTimeoutExceededWithFakeClock(t *testing.T) {
+	g := NewWithT(t)
+
+	fakeClock := clocktesting.NewFakePassiveClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	r := &Reconciler{clock: fakeClock}
+
+	machine := &clusterv1.Machine{
+		Spec: clusterv1.MachineSpec{
+			NodeDrainTimeout: &metav1.Duration{Duration: time.Minute},
+		},
+		Status: clusterv1.MachineStatus{
+			Deletion: &clusterv1.MachineDeletionStatus{
+				NodeDrainStartTime: &metav1.Time{Time: fakeClock.Now()},
+			},
+		},
+	}
+
+	g.Expect(r.nodeDrainTimeoutExceeded(machine)).To(BeFalse())
+
+	fakeClock.SetTime(fakeClock.Now().Add(59 * time.Second))
+	g.Expect(r.nodeDrainTimeoutExceeded(machine)).To(BeFalse())
+
+	fakeClock.SetTime(fakeClock.Now().Add(time.Second))
+	g.Expect(r.nodeDrainTimeoutExceeded(machine)).To(BeTrue())
+}
+
 func TestDrainNode(t *testing.T) {
 	g := NewWithT(t)
 
@@ -1452,7 +1578,9 @@ func TestDrainNode(t *testing.T) {
 		nodeName            string
 		node                *corev1.Node
 		pods                []*corev1.Pod
+		machineAnnotations  map[string]string
 		nodeDrainStartTime  *metav1.Time
+		wantExcludedFromLBs bool
 		wantCondition       *clusterv1.Condition
 		wantResult          ctrl.Result
 		wantErr             string
@@ -1574,6 +1702,44 @@ func TestDrainNode(t *testing.T) {
 			wantDeletingMessage: `Drain not completed yet (started at 2024-10-09T16:13:59Z):
 * Pod test-namespace/pod-2-delete-running-deployment-pod: deletionTimestamp set, but still not removed from the Node`,
 		},
+		{
+			name:     "Node does exist, should be excluded from load balancers and wait before evicting Pods",
+			nodeName: "node-1",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node-1",
+				},
+			},
+			machineAnnotations: map[string]string{
+				clusterv1.WaitForNodeDeregistrationAnnotation: "1m",
+			},
+			nodeDrainStartTime:  &metav1.Time{Time: nodeDrainStartTime},
+			wantExcludedFromLBs: true,
+			wantResult:          ctrl.Result{RequeueAfter: 50 * time.Second},
+			wantCondition: &clusterv1.Condition{
+				Type:     clusterv1.DrainingSucceededCondition,
+				Status:   corev1.ConditionFalse,
+				Severity: clusterv1.ConditionSeverityInfo,
+				Reason:   clusterv1.DrainingReason,
+				Message:  "Waiting 1m0s for the Node to be deregistered from load balancers before evicting Pods (started at 2024-10-09T16:13:59Z)",
+			},
+			wantDeletingReason:  clusterv1.MachineDeletingDrainingNodeV1Beta2Reason,
+			wantDeletingMessage: "Waiting 1m0s for the Node to be deregistered from load balancers before evicting Pods (started at 2024-10-09T16:13:59Z)",
+		},
+		{
+			name:     "Node does exist, should be excluded from load balancers and drained after waiting",
+			nodeName: "node-1",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node-1",
+				},
+			},
+			machineAnnotations: map[string]string{
+				clusterv1.WaitForNodeDeregistrationAnnotation: "5s",
+			},
+			nodeDrainStartTime:  &metav1.Time{Time: nodeDrainStartTime},
+			wantExcludedFromLBs: true,
+		},
 		{
 			name:     "Node does exist but is unreachable, no Pods have to be drained because they all have old deletionTimestamps",
 			nodeName: "node-1",
@@ -1616,6 +1782,7 @@ func TestDrainNode(t *testing.T) {
 
 			// Making a copy because drainNode will modify the Machine.
 			testMachine := testMachine.DeepCopy()
+			testMachine.Annotations = tt.machineAnnotations
 
 			var objs []client.Object
 			objs = append(objs, testCluster, testMachine)
@@ -1652,6 +1819,7 @@ func TestDrainNode(t *testing.T) {
 				Client:               c,
 				ClusterCache:         clustercache.NewFakeClusterCache(remoteClient, client.ObjectKeyFromObject(testCluster)),
 				reconcileDeleteCache: cache.New[cache.ReconcileEntry](),
+				clock:                clocktesting.NewFakePassiveClock(nodeDrainStartTime.Add(10 * time.Second)),
 			}
 
 			testMachine.Status.NodeRef = &corev1.ObjectReference{
@@ -1695,6 +1863,11 @@ func TestDrainNode(t *testing.T) {
 				gotNode := &corev1.Node{}
 				g.Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(tt.node), gotNode)).To(Succeed())
 				g.Expect(gotNode.Spec.Unschedulable).To(BeTrue())
+				if tt.wantExcludedFromLBs {
+					g.Expect(gotNode.Labels).To(HaveKey(corev1.LabelNodeExcludeBalancers))
+				} else {
+					g.Expect(gotNode.Labels).ToNot(HaveKey(corev1.LabelNodeExcludeBalancers))
+				}
 			}
 		})
 	}
@@ -2412,6 +2585,37 @@ func TestShouldWaitForNodeVolumes(t *testing.T) {
 	}
 }
 
+func TestShouldWaitForNodeVolumes_ClusterNotConnected(t *testing.T) {
+	g := NewWithT(t)
+
+	testCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "test-cluster"},
+	}
+	testMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "test-machine"},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Name: "test-node"},
+		},
+	}
+
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithObjects(testCluster).Build(),
+		// The ClusterCache has no connection for testCluster.
+		ClusterCache:         clustercache.NewFakeClusterCache(fake.NewClientBuilder().Build(), client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "other-cluster"}),
+		reconcileDeleteCache: cache.New[cache.ReconcileEntry](),
+	}
+	s := &scope{
+		cluster: testCluster,
+		machine: testMachine,
+	}
+
+	got, err := r.shouldWaitForNodeVolumes(ctx, s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+	g.Expect(s.deletingReason).To(Equal(clusterv1.MachineDeletingWaitingForVolumeDetachV1Beta2Reason))
+	g.Expect(s.deletingMessage).To(Equal("Requeuing wait for Node volumes to be detached because connection to the workload cluster is down"))
+}
+
 func nodeNameIndex(o client.Object) []string {
 	return []string{o.(*corev1.Pod).Spec.NodeName}
 }
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func init() {
+	// Register the metrics at the controller-runtime metrics registry.
+	ctrlmetrics.Registry.MustRegister(provisioningDuration)
+	ctrlmetrics.Registry.MustRegister(deletionDuration)
+}
+
+// Metrics subsystem used by the Machine controller.
+const machineSubsystem = "capi_machine"
+
+// Milestones of the Machine provisioning reported by the provisioningDuration metric.
+const (
+	bootstrapDataGeneratedMilestone    = "bootstrap_data_generated"
+	infrastructureProvisionedMilestone = "infrastructure_provisioned"
+	nodeJoinedMilestone                = "node_joined"
+)
+
+// durationBuckets covers the range from a few seconds to a few hours, which is what provisioning
+// and deleting a Machine usually takes.
+var durationBuckets = []float64{5, 15, 30, 60, 120, 180, 300, 600, 900, 1200, 1800, 2700, 3600, 7200, 14400}
+
+var (
+	// provisioningDuration reports the time from the creation of a Machine to the milestones of its provisioning,
+	// as recorded in status.timeline.
+	provisioningDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: machineSubsystem,
+		Name:      "provisioning_duration_seconds",
+		Help:      "Time from the creation of a Machine to a provisioning milestone in seconds, partitioned by milestone.",
+		Buckets:   durationBuckets,
+	}, []string{"milestone"})
+
+	// deletionDuration reports the time from the deletion of a Machine to the removal of its finalizer.
+	deletionDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Subsystem: machineSubsystem,
+		Name:      "deletion_duration_seconds",
+		Help:      "Time from the deletion timestamp of a Machine to the completion of its deletion in seconds.",
+		Buckets:   durationBuckets,
+	})
+)
+
+// machineObserver records the provisioning milestones and the deletion of a Machine in the metrics.
+type machineObserver struct {
+	provisioningDuration *prometheus.HistogramVec
+	deletionDuration     prometheus.Histogram
+
+	timeline     clusterv1.MachineTimeline
+	hasFinalizer bool
+}
+
+// newMachineObserver captures the provisioning milestones and the finalizer of a Machine before it is reconciled.
+func newMachineObserver(m *clusterv1.Machine) machineObserver {
+	o := machineObserver{
+		provisioningDuration: provisioningDuration,
+		deletionDuration:     deletionDuration,
+		hasFinalizer:         controllerutil.ContainsFinalizer(m, clusterv1.MachineFinalizer),
+	}
+	if m.Status.Timeline != nil {
+		o.timeline = *m.Status.Timeline
+	}
+	return o
+}
+
+// observe records the provisioning milestones reached and the deletion completed since the observer has been created.
+// NOTE: This must only be called after the Machine has been successfully patched, so each Machine is counted only once
+// per milestone even if patching fails and the milestone is reached again in the next reconcile.
+func (o machineObserver) observe(m *clusterv1.Machine, now time.Time) {
+	if timeline := m.Status.Timeline; timeline != nil && !m.CreationTimestamp.IsZero() {
+		o.observeMilestone(m.CreationTimestamp, o.timeline.BootstrapDataGeneratedAt, timeline.BootstrapDataGeneratedAt, bootstrapDataGeneratedMilestone)
+		o.observeMilestone(m.CreationTimestamp, o.timeline.InfrastructureProvisionedAt, timeline.InfrastructureProvisionedAt, infrastructureProvisionedMilestone)
+		o.observeMilestone(m.CreationTimestamp, o.timeline.NodeJoinedAt, timeline.NodeJoinedAt, nodeJoinedMilestone)
+	}
+
+	if o.hasFinalizer && !controllerutil.ContainsFinalizer(m, clusterv1.MachineFinalizer) && !m.DeletionTimestamp.IsZero() {
+		o.deletionDuration.Observe(now.Sub(m.DeletionTimestamp.Time).Seconds())
+	}
+}
+
+// observeMilestone records the time from the creation of a Machine to a milestone, if the milestone has been reached
+// since the observer has been created.
+func (o machineObserver) observeMilestone(creationTimestamp metav1.Time, before, after *metav1.Time, milestone string) {
+	if before != nil || after == nil {
+		return
+	}
+	o.provisioningDuration.WithLabelValues(milestone).Observe(after.Sub(creationTimestamp.Time).Seconds())
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestMachineObserver(t *testing.T) {
+	creationTimestamp := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	at := func(d time.Duration) *metav1.Time {
+		return ptr.To(metav1.NewTime(creationTimestamp.Add(d)))
+	}
+
+	noDeletion := `
+capi_machine_deletion_duration_seconds_bucket{le="600"} 0
+capi_machine_deletion_duration_seconds_bucket{le="+Inf"} 0
+capi_machine_deletion_duration_seconds_sum 0
+capi_machine_deletion_duration_seconds_count 0
+`
+
+	tests := []struct {
+		name                 string
+		before               *clusterv1.Machine
+		after                func(m *clusterv1.Machine)
+		expectedProvisioning string
+		expectedDeletion     string
+	}{
+		{
+			name: "records the milestones reached during reconcile",
+			before: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: creationTimestamp},
+			},
+			after: func(m *clusterv1.Machine) {
+				m.Status.Timeline = &clusterv1.MachineTimeline{
+					BootstrapDataGeneratedAt:    at(30 * time.Second),
+					InfrastructureProvisionedAt: at(2 * time.Minute),
+				}
+			},
+			expectedProvisioning: `
+capi_machine_provisioning_duration_seconds_bucket{milestone="bootstrap_data_generated",le="600"} 1
+capi_machine_provisioning_duration_seconds_bucket{milestone="bootstrap_data_generated",le="+Inf"} 1
+capi_machine_provisioning_duration_seconds_sum{milestone="bootstrap_data_generated"} 30
+capi_machine_provisioning_duration_seconds_count{milestone="bootstrap_data_generated"} 1
+capi_machine_provisioning_duration_seconds_bucket{milestone="infrastructure_provisioned",le="600"} 1
+capi_machine_provisioning_duration_seconds_bucket{milestone="infrastructure_provisioned",le="+Inf"} 1
+capi_machine_provisioning_duration_seconds_sum{milestone="infrastructure_provisioned"} 120
+capi_machine_provisioning_duration_seconds_count{milestone="infrastructure_provisioned"} 1
+`,
+			expectedDeletion: noDeletion,
+		},
+		{
+			name: "does not record milestones which have been reached before",
+			before: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: creationTimestamp},
+				Status: clusterv1.MachineStatus{
+					Timeline: &clusterv1.MachineTimeline{
+						BootstrapDataGeneratedAt:    at(30 * time.Second),
+						InfrastructureProvisionedAt: at(2 * time.Minute),
+					},
+				},
+			},
+			after: func(m *clusterv1.Machine) {
+				m.Status.Timeline.NodeJoinedAt = at(15 * time.Minute)
+			},
+			expectedProvisioning: `
+capi_machine_provisioning_duration_seconds_bucket{milestone="node_joined",le="600"} 0
+capi_machine_provisioning_duration_seconds_bucket{milestone="node_joined",le="+Inf"} 1
+capi_machine_provisioning_duration_seconds_sum{milestone="node_joined"} 900
+capi_machine_provisioning_duration_seconds_count{milestone="node_joined"} 1
+`,
+			expectedDeletion: noDeletion,
+		},
+		{
+			name: "records the deletion when the finalizer is removed",
+			before: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: creationTimestamp,
+					DeletionTimestamp: at(time.Hour),
+					Finalizers:        []string{clusterv1.MachineFinalizer},
+				},
+			},
+			after: func(m *clusterv1.Machine) {
+				m.Finalizers = nil
+			},
+			expectedDeletion: `
+capi_machine_deletion_duration_seconds_bucket{le="600"} 1
+capi_machine_deletion_duration_seconds_bucket{le="+Inf"} 1
+capi_machine_deletion_duration_seconds_sum 300
+capi_machine_deletion_duration_seconds_count 1
+`,
+		},
+		{
+			name: "does not record the deletion while the finalizer is still set",
+			before: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: creationTimestamp,
+					DeletionTimestamp: at(time.Hour),
+					Finalizers:        []string{clusterv1.MachineFinalizer},
+				},
+			},
+			after:            func(*clusterv1.Machine) {},
+			expectedDeletion: noDeletion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Use dedicated metrics with a single bucket to keep the expected output short and to not
+			// depend on the observations of other tests.
+			o := newMachineObserver(tt.before)
+			o.provisioningDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Subsystem: machineSubsystem,
+				Name:      "provisioning_duration_seconds",
+				Help:      "Test provisioning duration.",
+				Buckets:   []float64{600},
+			}, []string{"milestone"})
+			o.deletionDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+				Subsystem: machineSubsystem,
+				Name:      "deletion_duration_seconds",
+				Help:      "Test deletion duration.",
+				Buckets:   []float64{600},
+			})
+
+			m := tt.before.DeepCopy()
+			tt.after(m)
+			o.observe(m, creationTimestamp.Add(65*time.Minute))
+
+			g.Expect(compareHistogram(o.provisioningDuration, "capi_machine_provisioning_duration_seconds", "Test provisioning duration.", tt.expectedProvisioning)).To(Succeed())
+			g.Expect(compareHistogram(o.deletionDuration, "capi_machine_deletion_duration_seconds", "Test deletion duration.", tt.expectedDeletion)).To(Succeed())
+		})
+	}
+}
+
+// compareHistogram compares the samples collected from a histogram.
+func compareHistogram(c prometheus.Collector, name, help, expected string) error {
+	if expected == "" {
+		return testutil.CollectAndCompare(c, strings.NewReader(""), name)
+	}
+	return testutil.CollectAndCompare(c, strings.NewReader("# HELP "+name+" "+help+"\n# TYPE "+name+" histogram"+expected), name)
+}
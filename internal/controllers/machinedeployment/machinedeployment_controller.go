@@ -20,15 +20,18 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,6 +41,8 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/internal/util/hash"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -76,6 +81,10 @@ type Reconciler struct {
 
 	recorder record.EventRecorder
 	ssaCache ssa.Cache
+
+	// clock is used to evaluate time-based logic like the progress deadline and rolloutAfter.
+	// If not set, the real clock is used; tests can inject a fake clock to advance time deterministically.
+	clock clock.PassiveClock
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -116,6 +125,14 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	return nil
 }
 
+// now returns the current time according to the Reconciler's clock.
+func (r *Reconciler) now() time.Time {
+	if r.clock == nil {
+		return time.Now()
+	}
+	return r.clock.Now()
+}
+
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ctrl.Result, reterr error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -135,7 +152,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ct
 	ctx = ctrl.LoggerInto(ctx, log)
 
 	// Add finalizer first if not set to avoid the race condition between init and delete.
-	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, deployment, clusterv1.MachineDeploymentFinalizer); err != nil || finalizerAdded {
+	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, deployment, clusterv1.MachineDeploymentFinalizer, finalizers.WithEventRecorder(r.recorder)); err != nil || finalizerAdded {
 		return ctrl.Result{}, err
 	}
 
@@ -164,6 +181,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ct
 			reterr = kerrors.NewAggregate([]error{reterr, err})
 		}
 
+		// Requeue to check again the progress deadline of a rollout when it is reached, because it could
+		// be reached without any change to the MachineDeployment or to its MachineSets.
+		if s.progressDeadlineRequeueAfter > 0 && (retres.RequeueAfter == 0 || s.progressDeadlineRequeueAfter < retres.RequeueAfter) {
+			retres.RequeueAfter = s.progressDeadlineRequeueAfter
+		}
+
 		// Always attempt to patch the object and status after each reconciliation.
 		// Patch ObservedGeneration only if the reconciliation completed successfully
 		patchOpts := []patch.Option{}
@@ -195,7 +218,9 @@ type scope struct {
 	bootstrapTemplateExists                      bool
 	infrastructureTemplateNotFound               bool
 	infrastructureTemplateExists                 bool
+	infrastructureTemplate                       *unstructured.Unstructured
 	getAndAdoptMachineSetsForDeploymentSucceeded bool
+	progressDeadlineRequeueAfter                 time.Duration
 }
 
 func patchMachineDeployment(ctx context.Context, patchHelper *patch.Helper, md *clusterv1.MachineDeployment, options ...patch.Option) error {
@@ -216,6 +241,9 @@ func patchMachineDeployment(ctx context.Context, patchHelper *patch.Helper, md *
 			clusterv1.MachineDeploymentAvailableV1Beta2Condition,
 			clusterv1.MachineDeploymentMachinesReadyV1Beta2Condition,
 			clusterv1.MachineDeploymentMachinesUpToDateV1Beta2Condition,
+			clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Condition,
+			clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition,
+			clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
 			clusterv1.MachineDeploymentScalingDownV1Beta2Condition,
 			clusterv1.MachineDeploymentScalingUpV1Beta2Condition,
 			clusterv1.MachineDeploymentRemediatingV1Beta2Condition,
@@ -282,6 +310,20 @@ func (r *Reconciler) reconcile(ctx context.Context, s *scope) error {
 		}
 	}
 
+	// Ensure all controlled MachineSets are identified by the machine-template-hash label.
+	// This logic is needed for adopted MachineSets which have not been created by a MachineDeployment,
+	// e.g. MachineSets created by hand.
+	for idx := range s.machineSets {
+		machineSet := s.machineSets[idx]
+		if _, ok := machineSet.Spec.Selector.MatchLabels[clusterv1.MachineDeploymentUniqueLabel]; ok {
+			continue
+		}
+
+		if err := r.addMachineSetUniqueLabel(ctx, md, machineSet); err != nil {
+			return errors.Wrapf(err, "failed to apply %s label to MachineSet %s", clusterv1.MachineDeploymentUniqueLabel, klog.KObj(machineSet))
+		}
+	}
+
 	// Loop over all MachineSets and cleanup managed fields.
 	// We do this so that MachineSets that were created/patched before (< v1.4.0) the controller adopted
 	// Server-Side-Apply (SSA) can also work with SSA. Otherwise, fields would be co-owned by our "old" "manager" and
@@ -407,10 +449,81 @@ func (r *Reconciler) getAndAdoptMachineSetsForDeployment(ctx context.Context, s
 func (r *Reconciler) adoptOrphan(ctx context.Context, deployment *clusterv1.MachineDeployment, machineSet *clusterv1.MachineSet) error {
 	patch := client.MergeFrom(machineSet.DeepCopy())
 	newRef := *metav1.NewControllerRef(deployment, machineDeploymentKind)
-	machineSet.SetOwnerReferences(util.EnsureOwnerRef(machineSet.GetOwnerReferences(), newRef))
+	machineSet.SetOwnerReferences(util.EnsureControllerRef(machineSet.GetOwnerReferences(), newRef))
 	return r.Client.Patch(ctx, machineSet, patch)
 }
 
+// addMachineSetUniqueLabel adds the machine-template-hash label to a MachineSet which doesn't have it, e.g. a MachineSet
+// created by hand and adopted by the MachineDeployment.
+// The label is added to the labels of the MachineSet first, so the same value is used if one of the following steps fails.
+// Then it is added to the Machines of the MachineSet and only afterwards to the selector and the template of the MachineSet,
+// so that the MachineSet doesn't lose its Machines. Its value is unique, because like for new MachineSets it contains
+// a random suffix.
+func (r *Reconciler) addMachineSetUniqueLabel(ctx context.Context, md *clusterv1.MachineDeployment, ms *clusterv1.MachineSet) error {
+	log := ctrl.LoggerFrom(ctx).WithValues("MachineSet", klog.KObj(ms))
+
+	uniqueIdentifierLabelValue, ok := ms.Labels[clusterv1.MachineDeploymentUniqueLabel]
+	if !ok {
+		templateHash, err := hash.Compute(mdutil.MachineTemplateDeepCopyRolloutFields(&ms.Spec.Template))
+		if err != nil {
+			return errors.Wrap(err, "failed to compute machine template hash")
+		}
+		_, randomSuffix := computeNewMachineSetName(md.Name + "-")
+		uniqueIdentifierLabelValue = fmt.Sprintf("%d-%s", templateHash, randomSuffix)
+
+		patchHelper, err := patch.NewHelper(ms, r.Client)
+		if err != nil {
+			return err
+		}
+		if ms.Labels == nil {
+			ms.Labels = map[string]string{}
+		}
+		ms.Labels[clusterv1.MachineDeploymentUniqueLabel] = uniqueIdentifierLabelValue
+		if err := patchHelper.Patch(ctx, ms); err != nil {
+			return err
+		}
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&ms.Spec.Selector)
+	if err != nil {
+		return errors.Wrap(err, "failed to get label selector from spec selector")
+	}
+	machines := &clusterv1.MachineList{}
+	if err := r.Client.List(ctx, machines, client.InNamespace(ms.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return errors.Wrap(err, "failed to list Machines")
+	}
+	for idx := range machines.Items {
+		machine := &machines.Items[idx]
+		if !metav1.IsControlledBy(machine, ms) || machine.Labels[clusterv1.MachineDeploymentUniqueLabel] == uniqueIdentifierLabelValue {
+			continue
+		}
+		patchHelper, err := patch.NewHelper(machine, r.Client)
+		if err != nil {
+			return err
+		}
+		if machine.Labels == nil {
+			machine.Labels = map[string]string{}
+		}
+		machine.Labels[clusterv1.MachineDeploymentUniqueLabel] = uniqueIdentifierLabelValue
+		if err := patchHelper.Patch(ctx, machine); err != nil {
+			return errors.Wrapf(err, "failed to apply label to Machine %s", klog.KObj(machine))
+		}
+	}
+
+	patchHelper, err := patch.NewHelper(ms, r.Client)
+	if err != nil {
+		return err
+	}
+	ms.Spec.Template.Labels = mdutil.CloneAndAddLabel(ms.Spec.Template.Labels, clusterv1.MachineDeploymentUniqueLabel, uniqueIdentifierLabelValue)
+	ms.Spec.Selector = *mdutil.CloneSelectorAndAddLabel(&ms.Spec.Selector, clusterv1.MachineDeploymentUniqueLabel, uniqueIdentifierLabelValue)
+	if err := patchHelper.Patch(ctx, ms); err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Added %s label to MachineSet", clusterv1.MachineDeploymentUniqueLabel), "value", uniqueIdentifierLabelValue)
+	return nil
+}
+
 // getMachineDeploymentsForMachineSet returns a list of MachineDeployments that could potentially match a MachineSet.
 func (r *Reconciler) getMachineDeploymentsForMachineSet(ctx context.Context, ms *clusterv1.MachineSet) []*clusterv1.MachineDeployment {
 	log := ctrl.LoggerFrom(ctx)
@@ -481,17 +594,19 @@ func (r *Reconciler) getTemplatesAndSetOwner(ctx context.Context, s *scope) erro
 	cluster := s.cluster
 
 	// Make sure to reconcile the external infrastructure reference.
-	if err := reconcileExternalTemplateReference(ctx, r.Client, cluster, &md.Spec.Template.Spec.InfrastructureRef); err != nil {
+	infrastructureTemplate, err := reconcileExternalTemplateReference(ctx, r.Client, cluster, &md.Spec.Template.Spec.InfrastructureRef)
+	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return err
 		}
 		s.infrastructureTemplateNotFound = true
 	} else {
 		s.infrastructureTemplateExists = true
+		s.infrastructureTemplate = infrastructureTemplate
 	}
 	// Make sure to reconcile the external bootstrap reference, if any.
 	if md.Spec.Template.Spec.Bootstrap.ConfigRef != nil {
-		if err := reconcileExternalTemplateReference(ctx, r.Client, cluster, md.Spec.Template.Spec.Bootstrap.ConfigRef); err != nil {
+		if _, err := reconcileExternalTemplateReference(ctx, r.Client, cluster, md.Spec.Template.Spec.Bootstrap.ConfigRef); err != nil {
 			if !apierrors.IsNotFound(err) {
 				return err
 			}
@@ -503,24 +618,24 @@ func (r *Reconciler) getTemplatesAndSetOwner(ctx context.Context, s *scope) erro
 	return nil
 }
 
-func reconcileExternalTemplateReference(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, ref *corev1.ObjectReference) error {
+func reconcileExternalTemplateReference(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, ref *corev1.ObjectReference) (*unstructured.Unstructured, error) {
 	if !strings.HasSuffix(ref.Kind, clusterv1.TemplateSuffix) {
-		return nil
+		return nil, nil
 	}
 
 	if err := utilconversion.UpdateReferenceAPIContract(ctx, c, ref); err != nil {
 		// We want to surface the NotFound error only for the referenced object, so we use a generic error in case CRD is not found.
-		return errors.New(err.Error())
+		return nil, errors.New(err.Error())
 	}
 
 	obj, err := external.Get(ctx, c, ref, cluster.Namespace)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	patchHelper, err := patch.NewHelper(obj, c)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	obj.SetOwnerReferences(util.EnsureOwnerRef(obj.GetOwnerReferences(), metav1.OwnerReference{
@@ -530,5 +645,8 @@ func reconcileExternalTemplateReference(ctx context.Context, c client.Client, cl
 		UID:        cluster.UID,
 	}))
 
-	return patchHelper.Patch(ctx, obj)
+	if err := patchHelper.Patch(ctx, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
 }
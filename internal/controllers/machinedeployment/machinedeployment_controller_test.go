@@ -983,6 +983,74 @@ func TestGetMachineSetsForDeployment(t *testing.T) {
 	}
 }
 
+func TestAddMachineSetUniqueLabel(t *testing.T) {
+	g := NewWithT(t)
+
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "md",
+			Namespace: metav1.NamespaceDefault,
+			UID:       "md-uid",
+		},
+	}
+	ms := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ms",
+			Namespace: metav1.NamespaceDefault,
+			UID:       "ms-uid",
+			Labels:    map[string]string{"foo": "bar"},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(md, machineDeploymentKind),
+			},
+		},
+		Spec: clusterv1.MachineSetSpec{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+			Template: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{Labels: map[string]string{"foo": "bar"}},
+			},
+		},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"foo": "bar"},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(ms, clusterv1.GroupVersion.WithKind("MachineSet")),
+			},
+		},
+	}
+	otherMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-machine",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"foo": "bar"},
+		},
+	}
+
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithObjects(ms, machine, otherMachine).Build(),
+	}
+	g.Expect(r.addMachineSetUniqueLabel(ctx, md, ms)).To(Succeed())
+
+	g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(ms), ms)).To(Succeed())
+	g.Expect(ms.Labels).To(HaveKey(clusterv1.MachineDeploymentUniqueLabel))
+	value := ms.Labels[clusterv1.MachineDeploymentUniqueLabel]
+	g.Expect(ms.Spec.Selector.MatchLabels).To(HaveKeyWithValue(clusterv1.MachineDeploymentUniqueLabel, value))
+	g.Expect(ms.Spec.Template.Labels).To(HaveKeyWithValue(clusterv1.MachineDeploymentUniqueLabel, value))
+
+	// Only the Machines controlled by the MachineSet get the label.
+	g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+	g.Expect(machine.Labels).To(HaveKeyWithValue(clusterv1.MachineDeploymentUniqueLabel, value))
+	g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(otherMachine), otherMachine)).To(Succeed())
+	g.Expect(otherMachine.Labels).ToNot(HaveKey(clusterv1.MachineDeploymentUniqueLabel))
+
+	// The value already set on the MachineSet is re-used, e.g. if a previous attempt failed.
+	ms.Spec.Selector.MatchLabels = map[string]string{"foo": "bar"}
+	g.Expect(r.addMachineSetUniqueLabel(ctx, md, ms)).To(Succeed())
+	g.Expect(ms.Spec.Selector.MatchLabels).To(HaveKeyWithValue(clusterv1.MachineDeploymentUniqueLabel, value))
+}
+
 // We have this as standalone variant to be able to use it from the tests.
 func updateMachineDeployment(ctx context.Context, c client.Client, md *clusterv1.MachineDeployment, modify func(*clusterv1.MachineDeployment)) error {
 	mdObjectKey := util.ObjectKey(md)
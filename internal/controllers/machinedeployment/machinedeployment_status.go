@@ -19,17 +19,24 @@ package machinedeployment
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/internal/util/clockskew"
+	"sigs.k8s.io/cluster-api/internal/util/drift"
 	"sigs.k8s.io/cluster-api/util/collections"
 	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
 	clog "sigs.k8s.io/cluster-api/util/log"
@@ -60,11 +67,19 @@ func (r *Reconciler) updateStatus(ctx context.Context, s *scope) (retErr error)
 
 	setAvailableCondition(ctx, s.machineDeployment, s.getAndAdoptMachineSetsForDeploymentSucceeded)
 
+	wasRollingOut := v1beta2conditions.IsTrue(s.machineDeployment, clusterv1.MachineDeploymentRollingOutV1Beta2Condition)
+	wasProgressDeadlineExceeded := v1beta2conditions.GetReason(s.machineDeployment, clusterv1.MachineDeploymentRollingOutV1Beta2Condition) == clusterv1.MachineDeploymentRollingOutProgressDeadlineExceededV1Beta2Reason
+	setRollingOutCondition(ctx, s.machineDeployment, s.machineSets, s.getAndAdoptMachineSetsForDeploymentSucceeded)
+	s.progressDeadlineRequeueAfter = setRollingOutProgressDeadlineExceeded(ctx, s.machineDeployment, machines, getMachinesSucceeded, r.now())
+	r.recordRolloutEvents(s.machineDeployment, wasRollingOut, wasProgressDeadlineExceeded)
+
 	setScalingUpCondition(ctx, s.machineDeployment, s.machineSets, s.bootstrapTemplateNotFound, s.infrastructureTemplateNotFound, s.getAndAdoptMachineSetsForDeploymentSucceeded)
 	setScalingDownCondition(ctx, s.machineDeployment, s.machineSets, machines, s.getAndAdoptMachineSetsForDeploymentSucceeded, getMachinesSucceeded)
 
 	setMachinesReadyCondition(ctx, s.machineDeployment, machines, getMachinesSucceeded)
 	setMachinesUpToDateCondition(ctx, s.machineDeployment, machines, getMachinesSucceeded)
+	setNodeVersionsUpToDateCondition(ctx, s.machineDeployment, machines, getMachinesSucceeded)
+	setImagesUpToDateCondition(ctx, s.machineDeployment, machines, getTemplateImage(s.infrastructureTemplate), getMachinesSucceeded)
 
 	setRemediatingCondition(ctx, s.machineDeployment, machinesToBeRemediated, unhealthyMachines, getMachinesSucceeded)
 
@@ -73,6 +88,39 @@ func (r *Reconciler) updateStatus(ctx context.Context, s *scope) (retErr error)
 	return retErr
 }
 
+// getTemplateImage returns the image declared by the InfrastructureMachineTemplate in its status.image field, if any.
+func getTemplateImage(infrastructureTemplate *unstructured.Unstructured) string {
+	if infrastructureTemplate == nil {
+		return ""
+	}
+	image, err := contract.InfrastructureMachineTemplate().Image().Get(infrastructureTemplate)
+	if err != nil {
+		return ""
+	}
+	return *image
+}
+
+// recordRolloutEvents emits an event when a rollout of the MachineDeployment starts or completes,
+// i.e. when the RollingOut condition transitions from or to False, and when a rollout exceeds its progress deadline.
+func (r *Reconciler) recordRolloutEvents(machineDeployment *clusterv1.MachineDeployment, wasRollingOut, wasProgressDeadlineExceeded bool) {
+	rollingOutCondition := v1beta2conditions.Get(machineDeployment, clusterv1.MachineDeploymentRollingOutV1Beta2Condition)
+	if rollingOutCondition == nil {
+		return
+	}
+
+	switch {
+	case !wasRollingOut && rollingOutCondition.Status == metav1.ConditionTrue:
+		r.recorder.Eventf(machineDeployment, corev1.EventTypeNormal, "RolloutStarted", "Rollout started: %s", rollingOutCondition.Message)
+	case wasRollingOut && rollingOutCondition.Status == metav1.ConditionFalse:
+		r.recorder.Event(machineDeployment, corev1.EventTypeNormal, "RolloutCompleted", "Rollout completed")
+	}
+
+	if !wasProgressDeadlineExceeded && rollingOutCondition.Reason == clusterv1.MachineDeploymentRollingOutProgressDeadlineExceededV1Beta2Reason {
+		r.recorder.Event(machineDeployment, corev1.EventTypeWarning, clusterv1.MachineDeploymentRollingOutProgressDeadlineExceededV1Beta2Reason, rollingOutCondition.Message)
+		rolloutProgressDeadlineExceededTotal.Inc()
+	}
+}
+
 // setReplicas sets replicas in the v1beta2 status.
 // Note: this controller computes replicas several time during a reconcile, because those counters are
 // used by low level operations to take decisions, but also those decisions might impact the very same the counters
@@ -145,6 +193,114 @@ func setAvailableCondition(_ context.Context, machineDeployment *clusterv1.Machi
 	})
 }
 
+func setRollingOutCondition(ctx context.Context, machineDeployment *clusterv1.MachineDeployment, machineSets []*clusterv1.MachineSet, getAndAdoptMachineSetsForDeploymentSucceeded bool) {
+	// If we got unexpected errors in listing the machine sets (this should never happen), surface them.
+	if !getAndAdoptMachineSetsForDeploymentSucceeded {
+		v1beta2conditions.Set(machineDeployment, metav1.Condition{
+			Type:    clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  clusterv1.MachineDeploymentRollingOutInternalErrorV1Beta2Reason,
+			Message: "Please check controller logs for errors",
+		})
+		return
+	}
+
+	// Note: FindOldMachineSets sorts the list it gets, so pass a copy to not change the order of machineSets.
+	reconciliationTime := metav1.Now()
+	oldMachineSets, err := mdutil.FindOldMachineSets(machineDeployment, slices.Clone(machineSets), &reconciliationTime)
+	if err != nil {
+		log := ctrl.LoggerFrom(ctx)
+		log.Error(err, "Failed to find old MachineSets")
+		v1beta2conditions.Set(machineDeployment, metav1.Condition{
+			Type:    clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  clusterv1.MachineDeploymentRollingOutInternalErrorV1Beta2Reason,
+			Message: "Please check controller logs for errors",
+		})
+		return
+	}
+
+	var oldReplicas int32
+	oldMachineSetNames := []string{}
+	for _, ms := range oldMachineSets {
+		replicas := max(ptr.Deref(ms.Spec.Replicas, 0), ms.Status.Replicas)
+		if replicas == 0 {
+			continue
+		}
+		oldReplicas += replicas
+		oldMachineSetNames = append(oldMachineSetNames, ms.Name)
+	}
+
+	if oldReplicas == 0 {
+		v1beta2conditions.Set(machineDeployment, metav1.Condition{
+			Type:   clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+			Status: metav1.ConditionFalse,
+			Reason: clusterv1.MachineDeploymentNotRollingOutV1Beta2Reason,
+		})
+		return
+	}
+
+	sort.Strings(oldMachineSetNames)
+	machineSetsLabel := "MachineSets"
+	if len(oldMachineSetNames) == 1 {
+		machineSetsLabel = "MachineSet"
+	}
+	replicasLabel := "replicas"
+	if oldReplicas == 1 {
+		replicasLabel = "replica"
+	}
+	v1beta2conditions.Set(machineDeployment, metav1.Condition{
+		Type:   clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1.MachineDeploymentRollingOutV1Beta2Reason,
+		Message: fmt.Sprintf("Rolling out %d %s from old %s %s", oldReplicas, replicasLabel, machineSetsLabel,
+			clog.ListToString(oldMachineSetNames, func(s string) string { return s }, 3)),
+	})
+}
+
+// setRollingOutProgressDeadlineExceeded sets the ProgressDeadlineExceeded reason on the RollingOut condition if the
+// rollout did not make progress for more than spec.progressDeadlineSeconds, i.e. if neither the rollout started
+// nor a Machine has been created, deleted or became available since then.
+// It returns after how long the progress deadline of a rollout which is still making progress will be reached, if any.
+// NOTE: Progress is not estimated while the MachineDeployment is paused.
+func setRollingOutProgressDeadlineExceeded(_ context.Context, machineDeployment *clusterv1.MachineDeployment, machines collections.Machines, getMachinesSucceeded bool, now time.Time) time.Duration {
+	rollingOutCondition := v1beta2conditions.Get(machineDeployment, clusterv1.MachineDeploymentRollingOutV1Beta2Condition)
+	if rollingOutCondition == nil || rollingOutCondition.Status != metav1.ConditionTrue {
+		return 0
+	}
+	if machineDeployment.Spec.Paused || machineDeployment.Spec.ProgressDeadlineSeconds == nil || !getMachinesSucceeded {
+		return 0
+	}
+
+	lastProgress := rollingOutCondition.LastTransitionTime.Time
+	for _, m := range machines {
+		if m.CreationTimestamp.After(lastProgress) {
+			lastProgress = m.CreationTimestamp.Time
+		}
+		if m.DeletionTimestamp != nil && m.DeletionTimestamp.After(lastProgress) {
+			lastProgress = m.DeletionTimestamp.Time
+		}
+		if available := v1beta2conditions.Get(m, clusterv1.MachineAvailableV1Beta2Condition); available != nil &&
+			available.Status == metav1.ConditionTrue && available.LastTransitionTime.After(lastProgress) {
+			lastProgress = available.LastTransitionTime.Time
+		}
+	}
+
+	progressDeadline := time.Duration(*machineDeployment.Spec.ProgressDeadlineSeconds) * time.Second
+	if remaining := clockskew.Remaining(lastProgress, progressDeadline, now); remaining > 0 {
+		return remaining
+	}
+
+	// NOTE: The message must not depend on the current time, otherwise each reconcile would change the condition.
+	v1beta2conditions.Set(machineDeployment, metav1.Condition{
+		Type:    clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+		Status:  metav1.ConditionTrue,
+		Reason:  clusterv1.MachineDeploymentRollingOutProgressDeadlineExceededV1Beta2Reason,
+		Message: fmt.Sprintf("%s, no progress for more than %s", rollingOutCondition.Message, progressDeadline),
+	})
+	return 0
+}
+
 func setScalingUpCondition(_ context.Context, machineDeployment *clusterv1.MachineDeployment, machineSets []*clusterv1.MachineSet, bootstrapObjectNotFound, infrastructureObjectNotFound, getAndAdoptMachineSetsForDeploymentSucceeded bool) {
 	// If we got unexpected errors in listing the machine sets (this should never happen), surface them.
 	if !getAndAdoptMachineSetsForDeploymentSucceeded {
@@ -233,6 +389,9 @@ func setScalingDownCondition(_ context.Context, machineDeployment *clusterv1.Mac
 	// Scaling down.
 	if currentReplicas > desiredReplicas {
 		message := fmt.Sprintf("Scaling down from %d to %d replicas", currentReplicas, desiredReplicas)
+		if blockedMessage := scaleDownBlockedByNewMachineSet(machineDeployment, machineSets); blockedMessage != "" {
+			message += fmt.Sprintf(" is blocked by %s", blockedMessage)
+		}
 		if getMachinesSucceeded {
 			staleMessage := aggregateStaleMachines(machines)
 			if staleMessage != "" {
@@ -256,6 +415,53 @@ func setScalingDownCondition(_ context.Context, machineDeployment *clusterv1.Mac
 	})
 }
 
+// scaleDownBlockedByNewMachineSet returns a message if the rolling update cannot scale down old MachineSets
+// because Machines of the new MachineSet are not available yet, e.g. because they are failing to provision.
+// NOTE: This mirrors the maxScaledDown computation in reconcileOldMachineSets; the MachineSet with the
+// highest revision is considered the new MachineSet.
+func scaleDownBlockedByNewMachineSet(machineDeployment *clusterv1.MachineDeployment, machineSets []*clusterv1.MachineSet) string {
+	if machineDeployment.Spec.Strategy == nil || !mdutil.IsRollingUpdate(machineDeployment) || machineDeployment.Spec.Strategy.RollingUpdate == nil {
+		return ""
+	}
+
+	var newMS *clusterv1.MachineSet
+	var newRevision int64
+	for _, ms := range machineSets {
+		revision, err := mdutil.Revision(ms)
+		if err != nil {
+			continue
+		}
+		if newMS == nil || revision > newRevision {
+			newMS, newRevision = ms, revision
+		}
+	}
+	if newMS == nil || newMS.Spec.Replicas == nil {
+		return ""
+	}
+
+	oldMachinesCount := int32(0)
+	for _, ms := range machineSets {
+		if ms != newMS && ms.Spec.Replicas != nil {
+			oldMachinesCount += *ms.Spec.Replicas
+		}
+	}
+	newMSUnavailableMachineCount := *newMS.Spec.Replicas - newMS.Status.AvailableReplicas
+	if oldMachinesCount == 0 || newMSUnavailableMachineCount <= 0 {
+		return ""
+	}
+
+	minAvailable := *machineDeployment.Spec.Replicas - mdutil.MaxUnavailable(*machineDeployment)
+	maxScaledDown := mdutil.GetReplicaCountForMachineSets(machineSets) - minAvailable - newMSUnavailableMachineCount
+	if maxScaledDown > 0 {
+		return ""
+	}
+
+	if newMSUnavailableMachineCount == 1 {
+		return fmt.Sprintf("1 Machine of the new MachineSet %s not being available", newMS.Name)
+	}
+	return fmt.Sprintf("%d Machines of the new MachineSet %s not being available", newMSUnavailableMachineCount, newMS.Name)
+}
+
 func setMachinesReadyCondition(ctx context.Context, machineDeployment *clusterv1.MachineDeployment, machines collections.Machines, getMachinesSucceeded bool) {
 	log := ctrl.LoggerFrom(ctx)
 	// If we got unexpected errors in listing the machines (this should never happen), surface them.
@@ -356,6 +562,92 @@ func setMachinesUpToDateCondition(ctx context.Context, machineDeployment *cluste
 	v1beta2conditions.Set(machineDeployment, *upToDateCondition)
 }
 
+func setNodeVersionsUpToDateCondition(_ context.Context, machineDeployment *clusterv1.MachineDeployment, machines collections.Machines, getMachinesSucceeded bool) {
+	// If we got unexpected errors in listing the machines (this should never happen), surface them.
+	if !getMachinesSucceeded {
+		v1beta2conditions.Set(machineDeployment, metav1.Condition{
+			Type:    clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Condition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  clusterv1.MachineDeploymentNodeVersionsUpToDateInternalErrorV1Beta2Reason,
+			Message: "Please check controller logs for errors",
+		})
+		return
+	}
+
+	if len(machines) == 0 {
+		v1beta2conditions.Set(machineDeployment, metav1.Condition{
+			Type:   clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Condition,
+			Status: metav1.ConditionTrue,
+			Reason: clusterv1.MachineDeploymentNodeVersionsUpToDateNoReplicasV1Beta2Reason,
+		})
+		return
+	}
+
+	if message := aggregateMachinesWithNodeVersionDrift(machines); message != "" {
+		v1beta2conditions.Set(machineDeployment, metav1.Condition{
+			Type:    clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Condition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.MachineDeploymentNodeVersionsNotUpToDateV1Beta2Reason,
+			Message: message,
+		})
+		return
+	}
+
+	v1beta2conditions.Set(machineDeployment, metav1.Condition{
+		Type:   clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Condition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Reason,
+	})
+}
+
+func setImagesUpToDateCondition(_ context.Context, machineDeployment *clusterv1.MachineDeployment, machines collections.Machines, templateImage string, getMachinesSucceeded bool) {
+	// If we got unexpected errors in listing the machines (this should never happen), surface them.
+	if !getMachinesSucceeded {
+		v1beta2conditions.Set(machineDeployment, metav1.Condition{
+			Type:    clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  clusterv1.MachineDeploymentImagesUpToDateInternalErrorV1Beta2Reason,
+			Message: "Please check controller logs for errors",
+		})
+		return
+	}
+
+	if len(machines) == 0 {
+		v1beta2conditions.Set(machineDeployment, metav1.Condition{
+			Type:   clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition,
+			Status: metav1.ConditionTrue,
+			Reason: clusterv1.MachineDeploymentImagesUpToDateNoReplicasV1Beta2Reason,
+		})
+		return
+	}
+
+	if templateImage == "" {
+		v1beta2conditions.Set(machineDeployment, metav1.Condition{
+			Type:    clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  clusterv1.MachineDeploymentImagesUpToDateNotReportedV1Beta2Reason,
+			Message: fmt.Sprintf("%s does not report status.image", machineDeployment.Spec.Template.Spec.InfrastructureRef.Kind),
+		})
+		return
+	}
+
+	if message := aggregateMachinesWithImageDrift(machines, templateImage); message != "" {
+		v1beta2conditions.Set(machineDeployment, metav1.Condition{
+			Type:    clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.MachineDeploymentImagesNotUpToDateV1Beta2Reason,
+			Message: message,
+		})
+		return
+	}
+
+	v1beta2conditions.Set(machineDeployment, metav1.Condition{
+		Type:   clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1.MachineDeploymentImagesUpToDateV1Beta2Reason,
+	})
+}
+
 func setRemediatingCondition(ctx context.Context, machineDeployment *clusterv1.MachineDeployment, machinesToBeRemediated, unhealthyMachines collections.Machines, getMachinesSucceeded bool) {
 	if !getMachinesSucceeded {
 		v1beta2conditions.Set(machineDeployment, metav1.Condition{
@@ -504,6 +796,76 @@ func aggregateStaleMachines(machines collections.Machines) string {
 	return message
 }
 
+// aggregateMachinesWithNodeVersionDrift returns a message listing the Machines whose Node is running a kubelet
+// version different from the Machine's spec.version; only major.minor.patch are compared and Machines being deleted are ignored.
+func aggregateMachinesWithNodeVersionDrift(machines collections.Machines) string {
+	machineNames := []string{}
+	for _, machine := range machines {
+		if !machine.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if drift.KubeletVersion(machine, machine.Status.NodeInfo) {
+			machineNames = append(machineNames, machine.GetName())
+		}
+	}
+
+	if len(machineNames) == 0 {
+		return ""
+	}
+
+	message := "Node of Machine"
+	if len(machineNames) > 1 {
+		message = "Nodes of Machines"
+	}
+
+	sort.Strings(machineNames)
+	message += " " + clog.ListToString(machineNames, func(s string) string { return s }, 3)
+
+	if len(machineNames) == 1 {
+		message += " is "
+	} else {
+		message += " are "
+	}
+	message += "running a kubelet version different from spec.version"
+
+	return message
+}
+
+// aggregateMachinesWithImageDrift returns a message listing the Machines running an image different from the one
+// declared by the InfrastructureMachineTemplate; Machines being deleted are ignored.
+func aggregateMachinesWithImageDrift(machines collections.Machines, templateImage string) string {
+	machineNames := []string{}
+	for _, machine := range machines {
+		if !machine.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if drift.Image(machine, templateImage) {
+			machineNames = append(machineNames, machine.GetName())
+		}
+	}
+
+	if len(machineNames) == 0 {
+		return ""
+	}
+
+	message := "Machine"
+	if len(machineNames) > 1 {
+		message += "s"
+	}
+
+	sort.Strings(machineNames)
+	message += " " + clog.ListToString(machineNames, func(s string) string { return s }, 3)
+
+	if len(machineNames) == 1 {
+		message += " is "
+	} else {
+		message += " are "
+	}
+	message += fmt.Sprintf("running an image different from %s", templateImage)
+
+	return message
+}
+
 func aggregateUnhealthyMachines(machines collections.Machines) string {
 	if len(machines) == 0 {
 		return ""
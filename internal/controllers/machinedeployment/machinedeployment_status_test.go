@@ -17,16 +17,21 @@ limitations under the License.
 package machinedeployment
 
 import (
+	"slices"
+	"strconv"
 	"testing"
 	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/util/clockskew"
 	"sigs.k8s.io/cluster-api/util/collections"
 	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
 )
@@ -203,6 +208,342 @@ func Test_setAvailableCondition(t *testing.T) {
 	}
 }
 
+func Test_setRollingOutCondition(t *testing.T) {
+	machineDeployment := &clusterv1.MachineDeployment{
+		Spec: clusterv1.MachineDeploymentSpec{
+			Replicas: ptr.To[int32](3),
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Version: ptr.To("v1.31.0"),
+					InfrastructureRef: corev1.ObjectReference{
+						Kind:      "DockerMachineTemplate",
+						Namespace: "some-namespace",
+						Name:      "some-name",
+					},
+				},
+			},
+		},
+	}
+	newTemplate := machineDeployment.Spec.Template
+	oldTemplate := *machineDeployment.Spec.Template.DeepCopy()
+	oldTemplate.Spec.Version = ptr.To("v1.30.0")
+
+	tests := []struct {
+		name                                         string
+		machineSets                                  []*clusterv1.MachineSet
+		getAndAdoptMachineSetsForDeploymentSucceeded bool
+		expectCondition                              metav1.Condition
+	}{
+		{
+			name:        "getAndAdoptMachineSetsForDeploymentSucceeded failed",
+			machineSets: nil,
+			getAndAdoptMachineSetsForDeploymentSucceeded: false,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.MachineDeploymentRollingOutInternalErrorV1Beta2Reason,
+				Message: "Please check controller logs for errors",
+			},
+		},
+		{
+			name: "all replicas on the new MachineSet, old MachineSets scaled down",
+			machineSets: []*clusterv1.MachineSet{
+				fakeMachineSet("ms1", withMachineTemplate(oldTemplate), withSpecReplicas(0)),
+				fakeMachineSet("ms2", withMachineTemplate(newTemplate), withSpecReplicas(3), withStatusReplicas(3)),
+			},
+			getAndAdoptMachineSetsForDeploymentSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:   clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.MachineDeploymentNotRollingOutV1Beta2Reason,
+			},
+		},
+		{
+			name: "replicas on an old MachineSet",
+			machineSets: []*clusterv1.MachineSet{
+				fakeMachineSet("ms1", withMachineTemplate(oldTemplate), withSpecReplicas(0), withStatusReplicas(1)),
+				fakeMachineSet("ms2", withMachineTemplate(newTemplate), withSpecReplicas(3), withStatusReplicas(2)),
+			},
+			getAndAdoptMachineSetsForDeploymentSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.MachineDeploymentRollingOutV1Beta2Reason,
+				Message: "Rolling out 1 replica from old MachineSet ms1",
+			},
+		},
+		{
+			name: "no MachineSet matching the template yet",
+			machineSets: []*clusterv1.MachineSet{
+				fakeMachineSet("ms2", withMachineTemplate(oldTemplate), withSpecReplicas(1), withStatusReplicas(1)),
+				fakeMachineSet("ms1", withMachineTemplate(oldTemplate), withSpecReplicas(2), withStatusReplicas(2)),
+			},
+			getAndAdoptMachineSetsForDeploymentSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.MachineDeploymentRollingOutV1Beta2Reason,
+				Message: "Rolling out 3 replicas from old MachineSets ms1, ms2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := machineDeployment.DeepCopy()
+			for _, ms := range tt.machineSets {
+				ms.UID = types.UID(ms.Name)
+			}
+			machineSets := slices.Clone(tt.machineSets)
+			setRollingOutCondition(ctx, md, tt.machineSets, tt.getAndAdoptMachineSetsForDeploymentSucceeded)
+			g.Expect(tt.machineSets).To(Equal(machineSets), "the order of MachineSets must not change")
+
+			condition := v1beta2conditions.Get(md, clusterv1.MachineDeploymentRollingOutV1Beta2Condition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(*condition).To(v1beta2conditions.MatchCondition(tt.expectCondition, v1beta2conditions.IgnoreLastTransitionTime(true)))
+		})
+	}
+}
+
+func Test_recordRolloutEvents(t *testing.T) {
+	tests := []struct {
+		name                        string
+		wasRollingOut               bool
+		wasProgressDeadlineExceeded bool
+		condition                   *metav1.Condition
+		expectedEvent               string
+		expectNoEvents              bool
+	}{
+		{
+			name:           "no event if the RollingOut condition is not set",
+			expectNoEvents: true,
+		},
+		{
+			name:          "rollout started",
+			wasRollingOut: false,
+			condition: &metav1.Condition{
+				Type:    clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.MachineDeploymentRollingOutV1Beta2Reason,
+				Message: "Rolling out 1 replica from old MachineSet ms1",
+			},
+			expectedEvent: "Normal RolloutStarted Rollout started: Rolling out 1 replica from old MachineSet ms1",
+		},
+		{
+			name:          "rollout in progress",
+			wasRollingOut: true,
+			condition: &metav1.Condition{
+				Type:   clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+				Status: metav1.ConditionTrue,
+				Reason: clusterv1.MachineDeploymentRollingOutV1Beta2Reason,
+			},
+			expectNoEvents: true,
+		},
+		{
+			name:          "rollout completed",
+			wasRollingOut: true,
+			condition: &metav1.Condition{
+				Type:   clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.MachineDeploymentNotRollingOutV1Beta2Reason,
+			},
+			expectedEvent: "Normal RolloutCompleted Rollout completed",
+		},
+		{
+			name:          "rollout exceeded its progress deadline",
+			wasRollingOut: true,
+			condition: &metav1.Condition{
+				Type:    clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.MachineDeploymentRollingOutProgressDeadlineExceededV1Beta2Reason,
+				Message: "Rolling out 1 replica from old MachineSet ms1, no progress for more than 10m0s",
+			},
+			expectedEvent: "Warning ProgressDeadlineExceeded Rolling out 1 replica from old MachineSet ms1, no progress for more than 10m0s",
+		},
+		{
+			name:                        "rollout still exceeding its progress deadline",
+			wasRollingOut:               true,
+			wasProgressDeadlineExceeded: true,
+			condition: &metav1.Condition{
+				Type:   clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+				Status: metav1.ConditionTrue,
+				Reason: clusterv1.MachineDeploymentRollingOutProgressDeadlineExceededV1Beta2Reason,
+			},
+			expectNoEvents: true,
+		},
+		{
+			name:          "no rollout",
+			wasRollingOut: false,
+			condition: &metav1.Condition{
+				Type:   clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.MachineDeploymentNotRollingOutV1Beta2Reason,
+			},
+			expectNoEvents: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := &clusterv1.MachineDeployment{}
+			if tt.condition != nil {
+				v1beta2conditions.Set(md, *tt.condition)
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{recorder: recorder}
+
+			r.recordRolloutEvents(md, tt.wasRollingOut, tt.wasProgressDeadlineExceeded)
+
+			if tt.expectNoEvents {
+				g.Expect(recorder.Events).To(BeEmpty())
+				return
+			}
+			g.Expect(recorder.Events).To(Receive(Equal(tt.expectedEvent)))
+		})
+	}
+}
+
+func Test_setRollingOutProgressDeadlineExceeded(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rolloutStart := metav1.NewTime(now.Add(-20 * time.Minute))
+
+	rollingOut := func(pds *int32, paused bool) *clusterv1.MachineDeployment {
+		md := &clusterv1.MachineDeployment{
+			Spec: clusterv1.MachineDeploymentSpec{
+				ProgressDeadlineSeconds: pds,
+				Paused:                  paused,
+			},
+		}
+		v1beta2conditions.Set(md, metav1.Condition{
+			Type:               clusterv1.MachineDeploymentRollingOutV1Beta2Condition,
+			Status:             metav1.ConditionTrue,
+			Reason:             clusterv1.MachineDeploymentRollingOutV1Beta2Reason,
+			Message:            "Rolling out 1 replica from old MachineSet ms1",
+			LastTransitionTime: rolloutStart,
+		})
+		return md
+	}
+	machine := func(name string, creationTimestamp time.Time, deletionTimestamp *time.Time, availableSince *time.Time) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(creationTimestamp),
+			},
+		}
+		if deletionTimestamp != nil {
+			m.DeletionTimestamp = ptr.To(metav1.NewTime(*deletionTimestamp))
+		}
+		if availableSince != nil {
+			v1beta2conditions.Set(m, metav1.Condition{
+				Type:               clusterv1.MachineAvailableV1Beta2Condition,
+				Status:             metav1.ConditionTrue,
+				Reason:             clusterv1.MachineAvailableV1Beta2Reason,
+				LastTransitionTime: metav1.NewTime(*availableSince),
+			})
+		}
+		return m
+	}
+
+	tests := []struct {
+		name                 string
+		machineDeployment    *clusterv1.MachineDeployment
+		machines             []*clusterv1.Machine
+		getMachinesSucceeded bool
+		expectExceeded       bool
+		expectRequeueAfter   time.Duration
+	}{
+		{
+			name:                 "no-op if not rolling out",
+			machineDeployment:    &clusterv1.MachineDeployment{Spec: clusterv1.MachineDeploymentSpec{ProgressDeadlineSeconds: ptr.To[int32](600)}},
+			getMachinesSucceeded: true,
+		},
+		{
+			name:                 "no-op if the progress deadline is not set",
+			machineDeployment:    rollingOut(nil, false),
+			getMachinesSucceeded: true,
+		},
+		{
+			name:                 "no-op if paused",
+			machineDeployment:    rollingOut(ptr.To[int32](600), true),
+			getMachinesSucceeded: true,
+		},
+		{
+			name:                 "no-op if failed to get machines",
+			machineDeployment:    rollingOut(ptr.To[int32](600), false),
+			getMachinesSucceeded: false,
+		},
+		{
+			name:                 "progress deadline exceeded since the start of the rollout",
+			machineDeployment:    rollingOut(ptr.To[int32](600), false),
+			machines:             []*clusterv1.Machine{machine("m1", now.Add(-time.Hour), nil, ptr.To(now.Add(-time.Hour)))},
+			getMachinesSucceeded: true,
+			expectExceeded:       true,
+		},
+		{
+			name:                 "progress deadline not exceeded if a Machine has been created recently",
+			machineDeployment:    rollingOut(ptr.To[int32](600), false),
+			machines:             []*clusterv1.Machine{machine("m1", now.Add(-2*time.Minute), nil, nil)},
+			getMachinesSucceeded: true,
+			expectRequeueAfter:   8*time.Minute + clockskew.Tolerance,
+		},
+		{
+			name:                 "progress deadline not exceeded if a Machine has been deleted recently",
+			machineDeployment:    rollingOut(ptr.To[int32](600), false),
+			machines:             []*clusterv1.Machine{machine("m1", now.Add(-time.Hour), ptr.To(now.Add(-3*time.Minute)), nil)},
+			getMachinesSucceeded: true,
+			expectRequeueAfter:   7*time.Minute + clockskew.Tolerance,
+		},
+		{
+			name:                 "progress deadline not exceeded if a Machine became available recently",
+			machineDeployment:    rollingOut(ptr.To[int32](600), false),
+			machines:             []*clusterv1.Machine{machine("m1", now.Add(-15*time.Minute), nil, ptr.To(now.Add(-4*time.Minute)))},
+			getMachinesSucceeded: true,
+			expectRequeueAfter:   6*time.Minute + clockskew.Tolerance,
+		},
+		{
+			name:                 "progress deadline not exceeded within the clock skew tolerance",
+			machineDeployment:    rollingOut(ptr.To[int32](600), false),
+			machines:             []*clusterv1.Machine{machine("m1", now.Add(-15*time.Minute), ptr.To(now.Add(-10*time.Minute-30*time.Second)), nil)},
+			getMachinesSucceeded: true,
+			expectRequeueAfter:   clockskew.Tolerance - 30*time.Second,
+		},
+		{
+			name:                 "progress deadline exceeded if Machines did not make progress recently",
+			machineDeployment:    rollingOut(ptr.To[int32](600), false),
+			machines:             []*clusterv1.Machine{machine("m1", now.Add(-15*time.Minute), ptr.To(now.Add(-12*time.Minute)), nil)},
+			getMachinesSucceeded: true,
+			expectExceeded:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var machines collections.Machines
+			if tt.machines != nil {
+				machines = collections.FromMachines(tt.machines...)
+			}
+			before := v1beta2conditions.Get(tt.machineDeployment, clusterv1.MachineDeploymentRollingOutV1Beta2Condition)
+
+			requeueAfter := setRollingOutProgressDeadlineExceeded(ctx, tt.machineDeployment, machines, tt.getMachinesSucceeded, now)
+			g.Expect(requeueAfter).To(Equal(tt.expectRequeueAfter))
+
+			condition := v1beta2conditions.Get(tt.machineDeployment, clusterv1.MachineDeploymentRollingOutV1Beta2Condition)
+			if !tt.expectExceeded {
+				g.Expect(condition).To(Equal(before))
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			g.Expect(condition.Reason).To(Equal(clusterv1.MachineDeploymentRollingOutProgressDeadlineExceededV1Beta2Reason))
+			g.Expect(condition.Message).To(Equal("Rolling out 1 replica from old MachineSet ms1, no progress for more than 10m0s"))
+			g.Expect(condition.LastTransitionTime).To(Equal(rolloutStart))
+		})
+	}
+}
+
 func Test_setScalingUpCondition(t *testing.T) {
 	defaultMachineDeployment := &clusterv1.MachineDeployment{
 		Spec: clusterv1.MachineDeploymentSpec{
@@ -545,6 +886,32 @@ func Test_setScalingDownCondition(t *testing.T) {
 				Message: "Scaling down from 4 to 1 replicas and Machines stale-machine-1, stale-machine-2, stale-machine-3 are in deletion since more than 30m",
 			},
 		},
+		{
+			name: "scaling down blocked by unavailable machines of the new MachineSet",
+			machineDeployment: func() *clusterv1.MachineDeployment {
+				md := defaultMachineDeployment.DeepCopy()
+				md.Spec.Replicas = ptr.To[int32](3)
+				md.Spec.Strategy = &clusterv1.MachineDeploymentStrategy{
+					Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+					RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+						MaxSurge:       ptr.To(intstr.FromInt32(1)),
+						MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+					},
+				}
+				return md
+			}(),
+			machineSets: []*clusterv1.MachineSet{
+				fakeMachineSet("ms1", withRevision(1), withSpecReplicas(3), withStatusReplicas(3), withStatusAvailableReplicas(3)),
+				fakeMachineSet("ms2", withRevision(2), withSpecReplicas(1), withStatusReplicas(1)),
+			},
+			getAndAdoptMachineSetsForDeploymentSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineDeploymentScalingDownV1Beta2Condition,
+				Status:  metav1.ConditionTrue,
+				Reason:  clusterv1.MachineDeploymentScalingDownV1Beta2Reason,
+				Message: "Scaling down from 4 to 3 replicas is blocked by 1 Machine of the new MachineSet ms2 not being available",
+			},
+		},
 		{
 			name:              "scaling down with 5 stale machines",
 			machineDeployment: machineDeploymentWith1Replica,
@@ -883,6 +1250,213 @@ func Test_setMachinesUpToDateCondition(t *testing.T) {
 	}
 }
 
+func Test_setNodeVersionsUpToDateCondition(t *testing.T) {
+	tests := []struct {
+		name                 string
+		machines             []*clusterv1.Machine
+		getMachinesSucceeded bool
+		expectCondition      metav1.Condition
+	}{
+		{
+			name:                 "get machines failed",
+			machines:             nil,
+			getMachinesSucceeded: false,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Condition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.MachineDeploymentNodeVersionsUpToDateInternalErrorV1Beta2Reason,
+				Message: "Please check controller logs for errors",
+			},
+		},
+		{
+			name:                 "no machines",
+			machines:             []*clusterv1.Machine{},
+			getMachinesSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:   clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Condition,
+				Status: metav1.ConditionTrue,
+				Reason: clusterv1.MachineDeploymentNodeVersionsUpToDateNoReplicasV1Beta2Reason,
+			},
+		},
+		{
+			name: "all nodes running the declared version",
+			machines: []*clusterv1.Machine{
+				fakeMachine("machine-1", withVersion("v1.31.0"), withNodeKubeletVersion("v1.31.0")),
+				fakeMachine("machine-2", withVersion("v1.31.0"), withNodeKubeletVersion("v1.31.0+vendor.1")),
+				fakeMachine("machine-3", withVersion("v1.31.0")),
+			},
+			getMachinesSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:   clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Condition,
+				Status: metav1.ConditionTrue,
+				Reason: clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Reason,
+			},
+		},
+		{
+			name: "one node running a different version",
+			machines: []*clusterv1.Machine{
+				fakeMachine("machine-1", withVersion("v1.31.0"), withNodeKubeletVersion("v1.31.0")),
+				fakeMachine("machine-2", withVersion("v1.31.0"), withNodeKubeletVersion("v1.30.2")),
+			},
+			getMachinesSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Condition,
+				Status:  metav1.ConditionFalse,
+				Reason:  clusterv1.MachineDeploymentNodeVersionsNotUpToDateV1Beta2Reason,
+				Message: "Node of Machine machine-2 is running a kubelet version different from spec.version",
+			},
+		},
+		{
+			name: "many nodes running a different version, ignoring deleting machines",
+			machines: []*clusterv1.Machine{
+				fakeMachine("machine-1", withVersion("v1.31.0"), withNodeKubeletVersion("v1.30.2")),
+				fakeMachine("machine-2", withVersion("v1.31.0"), withNodeKubeletVersion("v1.30.2")),
+				fakeMachine("machine-3", withVersion("v1.31.0"), withNodeKubeletVersion("v1.30.2"), withStaleDeletion()),
+			},
+			getMachinesSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Condition,
+				Status:  metav1.ConditionFalse,
+				Reason:  clusterv1.MachineDeploymentNodeVersionsNotUpToDateV1Beta2Reason,
+				Message: "Nodes of Machines machine-1, machine-2 are running a kubelet version different from spec.version",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machineDeployment := &clusterv1.MachineDeployment{}
+			var machines collections.Machines
+			if tt.machines != nil {
+				machines = collections.FromMachines(tt.machines...)
+			}
+			setNodeVersionsUpToDateCondition(ctx, machineDeployment, machines, tt.getMachinesSucceeded)
+
+			condition := v1beta2conditions.Get(machineDeployment, clusterv1.MachineDeploymentNodeVersionsUpToDateV1Beta2Condition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(*condition).To(v1beta2conditions.MatchCondition(tt.expectCondition, v1beta2conditions.IgnoreLastTransitionTime(true)))
+		})
+	}
+}
+
+func Test_setImagesUpToDateCondition(t *testing.T) {
+	tests := []struct {
+		name                 string
+		machines             []*clusterv1.Machine
+		templateImage        string
+		getMachinesSucceeded bool
+		expectCondition      metav1.Condition
+	}{
+		{
+			name:                 "get machines failed",
+			machines:             nil,
+			templateImage:        "image-2",
+			getMachinesSucceeded: false,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.MachineDeploymentImagesUpToDateInternalErrorV1Beta2Reason,
+				Message: "Please check controller logs for errors",
+			},
+		},
+		{
+			name:                 "no machines",
+			machines:             []*clusterv1.Machine{},
+			templateImage:        "image-2",
+			getMachinesSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:   clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition,
+				Status: metav1.ConditionTrue,
+				Reason: clusterv1.MachineDeploymentImagesUpToDateNoReplicasV1Beta2Reason,
+			},
+		},
+		{
+			name: "template not reporting an image",
+			machines: []*clusterv1.Machine{
+				fakeMachine("machine-1", withImage("image-1")),
+			},
+			templateImage:        "",
+			getMachinesSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  clusterv1.MachineDeploymentImagesUpToDateNotReportedV1Beta2Reason,
+				Message: "GenericInfrastructureMachineTemplate does not report status.image",
+			},
+		},
+		{
+			name: "all machines running the declared image, ignoring machines not reporting an image",
+			machines: []*clusterv1.Machine{
+				fakeMachine("machine-1", withImage("image-2")),
+				fakeMachine("machine-2"),
+			},
+			templateImage:        "image-2",
+			getMachinesSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:   clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition,
+				Status: metav1.ConditionTrue,
+				Reason: clusterv1.MachineDeploymentImagesUpToDateV1Beta2Reason,
+			},
+		},
+		{
+			name: "one machine running a different image",
+			machines: []*clusterv1.Machine{
+				fakeMachine("machine-1", withImage("image-2")),
+				fakeMachine("machine-2", withImage("image-1")),
+			},
+			templateImage:        "image-2",
+			getMachinesSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition,
+				Status:  metav1.ConditionFalse,
+				Reason:  clusterv1.MachineDeploymentImagesNotUpToDateV1Beta2Reason,
+				Message: "Machine machine-2 is running an image different from image-2",
+			},
+		},
+		{
+			name: "many machines running a different image, ignoring deleting machines",
+			machines: []*clusterv1.Machine{
+				fakeMachine("machine-1", withImage("image-1")),
+				fakeMachine("machine-2", withImage("image-1")),
+				fakeMachine("machine-3", withImage("image-1"), withStaleDeletion()),
+			},
+			templateImage:        "image-2",
+			getMachinesSucceeded: true,
+			expectCondition: metav1.Condition{
+				Type:    clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition,
+				Status:  metav1.ConditionFalse,
+				Reason:  clusterv1.MachineDeploymentImagesNotUpToDateV1Beta2Reason,
+				Message: "Machines machine-1, machine-2 are running an image different from image-2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machineDeployment := &clusterv1.MachineDeployment{
+				Spec: clusterv1.MachineDeploymentSpec{
+					Template: clusterv1.MachineTemplateSpec{
+						Spec: clusterv1.MachineSpec{
+							InfrastructureRef: corev1.ObjectReference{Kind: "GenericInfrastructureMachineTemplate"},
+						},
+					},
+				},
+			}
+			var machines collections.Machines
+			if tt.machines != nil {
+				machines = collections.FromMachines(tt.machines...)
+			}
+			setImagesUpToDateCondition(ctx, machineDeployment, machines, tt.templateImage, tt.getMachinesSucceeded)
+
+			condition := v1beta2conditions.Get(machineDeployment, clusterv1.MachineDeploymentImagesUpToDateV1Beta2Condition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(*condition).To(v1beta2conditions.MatchCondition(tt.expectCondition, v1beta2conditions.IgnoreLastTransitionTime(true)))
+		})
+	}
+}
+
 func Test_setRemediatingCondition(t *testing.T) {
 	healthCheckSucceeded := clusterv1.Condition{Type: clusterv1.MachineHealthCheckSucceededV1Beta2Condition, Status: corev1.ConditionTrue}
 	healthCheckNotSucceeded := clusterv1.Condition{Type: clusterv1.MachineHealthCheckSucceededV1Beta2Condition, Status: corev1.ConditionFalse}
@@ -1125,12 +1699,39 @@ func fakeMachineSet(name string, options ...fakeMachineSetOption) *clusterv1.Mac
 	return p
 }
 
+func withMachineTemplate(template clusterv1.MachineTemplateSpec) fakeMachineSetOption {
+	return func(ms *clusterv1.MachineSet) {
+		ms.Spec.Template = *template.DeepCopy()
+	}
+}
+
 func withStatusReplicas(n int32) fakeMachineSetOption {
 	return func(ms *clusterv1.MachineSet) {
 		ms.Status.Replicas = n
 	}
 }
 
+func withRevision(n int) fakeMachineSetOption {
+	return func(ms *clusterv1.MachineSet) {
+		if ms.Annotations == nil {
+			ms.Annotations = map[string]string{}
+		}
+		ms.Annotations[clusterv1.RevisionAnnotation] = strconv.Itoa(n)
+	}
+}
+
+func withSpecReplicas(n int32) fakeMachineSetOption {
+	return func(ms *clusterv1.MachineSet) {
+		ms.Spec.Replicas = ptr.To(n)
+	}
+}
+
+func withStatusAvailableReplicas(n int32) fakeMachineSetOption {
+	return func(ms *clusterv1.MachineSet) {
+		ms.Status.AvailableReplicas = n
+	}
+}
+
 func withStatusV1beta2ReadyReplicas(n int32) fakeMachineSetOption {
 	return func(ms *clusterv1.MachineSet) {
 		if ms.Status.V1Beta2 == nil {
@@ -1178,6 +1779,24 @@ func withStaleDeletion() fakeMachinesOption {
 	}
 }
 
+func withVersion(v string) fakeMachinesOption {
+	return func(m *clusterv1.Machine) {
+		m.Spec.Version = ptr.To(v)
+	}
+}
+
+func withNodeKubeletVersion(v string) fakeMachinesOption {
+	return func(m *clusterv1.Machine) {
+		m.Status.NodeInfo = &corev1.NodeSystemInfo{KubeletVersion: v}
+	}
+}
+
+func withImage(image string) fakeMachinesOption {
+	return func(m *clusterv1.Machine) {
+		m.Status.Image = image
+	}
+}
+
 func withV1Beta2Condition(c metav1.Condition) fakeMachinesOption {
 	return func(m *clusterv1.Machine) {
 		if m.Status.V1Beta2 == nil {
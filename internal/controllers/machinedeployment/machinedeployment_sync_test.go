@@ -586,6 +586,7 @@ func TestComputeDesiredMachineSet(t *testing.T) {
 					NodeDrainTimeout:        duration10s,
 					NodeVolumeDetachTimeout: duration10s,
 					NodeDeletionTimeout:     duration10s,
+					Taints:                  []corev1.Taint{{Key: "foo", Effect: corev1.TaintEffectNoSchedule}},
 				},
 			},
 		},
@@ -654,6 +655,7 @@ func TestComputeDesiredMachineSet(t *testing.T) {
 		existingMS.Spec.Template.Spec.NodeDrainTimeout = duration5s
 		existingMS.Spec.Template.Spec.NodeDeletionTimeout = duration5s
 		existingMS.Spec.Template.Spec.NodeVolumeDetachTimeout = duration5s
+		existingMS.Spec.Template.Spec.Taints = []corev1.Taint{{Key: "bar", Effect: corev1.TaintEffectNoSchedule}}
 		existingMS.Spec.DeletePolicy = string(clusterv1.NewestMachineSetDeletePolicy)
 		existingMS.Spec.MinReadySeconds = 0
 
@@ -748,6 +750,7 @@ func TestComputeDesiredMachineSet(t *testing.T) {
 		existingMS.Spec.Template.Spec.NodeDrainTimeout = duration5s
 		existingMS.Spec.Template.Spec.NodeDeletionTimeout = duration5s
 		existingMS.Spec.Template.Spec.NodeVolumeDetachTimeout = duration5s
+		existingMS.Spec.Template.Spec.Taints = []corev1.Taint{{Key: "bar", Effect: corev1.TaintEffectNoSchedule}}
 		existingMS.Spec.DeletePolicy = string(clusterv1.NewestMachineSetDeletePolicy)
 		existingMS.Spec.MinReadySeconds = 0
 
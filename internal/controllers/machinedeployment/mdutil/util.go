@@ -435,6 +435,7 @@ func MachineTemplateDeepCopyRolloutFields(template *clusterv1.MachineTemplateSpe
 	templateCopy.Spec.NodeDrainTimeout = nil
 	templateCopy.Spec.NodeDeletionTimeout = nil
 	templateCopy.Spec.NodeVolumeDetachTimeout = nil
+	templateCopy.Spec.Taints = nil
 
 	// Remove the version part from the references APIVersion field,
 	// for more details see issue #2183 and #2140.
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func init() {
+	// Register the metrics at the controller-runtime metrics registry.
+	ctrlmetrics.Registry.MustRegister(rolloutProgressDeadlineExceededTotal)
+}
+
+// Metrics subsystem used by the MachineDeployment controller.
+const machineDeploymentSubsystem = "capi_machinedeployment"
+
+var (
+	// rolloutProgressDeadlineExceededTotal reports the number of rollouts which exceeded their progress deadline.
+	rolloutProgressDeadlineExceededTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: machineDeploymentSubsystem,
+		Name:      "rollout_progress_deadline_exceeded_total",
+		Help:      "Number of times a MachineDeployment rollout did not make progress within spec.progressDeadlineSeconds.",
+	})
+)
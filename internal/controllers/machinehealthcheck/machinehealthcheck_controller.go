@@ -35,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -84,6 +85,10 @@ type Reconciler struct {
 
 	controller controller.Controller
 	recorder   record.EventRecorder
+
+	// clock is used to evaluate time-based logic like the node startup timeout and the timeouts of unhealthy conditions.
+	// If not set, the real clock is used; tests can inject a fake clock to advance time deterministically.
+	clock clock.PassiveClock
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -122,6 +127,14 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	return nil
 }
 
+// now returns the current time according to the Reconciler's clock.
+func (r *Reconciler) now() time.Time {
+	if r.clock == nil {
+		return time.Now()
+	}
+	return r.clock.Now()
+}
+
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -223,11 +236,15 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 
 	// fetch all targets
 	logger.V(3).Info("Finding targets")
-	targets, err := r.getTargetsFromMHC(ctx, logger, remoteClient, cluster, m)
+	targets, pendingRemediationToCancel, err := r.getTargetsFromMHC(ctx, logger, remoteClient, cluster, m)
 	if err != nil {
 		logger.Error(err, "Failed to fetch targets from MachineHealthCheck")
 		return ctrl.Result{}, err
 	}
+
+	// cancel pending remediation of machines with the skip-remediation annotation
+	errList := r.cancelPendingRemediations(ctx, logger, pendingRemediationToCancel)
+
 	totalTargets := len(targets)
 	m.Status.ExpectedMachines = int32(totalTargets)
 	m.Status.Targets = make([]string, totalTargets)
@@ -305,7 +322,6 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 				message,
 			)
 		}
-		errList := []error{}
 		for _, t := range append(healthy, unhealthy...) {
 			patchOpts := []patch.Option{
 				patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
@@ -314,6 +330,7 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 				}},
 				patch.WithOwnedV1Beta2Conditions{Conditions: []string{
 					clusterv1.MachineHealthCheckSucceededV1Beta2Condition,
+					clusterv1.MachineNodeClockSkewedV1Beta2Condition,
 					// Note: intentionally leaving out OwnerRemediated condition which is mostly controlled by the owner.
 					// (Same for ExternallyRemediated condition)
 				}},
@@ -355,7 +372,7 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 		Reason: clusterv1.MachineHealthCheckRemediationAllowedV1Beta2Reason,
 	})
 
-	errList := r.patchUnhealthyTargets(ctx, logger, unhealthy, cluster, m)
+	errList = append(errList, r.patchUnhealthyTargets(ctx, logger, unhealthy, cluster, m)...)
 	errList = append(errList, r.patchHealthyTargets(ctx, logger, healthy, m)...)
 
 	// handle update errors
@@ -405,6 +422,7 @@ func (r *Reconciler) patchHealthyTargets(ctx context.Context, logger logr.Logger
 			}},
 			patch.WithOwnedV1Beta2Conditions{Conditions: []string{
 				clusterv1.MachineHealthCheckSucceededV1Beta2Condition,
+				clusterv1.MachineNodeClockSkewedV1Beta2Condition,
 				// Note: intentionally leaving out OwnerRemediated condition which is mostly controlled by the owner.
 				// (Same for ExternallyRemediated condition)
 			}},
@@ -417,6 +435,41 @@ func (r *Reconciler) patchHealthyTargets(ctx context.Context, logger logr.Logger
 	return errList
 }
 
+// cancelPendingRemediations removes the conditions set by the MachineHealthCheck from machines with the
+// skip-remediation annotation which are still waiting for remediation.
+func (r *Reconciler) cancelPendingRemediations(ctx context.Context, logger logr.Logger, machines []*clusterv1.Machine) []error {
+	errList := []error{}
+	for _, machine := range machines {
+		patchHelper, err := patch.NewHelper(machine, r.Client)
+		if err != nil {
+			errList = append(errList, err)
+			continue
+		}
+
+		conditions.Delete(machine, clusterv1.MachineHealthCheckSucceededCondition)
+		conditions.Delete(machine, clusterv1.MachineOwnerRemediatedCondition)
+		v1beta2conditions.Delete(machine, clusterv1.MachineHealthCheckSucceededV1Beta2Condition)
+		v1beta2conditions.Delete(machine, clusterv1.MachineOwnerRemediatedV1Beta2Condition)
+
+		patchOpts := []patch.Option{
+			patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+				clusterv1.MachineHealthCheckSucceededCondition,
+				clusterv1.MachineOwnerRemediatedCondition,
+			}},
+			patch.WithOwnedV1Beta2Conditions{Conditions: []string{
+				clusterv1.MachineHealthCheckSucceededV1Beta2Condition,
+				clusterv1.MachineOwnerRemediatedV1Beta2Condition,
+			}},
+		}
+		if err := patchHelper.Patch(ctx, machine, patchOpts...); err != nil {
+			errList = append(errList, errors.Wrapf(err, "failed to cancel pending remediation of Machine %s", klog.KObj(machine)))
+			continue
+		}
+		logger.Info("Cancelled pending remediation", "Machine", klog.KObj(machine), "reason", fmt.Sprintf("machine has %q annotation", clusterv1.MachineSkipRemediationAnnotation))
+	}
+	return errList
+}
+
 // patchUnhealthyTargets patches machines with MachineOwnerRemediatedCondition for remediation.
 func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logger, unhealthy []healthCheckTarget, cluster *clusterv1.Cluster, m *clusterv1.MachineHealthCheck) []error {
 	// mark for remediation
@@ -428,10 +481,10 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 			logger.Info("Machine has failed health check, but machine is paused so skipping remediation", "target", t.string(), "reason", condition.Reason, "message", condition.Message)
 		} else {
 			if m.Spec.RemediationTemplate != nil {
-				// If external remediation request already exists,
-				// return early
+				// If external remediation request already exists, skip this target
+				// without blocking remediation of the other unhealthy targets.
 				if r.externalRemediationRequestExists(ctx, m, t.Machine.Name) {
-					return errList
+					continue
 				}
 
 				cloneOwnerRef := &metav1.OwnerReference{
@@ -491,6 +544,8 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 					return errList
 				}
 
+				remediationsTotal.WithLabelValues(remediationTypeExternal).Inc()
+
 				v1beta2conditions.Set(t.Machine, metav1.Condition{
 					Type:   clusterv1.MachineExternallyRemediatedV1Beta2Condition,
 					Status: metav1.ConditionFalse,
@@ -502,6 +557,7 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 				// instead, if a remediation is in already progress, the remediation owner is responsible for completing the process and MHC should not overwrite the condition.
 				if !conditions.Has(t.Machine, clusterv1.MachineOwnerRemediatedCondition) || conditions.IsTrue(t.Machine, clusterv1.MachineOwnerRemediatedCondition) {
 					conditions.MarkFalse(t.Machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, "")
+					remediationsTotal.WithLabelValues(remediationTypeOwner).Inc()
 				}
 
 				if ownerRemediatedCondition := v1beta2conditions.Get(t.Machine, clusterv1.MachineOwnerRemediatedV1Beta2Condition); ownerRemediatedCondition == nil || ownerRemediatedCondition.Status == metav1.ConditionTrue {
@@ -522,6 +578,7 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 			}},
 			patch.WithOwnedV1Beta2Conditions{Conditions: []string{
 				clusterv1.MachineHealthCheckSucceededV1Beta2Condition,
+				clusterv1.MachineNodeClockSkewedV1Beta2Condition,
 				// Note: intentionally leaving out OwnerRemediated condition which is mostly controlled by the owner.
 				// (Same for ExternallyRemediated condition)
 			}},
@@ -603,7 +660,7 @@ func (r *Reconciler) nodeToMachineHealthCheck(ctx context.Context, o client.Obje
 		panic(fmt.Sprintf("Expected a corev1.Node, got %T", o))
 	}
 
-	machine, err := getMachineFromNode(ctx, r.Client, node.Name)
+	machine, err := getMachineFromNode(ctx, r.Client, node)
 	if machine == nil || err != nil {
 		return nil
 	}
@@ -620,30 +677,36 @@ func (r *Reconciler) watchClusterNodes(ctx context.Context, cluster *clusterv1.C
 	}))
 }
 
-// getMachineFromNode retrieves the machine with a nodeRef to nodeName
+// getMachineFromNode retrieves the machine with a nodeRef to the given Node.
+// If the Node has the cluster name and namespace annotations, the lookup is restricted to the Machines of that Cluster,
+// so Nodes with the same name in different workload clusters are not mixed up.
 // There should at most one machine with a given nodeRef, returns an error otherwise.
-func getMachineFromNode(ctx context.Context, c client.Client, nodeName string) (*clusterv1.Machine, error) {
+func getMachineFromNode(ctx context.Context, c client.Client, node *corev1.Node) (*clusterv1.Machine, error) {
+	fields := client.MatchingFields{index.MachineNodeNameField: node.Name}
+	listOptions := []client.ListOption{}
+	if clusterName, ok := node.GetAnnotations()[clusterv1.ClusterNameAnnotation]; ok {
+		fields[index.MachineClusterNameField] = clusterName
+	}
+	if namespace, ok := node.GetAnnotations()[clusterv1.ClusterNamespaceAnnotation]; ok {
+		listOptions = append(listOptions, client.InNamespace(namespace))
+	}
+
 	machineList := &clusterv1.MachineList{}
 	if err := c.List(
 		ctx,
 		machineList,
-		client.MatchingFields{index.MachineNodeNameField: nodeName},
+		append(listOptions, fields)...,
 	); err != nil {
 		return nil, errors.Wrap(err, "failed getting machine list")
 	}
-	// TODO(vincepri): Remove this loop once controller runtime fake client supports
-	// adding indexes on objects.
-	items := []*clusterv1.Machine{}
-	for i := range machineList.Items {
-		machine := &machineList.Items[i]
-		if machine.Status.NodeRef != nil && machine.Status.NodeRef.Name == nodeName {
-			items = append(items, machine)
+	if len(machineList.Items) != 1 {
+		items := make([]*clusterv1.Machine, 0, len(machineList.Items))
+		for i := range machineList.Items {
+			items = append(items, &machineList.Items[i])
 		}
+		return nil, errors.Errorf("expecting one machine for node %v, got %v", node.Name, machineNames(items))
 	}
-	if len(items) != 1 {
-		return nil, errors.Errorf("expecting one machine for node %v, got %v", nodeName, machineNames(items))
-	}
-	return items[0], nil
+	return &machineList.Items[0], nil
 }
 
 func machineNames(machines []*clusterv1.Machine) []string {
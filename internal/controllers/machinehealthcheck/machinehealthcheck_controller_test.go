@@ -26,6 +26,7 @@ import (
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -2071,6 +2072,7 @@ func TestMachineToMachineHealthCheck(t *testing.T) {
 func TestNodeToMachineHealthCheck(t *testing.T) {
 	fakeClient := fake.NewClientBuilder().
 		WithIndex(&clusterv1.Machine{}, index.MachineNodeNameField, index.MachineByNodeName).
+		WithIndex(&clusterv1.Machine{}, index.MachineClusterNameField, index.MachineByClusterName).
 		WithStatusSubresource(&clusterv1.MachineHealthCheck{}, &clusterv1.Machine{}).
 		Build()
 
@@ -2092,12 +2094,22 @@ func TestNodeToMachineHealthCheck(t *testing.T) {
 
 	machine1 := newTestMachine("machine1", namespace, clusterName, nodeName, labels)
 	machine2 := newTestMachine("machine2", namespace, clusterName, nodeName, labels)
+	otherClusterMachine := newTestMachine("machine3", namespace, "othercluster", nodeName, labels)
 
 	node1 := &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: nodeName,
 		},
 	}
+	node1WithClusterAnnotations := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nodeName,
+			Annotations: map[string]string{
+				clusterv1.ClusterNameAnnotation:      clusterName,
+				clusterv1.ClusterNamespaceAnnotation: namespace,
+			},
+		},
+	}
 
 	testCases := []struct {
 		name        string
@@ -2120,6 +2132,13 @@ func TestNodeToMachineHealthCheck(t *testing.T) {
 			object:      node1,
 			expected:    []reconcile.Request{},
 		},
+		{
+			name:        "when Machines from different Clusters exist for the Node name and the Node has cluster annotations",
+			mhcToCreate: []clusterv1.MachineHealthCheck{*mhc1},
+			mToCreate:   []clusterv1.Machine{*machine1, *otherClusterMachine},
+			object:      node1WithClusterAnnotations,
+			expected:    []reconcile.Request{mhc1Req},
+		},
 		{
 			name:        "when no MachineHealthCheck exists for the Node in the Machine's namespace",
 			mhcToCreate: []clusterv1.MachineHealthCheck{*mhc4},
@@ -2738,3 +2757,88 @@ func TestPatchTargets(t *testing.T) {
 	// Target with wrong patch helper will fail but the other one will be patched.
 	g.Expect(r.patchHealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), []healthCheckTarget{target1, target3}, mhc)).ToNot(BeEmpty())
 }
+
+func TestPatchUnhealthyTargetsWithExternalRemediation(t *testing.T) {
+	g := NewWithT(t)
+
+	namespace := metav1.NamespaceDefault
+	clusterName := testClusterName
+	defaultCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: namespace,
+		},
+	}
+	labels := map[string]string{"cluster": "foo", "nodepool": "bar"}
+
+	remediationTemplate := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"size": "3xlarge",
+					},
+				},
+			},
+		},
+	}
+	remediationTemplate.SetKind("GenericExternalRemediationTemplate")
+	remediationTemplate.SetAPIVersion(builder.RemediationGroupVersion.String())
+	remediationTemplate.SetName("remediation-template")
+	remediationTemplate.SetNamespace(namespace)
+
+	mhc := newMachineHealthCheckWithLabels("mhc", namespace, clusterName, labels)
+	mhc.Spec.RemediationTemplate = &corev1.ObjectReference{
+		APIVersion: builder.RemediationGroupVersion.String(),
+		Kind:       "GenericExternalRemediationTemplate",
+		Name:       remediationTemplate.GetName(),
+	}
+
+	machine1 := newTestMachine("machine1", namespace, clusterName, "nodeName", labels)
+	conditions.MarkFalse(machine1, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.NodeNotFoundReason, clusterv1.ConditionSeverityWarning, "")
+	machine2 := machine1.DeepCopy()
+	machine2.Name = "machine2"
+
+	// machine1 already has a remediation request.
+	existingRequest := &unstructured.Unstructured{}
+	existingRequest.SetKind("GenericExternalRemediation")
+	existingRequest.SetAPIVersion(builder.RemediationGroupVersion.String())
+	existingRequest.SetName(machine1.Name)
+	existingRequest.SetNamespace(namespace)
+
+	cl := fake.NewClientBuilder().WithObjects(
+		machine1,
+		machine2,
+		mhc,
+		remediationTemplate,
+		existingRequest,
+	).WithStatusSubresource(&clusterv1.MachineHealthCheck{}, &clusterv1.Machine{}).Build()
+	r := &Reconciler{
+		Client:   cl,
+		recorder: record.NewFakeRecorder(32),
+	}
+
+	targets := []healthCheckTarget{}
+	for _, m := range []*clusterv1.Machine{machine1, machine2} {
+		patchHelper, err := patch.NewHelper(m, cl)
+		g.Expect(err).ToNot(HaveOccurred())
+		targets = append(targets, healthCheckTarget{
+			MHC:         mhc,
+			Machine:     m,
+			patchHelper: patchHelper,
+			Node:        &corev1.Node{},
+		})
+	}
+
+	externalRemediationsBefore := testutil.ToFloat64(remediationsTotal.WithLabelValues(remediationTypeExternal))
+
+	// The existing remediation request for machine1 must not prevent machine2 from being remediated.
+	g.Expect(r.patchUnhealthyTargets(ctx, logr.New(log.NullLogSink{}), targets, defaultCluster, mhc)).To(BeEmpty())
+	g.Expect(testutil.ToFloat64(remediationsTotal.WithLabelValues(remediationTypeExternal))).To(Equal(externalRemediationsBefore + 1))
+
+	request := &unstructured.Unstructured{}
+	request.SetKind("GenericExternalRemediation")
+	request.SetAPIVersion(builder.RemediationGroupVersion.String())
+	g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: machine2.Name}, request)).To(Succeed())
+	g.Expect(request.GetOwnerReferences()).To(ConsistOf(HaveField("Name", machine2.Name)))
+}
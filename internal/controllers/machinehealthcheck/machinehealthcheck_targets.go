@@ -23,6 +23,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/util/clockskew"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -46,11 +48,22 @@ const (
 	// EventDetectedUnhealthy is emitted in case a node associated with a
 	// machine was detected unhealthy.
 	EventDetectedUnhealthy string = "DetectedUnhealthy"
+	// EventDetectedNodeClockSkew is emitted in case the clock of a node associated with a
+	// machine was detected to be ahead of the clock of the management cluster.
+	EventDetectedNodeClockSkew string = "DetectedNodeClockSkew"
 )
 
 var (
 	// We allow users to disable the nodeStartupTimeout by setting the duration to 0.
 	disabledNodeStartupTimeout = clusterv1.ZeroDuration
+
+	// nodeClockSkewWarningThreshold is the clock skew of a node above which the NodeClockSkewed condition is set
+	// to true on the Machine and a warning event is emitted; the condition is set back to false only once the skew
+	// goes below half of the threshold, so a skew oscillating around the threshold does not flip the condition.
+	nodeClockSkewWarningThreshold = clockskew.Tolerance
+
+	// defaultNodeLeaseDuration is the lease duration used by the kubelet if the Lease does not specify one.
+	defaultNodeLeaseDuration = 40 * time.Second
 )
 
 // healthCheckTarget contains the information required to perform a health check
@@ -59,6 +72,7 @@ type healthCheckTarget struct {
 	Cluster     *clusterv1.Cluster
 	Machine     *clusterv1.Machine
 	Node        *corev1.Node
+	NodeLease   *coordinationv1.Lease
 	MHC         *clusterv1.MachineHealthCheck
 	patchHelper *patch.Helper
 	nodeMissing bool
@@ -91,9 +105,8 @@ func (t *healthCheckTarget) nodeName() string {
 // If the target doesn't currently need rememdiation, provide a duration after
 // which the target should next be checked.
 // The target should be requeued after this duration.
-func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachineToHaveNode metav1.Duration) (bool, time.Duration) {
+func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachineToHaveNode metav1.Duration, now time.Time) (bool, time.Duration) {
 	var nextCheckTimes []time.Duration
-	now := time.Now()
 
 	if annotations.HasRemediateMachine(t.Machine) {
 		conditions.MarkFalse(t.Machine, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.HasRemediateMachineAnnotationReason, clusterv1.ConditionSeverityWarning, "Marked for remediation via remediate-machine annotation")
@@ -182,7 +195,7 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 		logger.V(5).Info("Using comparison time", "time", comparisonTime)
 
 		timeoutDuration := timeoutForMachineToHaveNode.Duration
-		if comparisonTime.Add(timeoutForMachineToHaveNode.Duration).Before(now) {
+		if clockskew.Expired(comparisonTime, timeoutDuration, now) {
 			conditions.MarkFalse(t.Machine, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.NodeStartupTimeoutReason, clusterv1.ConditionSeverityWarning, "Node failed to report startup in %s", timeoutDuration)
 			logger.V(3).Info("Target is unhealthy: machine has no node", "duration", timeoutDuration)
 
@@ -195,12 +208,15 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 			return true, time.Duration(0)
 		}
 
-		durationUnhealthy := now.Sub(comparisonTime)
-		nextCheck := timeoutDuration - durationUnhealthy + time.Second
+		nextCheck := clockskew.Remaining(comparisonTime, timeoutDuration, now) + time.Second
 
 		return false, nextCheck
 	}
 
+	// Node conditions are set by the kubelet using the clock of the node, so if the clock of the node is ahead of
+	// or behind the clock of the management cluster the time a condition has been unhealthy is shifted accordingly.
+	skew := nodeClockSkew(t.Node, t.NodeLease, now)
+
 	// check conditions
 	for _, c := range t.MHC.Spec.UnhealthyConditions {
 		nodeCondition := getNodeCondition(t.Node, c.Type)
@@ -213,7 +229,8 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 
 		// If the condition has been in the unhealthy state for longer than the
 		// timeout, return true with no requeue time.
-		if nodeCondition.LastTransitionTime.Add(c.Timeout.Duration).Before(now) {
+		lastTransitionTime := nodeCondition.LastTransitionTime.Add(-skew)
+		if lastTransitionTime.Add(c.Timeout.Duration).Before(now) {
 			conditions.MarkFalse(t.Machine, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.UnhealthyNodeConditionReason, clusterv1.ConditionSeverityWarning, "Condition %s on node is reporting status %s for more than %s", c.Type, c.Status, c.Timeout.Duration.String())
 			logger.V(3).Info("Target is unhealthy: condition is in state longer than allowed timeout", "condition", c.Type, "state", c.Status, "timeout", c.Timeout.Duration.String())
 
@@ -226,7 +243,7 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 			return true, time.Duration(0)
 		}
 
-		durationUnhealthy := now.Sub(nodeCondition.LastTransitionTime.Time)
+		durationUnhealthy := now.Sub(lastTransitionTime)
 		nextCheck := c.Timeout.Duration - durationUnhealthy + time.Second
 		if nextCheck > 0 {
 			nextCheckTimes = append(nextCheckTimes, nextCheck)
@@ -235,29 +252,121 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 	return false, minDuration(nextCheckTimes)
 }
 
+// nodeClockSkew returns how far the clock of the node is ahead of now (positive) or behind now (negative).
+// The skew is detected by comparing the renew time of the node Lease, which the kubelet renews periodically using
+// the clock of the node, with now; given that the Lease could have been renewed up to a lease duration ago, a renew
+// time in the past is only considered a skew if it is older than the lease duration.
+// The Lease is only used if the node is ready, because otherwise the kubelet might not be renewing it.
+// If the Lease is not available, the skew is detected by heartbeat times of the node conditions in the future;
+// NOTE: a node clock which is behind cannot be detected this way, because heartbeat times in the past are expected.
+func nodeClockSkew(node *corev1.Node, lease *coordinationv1.Lease, now time.Time) time.Duration {
+	if lease != nil && lease.Spec.RenewTime != nil && util.IsNodeReady(node) {
+		skew := lease.Spec.RenewTime.Sub(now)
+		if skew >= 0 {
+			return skew
+		}
+		leaseDuration := defaultNodeLeaseDuration
+		if lease.Spec.LeaseDurationSeconds != nil {
+			leaseDuration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+		}
+		if skew += leaseDuration; skew < 0 {
+			return skew
+		}
+		return 0
+	}
+
+	var skew time.Duration
+	for _, c := range node.Status.Conditions {
+		if d := c.LastHeartbeatTime.Sub(now); d > skew {
+			skew = d
+		}
+	}
+	return skew
+}
+
+// setNodeClockSkewedCondition sets the NodeClockSkewed condition on the Machine.
+// The condition is set to true if the clock of its Node is ahead of or behind the clock of the management cluster by
+// more than nodeClockSkewWarningThreshold, and it is set back to false only once the skew goes below half of the threshold.
+// A warning event is emitted only when the condition transitions to true.
+// NOTE: The message of the condition intentionally does not contain the actual skew, so it does not change on every reconcile.
+func (r *Reconciler) setNodeClockSkewedCondition(logger logr.Logger, t healthCheckTarget, now time.Time) {
+	if t.Node == nil {
+		if v1beta2conditions.Has(t.Machine, clusterv1.MachineNodeClockSkewedV1Beta2Condition) {
+			v1beta2conditions.Delete(t.Machine, clusterv1.MachineNodeClockSkewedV1Beta2Condition)
+		}
+		return
+	}
+
+	skew := nodeClockSkew(t.Node, t.NodeLease, now)
+	absSkew := skew
+	if absSkew < 0 {
+		absSkew = -absSkew
+	}
+
+	wasSkewed := v1beta2conditions.IsTrue(t.Machine, clusterv1.MachineNodeClockSkewedV1Beta2Condition)
+	isSkewed := absSkew > nodeClockSkewWarningThreshold || (wasSkewed && absSkew > nodeClockSkewWarningThreshold/2)
+	if !isSkewed {
+		v1beta2conditions.Set(t.Machine, metav1.Condition{
+			Type:   clusterv1.MachineNodeClockSkewedV1Beta2Condition,
+			Status: metav1.ConditionFalse,
+			Reason: clusterv1.MachineNodeNotClockSkewedV1Beta2Reason,
+		})
+		return
+	}
+
+	direction := "ahead of"
+	if skew < 0 {
+		direction = "behind"
+	}
+	if !wasSkewed {
+		logger.Info(fmt.Sprintf("Clock of the Node is %s the clock of the management cluster, timeouts of unhealthy conditions are adjusted accordingly", direction), "Node", klog.KObj(t.Node), "skew", skew.Truncate(time.Second).String())
+		r.recorder.Eventf(
+			t.Machine,
+			corev1.EventTypeWarning,
+			EventDetectedNodeClockSkew,
+			"Clock of Node %s is %s the clock of the management cluster by at least %s",
+			t.nodeName(),
+			direction,
+			absSkew.Truncate(time.Second).String(),
+		)
+	}
+	v1beta2conditions.Set(t.Machine, metav1.Condition{
+		Type:    clusterv1.MachineNodeClockSkewedV1Beta2Condition,
+		Status:  metav1.ConditionTrue,
+		Reason:  clusterv1.MachineNodeClockSkewedV1Beta2Reason,
+		Message: fmt.Sprintf("Clock of Node %s is %s the clock of the management cluster by more than %s", t.nodeName(), direction, nodeClockSkewWarningThreshold),
+	})
+}
+
 // getTargetsFromMHC uses the MachineHealthCheck's selector to fetch machines
 // and their nodes targeted by the health check, ready for health checking.
-func (r *Reconciler) getTargetsFromMHC(ctx context.Context, logger logr.Logger, clusterClient client.Reader, cluster *clusterv1.Cluster, mhc *clusterv1.MachineHealthCheck) ([]healthCheckTarget, error) {
+// It also returns the machines skipped because of the skip-remediation annotation which are still waiting
+// for remediation, so their pending remediation can be cancelled.
+func (r *Reconciler) getTargetsFromMHC(ctx context.Context, logger logr.Logger, clusterClient client.Reader, cluster *clusterv1.Cluster, mhc *clusterv1.MachineHealthCheck) ([]healthCheckTarget, []*clusterv1.Machine, error) {
 	machines, err := r.getMachinesFromMHC(ctx, mhc)
 	if err != nil {
-		return nil, errors.Wrap(err, "error getting machines from MachineHealthCheck")
+		return nil, nil, errors.Wrap(err, "error getting machines from MachineHealthCheck")
 	}
 	if len(machines) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	targets := []healthCheckTarget{}
+	pendingRemediationToCancel := []*clusterv1.Machine{}
 	for k := range machines {
 		logger := logger.WithValues("Machine", klog.KObj(&machines[k]))
 		skip, reason := shouldSkipRemediation(&machines[k])
 		if skip {
 			logger.Info("Skipping remediation", "reason", reason)
+			if shouldCancelPendingRemediation(&machines[k]) {
+				pendingRemediationToCancel = append(pendingRemediationToCancel, &machines[k])
+			}
 			continue
 		}
 
 		patchHelper, err := patch.NewHelper(&machines[k], r.Client)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		target := healthCheckTarget{
 			Cluster:     cluster,
@@ -269,17 +378,25 @@ func (r *Reconciler) getTargetsFromMHC(ctx context.Context, logger logr.Logger,
 			node, err := r.getNodeFromMachine(ctx, clusterClient, target.Machine)
 			if err != nil {
 				if !apierrors.IsNotFound(err) {
-					return nil, errors.Wrap(err, "error getting node")
+					return nil, nil, errors.Wrap(err, "error getting node")
 				}
 
 				// A node has been seen for this machine, but it no longer exists
 				target.nodeMissing = true
 			}
 			target.Node = node
+
+			if node != nil {
+				lease, err := r.getNodeLease(ctx, clusterClient, node)
+				if err != nil {
+					return nil, nil, errors.Wrap(err, "error getting node lease")
+				}
+				target.NodeLease = lease
+			}
 		}
 		targets = append(targets, target)
 	}
-	return targets, nil
+	return targets, pendingRemediationToCancel, nil
 }
 
 // getMachinesFromMHC fetches Machines matched by the MachineHealthCheck's
@@ -323,17 +440,31 @@ func (r *Reconciler) getNodeFromMachine(ctx context.Context, clusterClient clien
 	return node, nil
 }
 
+// getNodeLease fetches the Lease of a node from a local or remote cluster; it returns nil if the Lease does not exist.
+func (r *Reconciler) getNodeLease(ctx context.Context, clusterClient client.Reader, node *corev1.Node) (*coordinationv1.Lease, error) {
+	lease := &coordinationv1.Lease{}
+	if err := clusterClient.Get(ctx, types.NamespacedName{Namespace: corev1.NamespaceNodeLease, Name: node.Name}, lease); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return lease, nil
+}
+
 // healthCheckTargets health checks a slice of targets
 // and gives a data to measure the average health.
 func (r *Reconciler) healthCheckTargets(targets []healthCheckTarget, logger logr.Logger, timeoutForMachineToHaveNode metav1.Duration) ([]healthCheckTarget, []healthCheckTarget, []time.Duration) {
 	var nextCheckTimes []time.Duration
 	var unhealthy []healthCheckTarget
 	var healthy []healthCheckTarget
+	now := r.now()
 
 	for _, t := range targets {
 		logger := logger.WithValues("target", t.string())
 		logger.V(3).Info("Health checking target")
-		needsRemediation, nextCheck := t.needsRemediation(logger, timeoutForMachineToHaveNode)
+		needsRemediation, nextCheck := t.needsRemediation(logger, timeoutForMachineToHaveNode, now)
+		r.setNodeClockSkewedCondition(logger, t, now)
 
 		if needsRemediation {
 			unhealthy = append(unhealthy, t)
@@ -406,3 +537,15 @@ func shouldSkipRemediation(m *clusterv1.Machine) (bool, string) {
 
 	return false, ""
 }
+
+// shouldCancelPendingRemediation returns true if a machine with the skip-remediation annotation is still waiting
+// for the owner controller to remediate it, so the skip-remediation annotation also applies to machines which have
+// been marked for remediation before the annotation was added.
+// NOTE: Paused machines and machines for which the remediation already started are not changed.
+func shouldCancelPendingRemediation(m *clusterv1.Machine) bool {
+	if annotations.HasPaused(m) || !annotations.HasSkipRemediation(m) || !m.DeletionTimestamp.IsZero() {
+		return false
+	}
+	return conditions.IsFalse(m, clusterv1.MachineOwnerRemediatedCondition) &&
+		conditions.GetReason(m, clusterv1.MachineOwnerRemediatedCondition) == clusterv1.WaitingForRemediationReason
+}
@@ -22,16 +22,21 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/internal/util/clockskew"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	v1beta2conditions "sigs.k8s.io/cluster-api/util/conditions/v1beta2"
 	"sigs.k8s.io/cluster-api/util/patch"
 )
 
@@ -92,11 +97,22 @@ func TestGetTargetsFromMHC(t *testing.T) {
 	testNode6 := newTestNode("node6")
 	testMachine6 := newTestMachine("machine6", namespace, clusterName, testNode6.Name, mhcSelector)
 	testMachine6.Annotations = map[string]string{clusterv1.PausedAnnotation: ""}
+	testNode7 := newTestNode("node7")
+	testMachine7 := newTestMachine("machine7", namespace, clusterName, testNode7.Name, mhcSelector)
+	testMachine7.Annotations = map[string]string{clusterv1.MachineSkipRemediationAnnotation: ""}
+	conditions.MarkFalse(testMachine7, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.NodeNotFoundReason, clusterv1.ConditionSeverityWarning, "")
+	conditions.MarkFalse(testMachine7, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, "")
+	v1beta2conditions.Set(testMachine7, metav1.Condition{
+		Type:   clusterv1.MachineOwnerRemediatedV1Beta2Condition,
+		Status: metav1.ConditionFalse,
+		Reason: clusterv1.MachineOwnerRemediatedWaitingForRemediationV1Beta2Reason,
+	})
 
 	testCases := []struct {
-		desc            string
-		toCreate        []client.Object
-		expectedTargets []healthCheckTarget
+		desc                    string
+		toCreate                []client.Object
+		expectedTargets         []healthCheckTarget
+		expectCancelledMachines []*clusterv1.Machine
 	}{
 		{
 			desc:            "with no matching machines",
@@ -153,13 +169,19 @@ func TestGetTargetsFromMHC(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:                    "with machines waiting for remediation having skip-remediation annotation",
+			toCreate:                append(baseObjects, testMachine7),
+			expectedTargets:         nil,
+			expectCancelledMachines: []*clusterv1.Machine{testMachine7},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
 			gs := NewGomegaWithT(t)
 
-			k8sClient := fake.NewClientBuilder().WithObjects(tc.toCreate...).Build()
+			k8sClient := fake.NewClientBuilder().WithObjects(tc.toCreate...).WithStatusSubresource(&clusterv1.Machine{}).Build()
 
 			// Create a test reconciler
 			reconciler := &Reconciler{
@@ -171,7 +193,7 @@ func TestGetTargetsFromMHC(t *testing.T) {
 				t.patchHelper = patchHelper
 			}
 
-			targets, err := reconciler.getTargetsFromMHC(ctx, ctrl.LoggerFrom(ctx), k8sClient, cluster, testMHC)
+			targets, pendingRemediationToCancel, err := reconciler.getTargetsFromMHC(ctx, ctrl.LoggerFrom(ctx), k8sClient, cluster, testMHC)
 			gs.Expect(err).ToNot(HaveOccurred())
 
 			gs.Expect(targets).To(HaveLen(len(tc.expectedTargets)))
@@ -181,6 +203,25 @@ func TestGetTargetsFromMHC(t *testing.T) {
 				gs.Expect(target.MHC).To(BeComparableTo(expectedTarget.MHC))
 				gs.Expect(target.Node).To(BeComparableTo(expectedTarget.Node))
 			}
+
+			// Machines with a pending remediation to cancel are returned but not changed.
+			gs.Expect(pendingRemediationToCancel).To(HaveLen(len(tc.expectCancelledMachines)))
+			for i, m := range pendingRemediationToCancel {
+				gs.Expect(m.Name).To(Equal(tc.expectCancelledMachines[i].Name))
+
+				machine := &clusterv1.Machine{}
+				gs.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(m), machine)).To(Succeed())
+				gs.Expect(conditions.Has(machine, clusterv1.MachineOwnerRemediatedCondition)).To(BeTrue())
+			}
+
+			gs.Expect(reconciler.cancelPendingRemediations(ctx, ctrl.LoggerFrom(ctx), pendingRemediationToCancel)).To(BeEmpty())
+			for _, m := range tc.expectCancelledMachines {
+				machine := &clusterv1.Machine{}
+				gs.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(m), machine)).To(Succeed())
+				gs.Expect(conditions.Has(machine, clusterv1.MachineHealthCheckSucceededCondition)).To(BeFalse())
+				gs.Expect(conditions.Has(machine, clusterv1.MachineOwnerRemediatedCondition)).To(BeFalse())
+				gs.Expect(v1beta2conditions.Has(machine, clusterv1.MachineOwnerRemediatedV1Beta2Condition)).To(BeFalse())
+			}
 		})
 	}
 }
@@ -374,6 +415,18 @@ func TestHealthCheckTargets(t *testing.T) {
 	nodeUnknown400Condition := newFailedHealthCheckCondition(clusterv1.UnhealthyNodeConditionReason, "Condition Ready on node is reporting status Unknown for more than %s", timeoutForUnhealthyConditions)
 	nodeUnknown400V1Beta2Condition := newFailedHealthCheckV1Beta2Condition(clusterv1.MachineHealthCheckUnhealthyNodeV1Beta2Reason, "Condition Ready on Node is reporting status Unknown for more than %s", timeoutForUnhealthyConditions)
 
+	// Target for when the node has been in an unknown state for longer than the timeout, as reported by a node
+	// with a clock which is ten minutes ahead
+	testNodeUnknown400ClockSkew := newTestUnhealthyNode("node1", corev1.NodeReady, corev1.ConditionUnknown, 400*time.Second-10*time.Minute)
+	testNodeUnknown400ClockSkew.Status.Conditions[0].LastHeartbeatTime = metav1.NewTime(time.Now().Add(10 * time.Minute))
+	nodeUnknown400ClockSkew := healthCheckTarget{
+		Cluster:     cluster,
+		MHC:         testMHC,
+		Machine:     testMachine.DeepCopy(),
+		Node:        testNodeUnknown400ClockSkew,
+		nodeMissing: false,
+	}
+
 	// Target for when a node is healthy
 	testNodeHealthy := newTestNode("node1")
 	testNodeHealthy.UID = "12345"
@@ -438,14 +491,14 @@ func TestHealthCheckTargets(t *testing.T) {
 			targets:                  []healthCheckTarget{nodeNotYetStartedTarget400s},
 			expectedHealthy:          []healthCheckTarget{},
 			expectedNeedsRemediation: []healthCheckTarget{},
-			expectedNextCheckTimes:   []time.Duration{timeoutForMachineToHaveNode - 400*time.Second},
+			expectedNextCheckTimes:   []time.Duration{timeoutForMachineToHaveNode + clockskew.Tolerance - 400*time.Second},
 		},
 		{
 			desc:                     "when the node has not yet started for shorter than the timeout, and infra is ready",
 			targets:                  []healthCheckTarget{nodeNotYetStartedTargetAndInfraReady},
 			expectedHealthy:          []healthCheckTarget{},
 			expectedNeedsRemediation: []healthCheckTarget{},
-			expectedNextCheckTimes:   []time.Duration{timeoutForMachineToHaveNode - 50*time.Second},
+			expectedNextCheckTimes:   []time.Duration{timeoutForMachineToHaveNode + clockskew.Tolerance - 50*time.Second},
 		},
 		{
 			desc:                                     "when the node has not yet started for longer than the timeout",
@@ -481,6 +534,15 @@ func TestHealthCheckTargets(t *testing.T) {
 			expectedNeedsRemediationV1Beta2Condition: []metav1.Condition{nodeUnknown400V1Beta2Condition},
 			expectedNextCheckTimes:                   []time.Duration{},
 		},
+		{
+			desc:                                     "when the node has been in an unknown state for longer than the timeout and the node clock is ahead",
+			targets:                                  []healthCheckTarget{nodeUnknown400ClockSkew},
+			expectedHealthy:                          []healthCheckTarget{},
+			expectedNeedsRemediation:                 []healthCheckTarget{nodeUnknown400ClockSkew},
+			expectedNeedsRemediationCondition:        []clusterv1.Condition{nodeUnknown400Condition},
+			expectedNeedsRemediationV1Beta2Condition: []metav1.Condition{nodeUnknown400V1Beta2Condition},
+			expectedNextCheckTimes:                   []time.Duration{},
+		},
 		{
 			desc:                     "when the node is healthy",
 			targets:                  []healthCheckTarget{nodeHealthy},
@@ -614,6 +676,173 @@ func TestHealthCheckTargets(t *testing.T) {
 	}
 }
 
+func TestHealthCheckTargetsWithFakeClock(t *testing.T) {
+	g := NewWithT(t)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clocktesting.NewFakePassiveClock(start)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-mhc", Name: "test-cluster"},
+	}
+	cluster.SetConditions(clusterv1.Conditions{
+		{Type: clusterv1.InfrastructureReadyCondition, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(start.Add(-time.Hour))},
+		{Type: clusterv1.ControlPlaneInitializedCondition, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(start.Add(-time.Hour))},
+	})
+	machine := newTestMachine("machine1", "test-mhc", "test-cluster", "node1", nil)
+	machine.CreationTimestamp = metav1.NewTime(start)
+	target := healthCheckTarget{
+		Cluster: cluster,
+		MHC:     &clusterv1.MachineHealthCheck{ObjectMeta: metav1.ObjectMeta{Namespace: "test-mhc", Name: "test-mhc"}},
+		Machine: machine,
+	}
+
+	reconciler := &Reconciler{
+		recorder: record.NewFakeRecorder(5),
+		clock:    fakeClock,
+	}
+	timeoutForMachineToHaveNode := metav1.Duration{Duration: 10 * time.Minute}
+
+	// The Machine is checked again when the node startup timeout, plus the tolerated clock skew, expires.
+	fakeClock.SetTime(start.Add(9 * time.Minute))
+	_, unhealthy, nextCheckTimes := reconciler.healthCheckTargets([]healthCheckTarget{target}, ctrl.LoggerFrom(ctx), timeoutForMachineToHaveNode)
+	g.Expect(unhealthy).To(BeEmpty())
+	g.Expect(nextCheckTimes).To(ConsistOf(time.Minute + clockskew.Tolerance + time.Second))
+
+	fakeClock.SetTime(start.Add(10*time.Minute + clockskew.Tolerance + time.Second))
+	_, unhealthy, nextCheckTimes = reconciler.healthCheckTargets([]healthCheckTarget{target}, ctrl.LoggerFrom(ctx), timeoutForMachineToHaveNode)
+	g.Expect(unhealthy).To(HaveLen(1))
+	g.Expect(nextCheckTimes).To(BeEmpty())
+}
+
+func TestNodeClockSkew(t *testing.T) {
+	now := time.Now()
+	lease := func(renewTime time.Time) *coordinationv1.Lease {
+		return &coordinationv1.Lease{Spec: coordinationv1.LeaseSpec{
+			RenewTime:            &metav1.MicroTime{Time: renewTime},
+			LeaseDurationSeconds: ptr.To[int32](40),
+		}}
+	}
+
+	tests := []struct {
+		name       string
+		heartbeats []time.Time
+		ready      bool
+		lease      *coordinationv1.Lease
+		expected   time.Duration
+	}{
+		{
+			name:     "no skew without conditions",
+			expected: 0,
+		},
+		{
+			name:       "no skew if heartbeat times are in the past",
+			heartbeats: []time.Time{now.Add(-time.Minute), now.Add(-time.Second)},
+			expected:   0,
+		},
+		{
+			name:       "skew is the heartbeat time furthest in the future",
+			heartbeats: []time.Time{now.Add(-time.Minute), now.Add(2 * time.Minute), now.Add(time.Minute)},
+			expected:   2 * time.Minute,
+		},
+		{
+			name:     "skew is the lease renew time in the future",
+			ready:    true,
+			lease:    lease(now.Add(2 * time.Minute)),
+			expected: 2 * time.Minute,
+		},
+		{
+			name:     "no skew if the lease renew time is in the past by less than the lease duration",
+			ready:    true,
+			lease:    lease(now.Add(-30 * time.Second)),
+			expected: 0,
+		},
+		{
+			name:     "skew is the lease renew time in the past by more than the lease duration",
+			ready:    true,
+			lease:    lease(now.Add(-2*time.Minute - 40*time.Second)),
+			expected: -2 * time.Minute,
+		},
+		{
+			name:       "the lease is ignored if the node is not ready",
+			heartbeats: []time.Time{now.Add(-time.Minute)},
+			lease:      lease(now.Add(-10 * time.Minute)),
+			expected:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			node := &corev1.Node{}
+			for _, h := range tt.heartbeats {
+				node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{LastHeartbeatTime: metav1.NewTime(h)})
+			}
+			if tt.ready {
+				node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue})
+			}
+			g.Expect(nodeClockSkew(node, tt.lease, now).Truncate(time.Second)).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestSetNodeClockSkewedCondition(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	recorder := record.NewFakeRecorder(5)
+	reconciler := &Reconciler{recorder: recorder}
+
+	machine := newTestMachine("machine1", "test-mhc", "cluster1", "node1", map[string]string{})
+	node := newTestNode("node1")
+	node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+	lease := &coordinationv1.Lease{Spec: coordinationv1.LeaseSpec{LeaseDurationSeconds: ptr.To[int32](40)}}
+	target := healthCheckTarget{Machine: machine, Node: node, NodeLease: lease}
+	setRenewTime := func(d time.Duration) {
+		lease.Spec.RenewTime = &metav1.MicroTime{Time: now.Add(d)}
+	}
+
+	// The condition is false if the clock of the node is in sync.
+	setRenewTime(0)
+	reconciler.setNodeClockSkewedCondition(ctrl.LoggerFrom(ctx), target, now)
+	g.Expect(v1beta2conditions.IsFalse(machine, clusterv1.MachineNodeClockSkewedV1Beta2Condition)).To(BeTrue())
+	g.Expect(recorder.Events).To(BeEmpty())
+
+	// The event is emitted only when the condition transitions to true, the condition is set on every reconcile.
+	setRenewTime(2 * time.Minute)
+	for range 2 {
+		reconciler.setNodeClockSkewedCondition(ctrl.LoggerFrom(ctx), target, now)
+		g.Expect(v1beta2conditions.IsTrue(machine, clusterv1.MachineNodeClockSkewedV1Beta2Condition)).To(BeTrue())
+	}
+	g.Expect(recorder.Events).To(HaveLen(1))
+	g.Expect(<-recorder.Events).To(ContainSubstring(EventDetectedNodeClockSkew))
+	g.Expect(v1beta2conditions.Get(machine, clusterv1.MachineNodeClockSkewedV1Beta2Condition).Message).To(Equal("Clock of Node node1 is ahead of the clock of the management cluster by more than 1m0s"))
+
+	// The condition stays true while the skew is above half of the threshold.
+	setRenewTime(45 * time.Second)
+	reconciler.setNodeClockSkewedCondition(ctrl.LoggerFrom(ctx), target, now)
+	g.Expect(v1beta2conditions.IsTrue(machine, clusterv1.MachineNodeClockSkewedV1Beta2Condition)).To(BeTrue())
+
+	// The condition is set back to false once the skew is below half of the threshold.
+	setRenewTime(10 * time.Second)
+	reconciler.setNodeClockSkewedCondition(ctrl.LoggerFrom(ctx), target, now)
+	g.Expect(v1beta2conditions.IsFalse(machine, clusterv1.MachineNodeClockSkewedV1Beta2Condition)).To(BeTrue())
+	g.Expect(recorder.Events).To(BeEmpty())
+
+	// A skew below the threshold does not set the condition to true.
+	setRenewTime(45 * time.Second)
+	reconciler.setNodeClockSkewedCondition(ctrl.LoggerFrom(ctx), target, now)
+	g.Expect(v1beta2conditions.IsFalse(machine, clusterv1.MachineNodeClockSkewedV1Beta2Condition)).To(BeTrue())
+
+	// A clock behind is detected as well.
+	setRenewTime(-3 * time.Minute)
+	reconciler.setNodeClockSkewedCondition(ctrl.LoggerFrom(ctx), target, now)
+	g.Expect(v1beta2conditions.IsTrue(machine, clusterv1.MachineNodeClockSkewedV1Beta2Condition)).To(BeTrue())
+	g.Expect(recorder.Events).To(HaveLen(1))
+	g.Expect(<-recorder.Events).To(ContainSubstring("is behind the clock of the management cluster"))
+}
+
 func newTestMachine(name, namespace, clusterName, nodeName string, labels map[string]string) *clusterv1.Machine {
 	// Copy the labels so that the map is unique to each test Machine
 	l := make(map[string]string)
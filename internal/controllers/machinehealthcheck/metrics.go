@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func init() {
+	// Register the metrics at the controller-runtime metrics registry.
+	ctrlmetrics.Registry.MustRegister(remediationsTotal)
+}
+
+// Metrics subsystem used by the MachineHealthCheck controller.
+const machineHealthCheckSubsystem = "capi_machinehealthcheck"
+
+const (
+	// remediationTypeOwner is used for remediations delegated to the owner controller of the Machine,
+	// e.g. the MachineSet or the KubeadmControlPlane controller.
+	remediationTypeOwner = "owner"
+
+	// remediationTypeExternal is used for remediations delegated to an external remediation controller.
+	remediationTypeExternal = "external"
+)
+
+var (
+	// remediationsTotal reports the number of remediations triggered by MachineHealthChecks.
+	remediationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: machineHealthCheckSubsystem,
+		Name:      "remediations_total",
+		Help:      "Number of remediations triggered for unhealthy Machines, partitioned by remediation type (owner or external).",
+	}, []string{"type"})
+)
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machineset
+
+import (
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// clusterLockedRequeueAfter is used to requeue a MachineSet when another MachineSet of the same Cluster is creating
+// or deleting Machines.
+const clusterLockedRequeueAfter = 5 * time.Second
+
+// clusterLocks serializes the creation and deletion of Machines and their external objects across the
+// MachineSets of a Cluster with the ClusterSerializeMachineSetMutationsAnnotation.
+type clusterLocks struct {
+	lock  sync.Mutex
+	locks map[client.ObjectKey]*sync.Mutex
+}
+
+// TryLock tries to acquire the lock for the given Cluster if it has the ClusterSerializeMachineSetMutationsAnnotation,
+// and returns the func to release it; ok is false if the lock is held by another MachineSet.
+// For Clusters without the annotation it is a no-op.
+// Note: The returned func can be called multiple times, e.g. to release the lock early.
+func (c *clusterLocks) TryLock(cluster *clusterv1.Cluster) (unlock func(), ok bool) {
+	if cluster == nil {
+		return func() {}, true
+	}
+	key := client.ObjectKeyFromObject(cluster)
+	if _, ok := cluster.Annotations[clusterv1.ClusterSerializeMachineSetMutationsAnnotation]; !ok {
+		c.Forget(key)
+		return func() {}, true
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.locks == nil {
+		c.locks = map[client.ObjectKey]*sync.Mutex{}
+	}
+	l, exists := c.locks[key]
+	if !exists {
+		l = &sync.Mutex{}
+		c.locks[key] = l
+	}
+	if !l.TryLock() {
+		return nil, false
+	}
+	return sync.OnceFunc(l.Unlock), true
+}
+
+// Forget drops the lock for the given Cluster if it is not held, e.g. once the
+// ClusterSerializeMachineSetMutationsAnnotation has been removed or the Cluster is being deleted.
+func (c *clusterLocks) Forget(key client.ObjectKey) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	l, exists := c.locks[key]
+	if !exists || !l.TryLock() {
+		return
+	}
+	delete(c.locks, key)
+	l.Unlock()
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machineset
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestClusterLocks(t *testing.T) {
+	cluster := func(name string, serialize bool) *clusterv1.Cluster {
+		c := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+			},
+		}
+		if serialize {
+			c.Annotations = map[string]string{clusterv1.ClusterSerializeMachineSetMutationsAnnotation: ""}
+		}
+		return c
+	}
+
+	t.Run("is a no-op for Clusters without the annotation", func(t *testing.T) {
+		g := NewWithT(t)
+
+		locks := &clusterLocks{}
+		unlock, ok := locks.TryLock(cluster("foo", false))
+		g.Expect(ok).To(BeTrue())
+		defer unlock()
+
+		unlock2, ok := locks.TryLock(cluster("foo", false))
+		g.Expect(ok).To(BeTrue())
+		unlock2()
+		g.Expect(locks.locks).To(BeEmpty())
+	})
+
+	t.Run("serializes callers for the same Cluster", func(t *testing.T) {
+		g := NewWithT(t)
+
+		locks := &clusterLocks{}
+		unlock, ok := locks.TryLock(cluster("foo", true))
+		g.Expect(ok).To(BeTrue())
+
+		_, ok = locks.TryLock(cluster("foo", true))
+		g.Expect(ok).To(BeFalse())
+
+		unlock()
+		// Releasing the lock again is a no-op.
+		unlock()
+
+		unlock2, ok := locks.TryLock(cluster("foo", true))
+		g.Expect(ok).To(BeTrue())
+		unlock2()
+	})
+
+	t.Run("does not serialize callers for different Clusters", func(t *testing.T) {
+		g := NewWithT(t)
+
+		locks := &clusterLocks{}
+		unlock, ok := locks.TryLock(cluster("foo", true))
+		g.Expect(ok).To(BeTrue())
+		defer unlock()
+
+		unlock2, ok := locks.TryLock(cluster("bar", true))
+		g.Expect(ok).To(BeTrue())
+		unlock2()
+	})
+
+	t.Run("drops the lock once the annotation has been removed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		locks := &clusterLocks{}
+		unlock, ok := locks.TryLock(cluster("foo", true))
+		g.Expect(ok).To(BeTrue())
+		unlock()
+		g.Expect(locks.locks).To(HaveLen(1))
+
+		unlock, ok = locks.TryLock(cluster("foo", false))
+		g.Expect(ok).To(BeTrue())
+		unlock()
+		g.Expect(locks.locks).To(BeEmpty())
+	})
+
+	t.Run("does not drop a lock which is held", func(t *testing.T) {
+		g := NewWithT(t)
+
+		locks := &clusterLocks{}
+		unlock, ok := locks.TryLock(cluster("foo", true))
+		g.Expect(ok).To(BeTrue())
+
+		locks.Forget(client.ObjectKeyFromObject(cluster("foo", true)))
+		g.Expect(locks.locks).To(HaveLen(1))
+		_, ok = locks.TryLock(cluster("foo", true))
+		g.Expect(ok).To(BeFalse())
+
+		unlock()
+		locks.Forget(client.ObjectKeyFromObject(cluster("foo", true)))
+		g.Expect(locks.locks).To(BeEmpty())
+	})
+}
@@ -37,6 +37,7 @@ import (
 	"k8s.io/apiserver/pkg/storage/names"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -85,6 +86,7 @@ const machineSetManagerName = "capi-machineset"
 //
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinesets;machinesets/status;machinesets/finalizers,verbs=get;list;watch;create;update;patch;delete
 
@@ -100,8 +102,13 @@ type Reconciler struct {
 	// Deprecated: DeprecatedInfraMachineNaming. Name the InfraStructureMachines after the InfraMachineTemplate.
 	DeprecatedInfraMachineNaming bool
 
-	ssaCache ssa.Cache
-	recorder record.EventRecorder
+	ssaCache     ssa.Cache
+	recorder     record.EventRecorder
+	clusterLocks clusterLocks
+
+	// clock is used to evaluate time-based logic like minReadySeconds and rolloutAfter.
+	// If not set, the real clock is used; tests can inject a fake clock to advance time deterministically.
+	clock clock.PassiveClock
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -147,6 +154,14 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	return nil
 }
 
+// now returns the current time according to the Reconciler's clock.
+func (r *Reconciler) now() time.Time {
+	if r.clock == nil {
+		return time.Now()
+	}
+	return r.clock.Now()
+}
+
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ctrl.Result, reterr error) {
 	machineSet := &clusterv1.MachineSet{}
 	if err := r.Client.Get(ctx, req.NamespacedName, machineSet); err != nil {
@@ -163,7 +178,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ct
 	ctx = ctrl.LoggerInto(ctx, log)
 
 	// Add finalizer first if not set to avoid the race condition between init and delete.
-	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, machineSet, clusterv1.MachineSetFinalizer); err != nil || finalizerAdded {
+	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, machineSet, clusterv1.MachineSetFinalizer, finalizers.WithEventRecorder(r.recorder)); err != nil || finalizerAdded {
 		return ctrl.Result{}, err
 	}
 
@@ -183,10 +198,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ct
 		return ctrl.Result{}, err
 	}
 
+	// Drop the lock used to serialize the creation and deletion of Machines once the Cluster is being deleted.
+	if !cluster.DeletionTimestamp.IsZero() {
+		r.clusterLocks.Forget(client.ObjectKeyFromObject(cluster))
+	}
+
 	s := &scope{
 		cluster:            cluster,
 		machineSet:         machineSet,
-		reconciliationTime: time.Now(),
+		reconciliationTime: r.now(),
 	}
 
 	// Initialize the patch helper
@@ -684,6 +704,36 @@ func (r *Reconciler) syncReplicas(ctx context.Context, s *scope) (ctrl.Result, e
 			return result, err
 		}
 
+		// Serialize the creation of Machines and their external objects with other MachineSets of the same Cluster, if requested.
+		unlock, ok := r.clusterLocks.TryLock(cluster)
+		if !ok {
+			log.V(4).Info("Waiting for other MachineSets of the Cluster to complete creating or deleting Machines")
+			return ctrl.Result{RequeueAfter: clusterLockedRequeueAfter}, nil
+		}
+		defer unlock()
+
+		// If the MachineSet uses a Machine name pool, get the names for the new Machines.
+		var (
+			poolNames         []string
+			namePoolExhausted bool
+		)
+		poolName, usesNamePool := ms.Annotations[clusterv1.MachineSetMachineNamePoolAnnotation]
+		if usesNamePool {
+			poolNames, err = r.getFreeMachineNamesFromPool(ctx, ms, poolName)
+			if err != nil {
+				conditions.MarkFalse(ms, clusterv1.MachinesCreatedCondition, clusterv1.MachineNamePoolUnavailableReason, clusterv1.ConditionSeverityError, err.Error())
+				return ctrl.Result{}, err
+			}
+			if len(poolNames) < diff {
+				message := fmt.Sprintf("Machine name pool %s has %d free names, %d are required", klog.KRef(ms.Namespace, poolName), len(poolNames), diff)
+				log.Info(message)
+				r.recorder.Event(ms, corev1.EventTypeWarning, clusterv1.MachineNamePoolExhaustedReason, message)
+				conditions.MarkFalse(ms, clusterv1.MachinesCreatedCondition, clusterv1.MachineNamePoolExhaustedReason, clusterv1.ConditionSeverityWarning, message)
+				diff = len(poolNames)
+				namePoolExhausted = true
+			}
+		}
+
 		var (
 			machineList []*clusterv1.Machine
 			errs        []error
@@ -693,6 +743,9 @@ func (r *Reconciler) syncReplicas(ctx context.Context, s *scope) (ctrl.Result, e
 			// Create a new logger so the global logger is not modified.
 			log := log
 			machine := r.computeDesiredMachine(ms, nil)
+			if usesNamePool {
+				machine.Name = poolNames[i]
+			}
 			// Clone and set the infrastructure and bootstrap references.
 			var (
 				infraRef, bootstrapRef *corev1.ObjectReference
@@ -748,6 +801,13 @@ func (r *Reconciler) syncReplicas(ctx context.Context, s *scope) (ctrl.Result, e
 			})
 			if err != nil {
 				conditions.MarkFalse(ms, clusterv1.MachinesCreatedCondition, clusterv1.InfrastructureTemplateCloningFailedReason, clusterv1.ConditionSeverityError, err.Error())
+
+				// Try to cleanup the BootstrapConfig if the InfraMachine creation failed, so it is not left dangling.
+				if bootstrapRef != nil {
+					if err := r.Client.Delete(ctx, util.ObjectReferenceToUnstructured(*bootstrapRef)); err != nil && !apierrors.IsNotFound(err) {
+						log.Error(err, "Failed to cleanup bootstrap configuration object after infrastructure machine creation error", bootstrapRef.Kind, klog.KRef(bootstrapRef.Namespace, bootstrapRef.Name))
+					}
+				}
 				return ctrl.Result{}, errors.Wrapf(err, "failed to clone infrastructure machine from %s %s while creating a machine",
 					ms.Spec.Template.Spec.InfrastructureRef.Kind,
 					klog.KRef(ms.Spec.Template.Spec.InfrastructureRef.Namespace, ms.Spec.Template.Spec.InfrastructureRef.Name))
@@ -764,11 +824,11 @@ func (r *Reconciler) syncReplicas(ctx context.Context, s *scope) (ctrl.Result, e
 					clusterv1.ConditionSeverityError, err.Error())
 
 				// Try to cleanup the external objects if the Machine creation failed.
-				if err := r.Client.Delete(ctx, util.ObjectReferenceToUnstructured(*infraRef)); !apierrors.IsNotFound(err) {
+				if err := r.Client.Delete(ctx, util.ObjectReferenceToUnstructured(*infraRef)); err != nil && !apierrors.IsNotFound(err) {
 					log.Error(err, "Failed to cleanup infrastructure machine object after Machine creation error", infraRef.Kind, klog.KRef(infraRef.Namespace, infraRef.Name))
 				}
 				if bootstrapRef != nil {
-					if err := r.Client.Delete(ctx, util.ObjectReferenceToUnstructured(*bootstrapRef)); !apierrors.IsNotFound(err) {
+					if err := r.Client.Delete(ctx, util.ObjectReferenceToUnstructured(*bootstrapRef)); err != nil && !apierrors.IsNotFound(err) {
 						log.Error(err, "Failed to cleanup bootstrap configuration object after Machine creation error", bootstrapRef.Kind, klog.KRef(bootstrapRef.Namespace, bootstrapRef.Name))
 					}
 				}
@@ -783,7 +843,16 @@ func (r *Reconciler) syncReplicas(ctx context.Context, s *scope) (ctrl.Result, e
 		if len(errs) > 0 {
 			return ctrl.Result{}, kerrors.NewAggregate(errs)
 		}
-		return ctrl.Result{}, r.waitForMachineCreation(ctx, machineList)
+		// Release the lock before waiting for the cache, so other MachineSets of the Cluster are not blocked.
+		unlock()
+		if err := r.waitForMachineCreation(ctx, machineList); err != nil {
+			return ctrl.Result{}, err
+		}
+		if namePoolExhausted {
+			// Changes to the ConfigMap are not watched, check periodically for names added to the pool.
+			return ctrl.Result{RequeueAfter: machineNamePoolExhaustedRequeueAfter}, nil
+		}
+		return ctrl.Result{}, nil
 	case diff > 0:
 		log.Info(fmt.Sprintf("MachineSet is scaling down to %d replicas by deleting %d machines", *(ms.Spec.Replicas), diff), "replicas", *(ms.Spec.Replicas), "machineCount", len(machines), "deletePolicy", ms.Spec.DeletePolicy)
 
@@ -792,6 +861,14 @@ func (r *Reconciler) syncReplicas(ctx context.Context, s *scope) (ctrl.Result, e
 			return ctrl.Result{}, err
 		}
 
+		// Serialize the deletion of Machines with other MachineSets of the same Cluster, if requested.
+		unlock, ok := r.clusterLocks.TryLock(cluster)
+		if !ok {
+			log.V(4).Info("Waiting for other MachineSets of the Cluster to complete creating or deleting Machines")
+			return ctrl.Result{RequeueAfter: clusterLockedRequeueAfter}, nil
+		}
+		defer unlock()
+
 		var errs []error
 		machinesToDelete := getMachinesToDeletePrioritized(machines, diff, deletePriorityFunc)
 		for i, machine := range machinesToDelete {
@@ -813,6 +890,8 @@ func (r *Reconciler) syncReplicas(ctx context.Context, s *scope) (ctrl.Result, e
 		if len(errs) > 0 {
 			return ctrl.Result{}, kerrors.NewAggregate(errs)
 		}
+		// Release the lock before waiting for the cache, so other MachineSets of the Cluster are not blocked.
+		unlock()
 		return ctrl.Result{}, r.waitForMachineDeletion(ctx, machinesToDelete)
 	}
 
@@ -891,6 +970,7 @@ func (r *Reconciler) computeDesiredMachine(machineSet *clusterv1.MachineSet, exi
 	desiredMachine.Spec.NodeDrainTimeout = machineSet.Spec.Template.Spec.NodeDrainTimeout
 	desiredMachine.Spec.NodeDeletionTimeout = machineSet.Spec.Template.Spec.NodeDeletionTimeout
 	desiredMachine.Spec.NodeVolumeDetachTimeout = machineSet.Spec.Template.Spec.NodeVolumeDetachTimeout
+	desiredMachine.Spec.Taints = machineSet.Spec.Template.Spec.Taints
 
 	return desiredMachine
 }
@@ -972,7 +1052,7 @@ func shouldExcludeMachine(machineSet *clusterv1.MachineSet, machine *clusterv1.M
 func (r *Reconciler) adoptOrphan(ctx context.Context, machineSet *clusterv1.MachineSet, machine *clusterv1.Machine) error {
 	patch := client.MergeFrom(machine.DeepCopy())
 	newRef := *metav1.NewControllerRef(machineSet, machineSetKind)
-	machine.SetOwnerReferences(util.EnsureOwnerRef(machine.GetOwnerReferences(), newRef))
+	machine.SetOwnerReferences(util.EnsureControllerRef(machine.GetOwnerReferences(), newRef))
 	return r.Client.Patch(ctx, machine, patch)
 }
 
@@ -1113,11 +1193,22 @@ func (r *Reconciler) shouldAdopt(ms *clusterv1.MachineSet) bool {
 // reconcileStatus updates the Status field for the MachineSet
 // It checks for the current state of the replicas and updates the Status of the MachineSet.
 func (r *Reconciler) reconcileStatus(ctx context.Context, s *scope) error {
+	ms := s.machineSet
+
+	// Copy label selector to its status counterpart in string format.
+	// This is necessary for CRDs including scale subresources.
+	// NOTE: The selector does not depend on the Machines, so it is set even if listing Machines failed,
+	// so that the scale subresource is usable as soon as possible.
+	selector, err := metav1.LabelSelectorAsSelector(&ms.Spec.Selector)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update status for MachineSet %s/%s", ms.Namespace, ms.Name)
+	}
+	ms.Status.Selector = selector.String()
+
 	if !s.getAndAdoptMachinesForMachineSetSucceeded {
 		return nil
 	}
 
-	ms := s.machineSet
 	filteredMachines := s.machines
 	cluster := s.cluster
 
@@ -1128,14 +1219,6 @@ func (r *Reconciler) reconcileStatus(ctx context.Context, s *scope) error {
 	log := ctrl.LoggerFrom(ctx)
 	newStatus := ms.Status.DeepCopy()
 
-	// Copy label selector to its status counterpart in string format.
-	// This is necessary for CRDs including scale subresources.
-	selector, err := metav1.LabelSelectorAsSelector(&ms.Spec.Selector)
-	if err != nil {
-		return errors.Wrapf(err, "failed to update status for MachineSet %s/%s", ms.Namespace, ms.Name)
-	}
-	newStatus.Selector = selector.String()
-
 	// Count the number of machines that have labels matching the labels of the machine
 	// template of the replica set, the matching machines may have more
 	// labels than are in the template. Because the label of machineTemplateSpec is
@@ -1170,7 +1253,7 @@ func (r *Reconciler) reconcileStatus(ctx context.Context, s *scope) error {
 
 		if noderefutil.IsNodeReady(node) {
 			readyReplicasCount++
-			if noderefutil.IsNodeAvailable(node, ms.Spec.MinReadySeconds, metav1.Now()) {
+			if noderefutil.IsNodeAvailable(node, ms.Spec.MinReadySeconds, metav1.NewTime(r.now())) {
 				availableReplicasCount++
 			}
 		} else if machine.GetDeletionTimestamp().IsZero() {
@@ -989,6 +989,100 @@ func TestMachineSetReconcile_MachinesCreatedConditionFalseOnBadInfraRef(t *testi
 	g.Expect(gotCond.Reason).To(Equal(clusterv1.InfrastructureTemplateCloningFailedReason))
 }
 
+func TestMachineSetReconcile_CleanupBootstrapConfigOnBadInfraRef(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	bootstrapTmpl := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind":       builder.GenericBootstrapConfigTemplateKind,
+			"apiVersion": builder.BootstrapGroupVersion.String(),
+			"metadata": map[string]interface{}{
+				"name":      "ms-template",
+				"namespace": metav1.NamespaceDefault,
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{},
+				},
+			},
+		},
+	}
+
+	ms := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ms-foo",
+			Namespace: metav1.NamespaceDefault,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: cluster.Name,
+			},
+		},
+		Spec: clusterv1.MachineSetSpec{
+			ClusterName: cluster.Name,
+			Replicas:    ptr.To[int32](1),
+			Template: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{
+					Labels: map[string]string{
+						clusterv1.ClusterNameLabel: cluster.Name,
+					},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &corev1.ObjectReference{
+							Kind:       builder.GenericBootstrapConfigTemplateKind,
+							APIVersion: builder.BootstrapGroupVersion.String(),
+							Name:       bootstrapTmpl.GetName(),
+							Namespace:  cluster.Namespace,
+						},
+					},
+					InfrastructureRef: corev1.ObjectReference{
+						Kind:       builder.GenericInfrastructureMachineTemplateCRD.Kind,
+						APIVersion: builder.GenericInfrastructureMachineTemplateCRD.APIVersion,
+						// Try to break Infra Cloning
+						Name:      "something_invalid",
+						Namespace: cluster.Namespace,
+					},
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		cluster,
+		ms,
+		bootstrapTmpl,
+		builder.GenericBootstrapConfigTemplateCRD.DeepCopy(),
+		builder.GenericBootstrapConfigCRD.DeepCopy(),
+		builder.GenericInfrastructureMachineTemplateCRD.DeepCopy(),
+	).WithStatusSubresource(&clusterv1.MachineSet{}).Build()
+
+	r := &Reconciler{
+		Client:   fakeClient,
+		recorder: record.NewFakeRecorder(32),
+	}
+	s := &scope{
+		cluster:    cluster,
+		machineSet: ms,
+		machines:   []*clusterv1.Machine{},
+		getAndAdoptMachinesForMachineSetSucceeded: true,
+	}
+	_, err := r.syncReplicas(ctx, s)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(conditions.GetReason(ms, clusterv1.MachinesCreatedCondition)).To(Equal(clusterv1.InfrastructureTemplateCloningFailedReason))
+
+	// Verify the BootstrapConfig cloned before the InfraMachine creation failed has been deleted.
+	bootstrapConfigs := &unstructured.UnstructuredList{}
+	bootstrapConfigs.SetAPIVersion(builder.BootstrapGroupVersion.String())
+	bootstrapConfigs.SetKind(builder.GenericBootstrapConfigKind + "List")
+	g.Expect(fakeClient.List(ctx, bootstrapConfigs)).To(Succeed())
+	g.Expect(bootstrapConfigs.Items).To(BeEmpty())
+}
+
 func TestMachineSetReconciler_updateStatusResizedCondition(t *testing.T) {
 	cluster := &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1056,6 +1150,27 @@ func TestMachineSetReconciler_updateStatusResizedCondition(t *testing.T) {
 	}
 }
 
+func TestMachineSetReconciler_reconcileStatusSelector(t *testing.T) {
+	g := NewWithT(t)
+
+	machineSet := newMachineSet("ms", "foo", int32(1))
+	machineSet.Spec.Selector = metav1.LabelSelector{
+		MatchLabels: map[string]string{"foo": "bar"},
+	}
+
+	msr := &Reconciler{
+		Client:   fake.NewClientBuilder().Build(),
+		recorder: record.NewFakeRecorder(32),
+	}
+	s := &scope{
+		machineSet: machineSet,
+		// The selector must be set even if listing Machines failed.
+		getAndAdoptMachinesForMachineSetSucceeded: false,
+	}
+	g.Expect(msr.reconcileStatus(ctx, s)).To(Succeed())
+	g.Expect(machineSet.Status.Selector).To(Equal("foo=bar"))
+}
+
 func TestMachineSetReconciler_syncMachines(t *testing.T) {
 	setup := func(t *testing.T, g *WithT) (*corev1.Namespace, *clusterv1.Cluster) {
 		t.Helper()
@@ -2342,6 +2457,69 @@ func TestMachineSetReconciler_syncReplicas(t *testing.T) {
 		g.Expect(r.Client.List(ctx, machineList)).To(Succeed())
 		g.Expect(machineList.Items).To(BeEmpty(), "There should not be any machines")
 	})
+
+	t.Run("should requeue when another MachineSet of the Cluster is creating or deleting machines", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "default",
+				Annotations: map[string]string{
+					clusterv1.ClusterSerializeMachineSetMutationsAnnotation: "",
+				},
+			},
+		}
+		machineSet := &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-machineset",
+				Namespace: "default",
+			},
+			Spec: clusterv1.MachineSetSpec{
+				Replicas: ptr.To[int32](1),
+			},
+		}
+		machine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-machine",
+				Namespace: "default",
+			},
+		}
+
+		fakeClient := fake.NewClientBuilder().WithObjects(machineSet, machine).WithStatusSubresource(&clusterv1.MachineSet{}).Build()
+		r := &Reconciler{
+			Client: fakeClient,
+		}
+
+		// Simulate another MachineSet of the Cluster holding the lock.
+		unlock, ok := r.clusterLocks.TryLock(cluster)
+		g.Expect(ok).To(BeTrue())
+		defer unlock()
+
+		// Scale up.
+		s := &scope{
+			cluster:    cluster,
+			machineSet: machineSet,
+			machines:   []*clusterv1.Machine{},
+			getAndAdoptMachinesForMachineSetSucceeded: true,
+		}
+		result, err := r.syncReplicas(ctx, s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(BeComparableTo(reconcile.Result{RequeueAfter: clusterLockedRequeueAfter}))
+
+		// Scale down.
+		machineSet.Spec.Replicas = ptr.To[int32](0)
+		s.machines = []*clusterv1.Machine{machine}
+		result, err = r.syncReplicas(ctx, s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(BeComparableTo(reconcile.Result{RequeueAfter: clusterLockedRequeueAfter}))
+
+		// Verify no Machines are created or deleted.
+		machineList := &clusterv1.MachineList{}
+		g.Expect(r.Client.List(ctx, machineList)).To(Succeed())
+		g.Expect(machineList.Items).To(HaveLen(1))
+		g.Expect(machineList.Items[0].DeletionTimestamp.IsZero()).To(BeTrue())
+	})
 }
 
 func TestComputeDesiredMachine(t *testing.T) {
@@ -2388,6 +2566,7 @@ func TestComputeDesiredMachine(t *testing.T) {
 					NodeDrainTimeout:        duration10s,
 					NodeVolumeDetachTimeout: duration10s,
 					NodeDeletionTimeout:     duration10s,
+					Taints:                  []corev1.Taint{{Key: "foo", Effect: corev1.TaintEffectNoSchedule}},
 				},
 			},
 		},
@@ -2410,6 +2589,7 @@ func TestComputeDesiredMachine(t *testing.T) {
 			NodeDrainTimeout:        duration10s,
 			NodeVolumeDetachTimeout: duration10s,
 			NodeDeletionTimeout:     duration10s,
+			Taints:                  []corev1.Taint{{Key: "foo", Effect: corev1.TaintEffectNoSchedule}},
 		},
 	}
 
@@ -2437,6 +2617,7 @@ func TestComputeDesiredMachine(t *testing.T) {
 	existingMachine.Spec.NodeDrainTimeout = duration5s
 	existingMachine.Spec.NodeDeletionTimeout = duration5s
 	existingMachine.Spec.NodeVolumeDetachTimeout = duration5s
+	existingMachine.Spec.Taints = []corev1.Taint{{Key: "bar", Effect: corev1.TaintEffectNoSchedule}}
 
 	expectedUpdatedMachine := skeletonMachine.DeepCopy()
 	expectedUpdatedMachine.Name = existingMachine.Name
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machineset
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// machineNamePoolExhaustedRequeueAfter is used to requeue the MachineSet to check for new names when
+// its Machine name pool does not have enough free names.
+const machineNamePoolExhaustedRequeueAfter = time.Minute
+
+// getFreeMachineNamesFromPool returns the names from the Machine name pool of the MachineSet which are not
+// used by any Machine in the MachineSet namespace, in the order they are listed in the pool.
+// The pool is read with the APIReader to avoid caching all the ConfigMaps of the management cluster.
+func (r *Reconciler) getFreeMachineNamesFromPool(ctx context.Context, ms *clusterv1.MachineSet, poolName string) ([]string, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := r.APIReader.Get(ctx, client.ObjectKey{Namespace: ms.Namespace, Name: poolName}, configMap); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Machine name pool ConfigMap %s", klog.KRef(ms.Namespace, poolName))
+	}
+	pool, ok := configMap.Data[clusterv1.MachineSetMachineNamePoolConfigMapKey]
+	if !ok {
+		return nil, errors.Errorf("Machine name pool ConfigMap %s does not have the %q key", klog.KObj(configMap), clusterv1.MachineSetMachineNamePoolConfigMapKey)
+	}
+
+	machineList := &clusterv1.MachineList{}
+	if err := r.Client.List(ctx, machineList, client.InNamespace(ms.Namespace)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list Machines in namespace %s", ms.Namespace)
+	}
+	usedNames := sets.Set[string]{}
+	for _, m := range machineList.Items {
+		usedNames.Insert(m.Name)
+	}
+
+	return parseMachineNamePool(pool, usedNames), nil
+}
+
+// parseMachineNamePool returns the names listed in a Machine name pool, one per line, skipping empty lines,
+// duplicates and names in usedNames.
+func parseMachineNamePool(pool string, usedNames sets.Set[string]) []string {
+	free := []string{}
+	seen := sets.Set[string]{}
+	for _, line := range strings.Split(pool, "\n") {
+		name := strings.TrimSpace(line)
+		if name == "" || seen.Has(name) {
+			continue
+		}
+		seen.Insert(name)
+		if usedNames.Has(name) {
+			continue
+		}
+		free = append(free, name)
+	}
+	return free
+}
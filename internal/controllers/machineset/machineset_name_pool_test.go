@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machineset
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestParseMachineNamePool(t *testing.T) {
+	tests := []struct {
+		name      string
+		pool      string
+		usedNames sets.Set[string]
+		want      []string
+	}{
+		{
+			name: "empty pool",
+			pool: "",
+			want: []string{},
+		},
+		{
+			name: "skips empty lines, whitespace and duplicates",
+			pool: "host-1\n\n  host-2 \nhost-1\nhost-3\n",
+			want: []string{"host-1", "host-2", "host-3"},
+		},
+		{
+			name:      "skips used names",
+			pool:      "host-1\nhost-2\nhost-3",
+			usedNames: sets.New("host-2"),
+			want:      []string{"host-1", "host-3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(parseMachineNamePool(tt.pool, tt.usedNames)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetFreeMachineNamesFromPool(t *testing.T) {
+	ms := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ms",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	pool := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Data: map[string]string{
+			clusterv1.MachineSetMachineNamePoolConfigMapKey: "host-1\nhost-2\nhost-3",
+		},
+	}
+	usedMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "host-1",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	otherNamespaceMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "host-2",
+			Namespace: "other",
+		},
+	}
+
+	t.Run("returns names not used by Machines in the MachineSet namespace", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := fake.NewClientBuilder().WithObjects(pool, usedMachine, otherNamespaceMachine).Build()
+		r := &Reconciler{Client: c, APIReader: c}
+
+		names, err := r.getFreeMachineNamesFromPool(ctx, ms, pool.Name)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(names).To(Equal([]string{"host-2", "host-3"}))
+	})
+
+	t.Run("fails if the ConfigMap does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := fake.NewClientBuilder().Build()
+		r := &Reconciler{Client: c, APIReader: c}
+
+		_, err := r.getFreeMachineNamesFromPool(ctx, ms, pool.Name)
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("fails if the ConfigMap does not have the names key", func(t *testing.T) {
+		g := NewWithT(t)
+
+		invalidPool := pool.DeepCopy()
+		invalidPool.Data = map[string]string{"hosts": "host-1"}
+		c := fake.NewClientBuilder().WithObjects(invalidPool).Build()
+		r := &Reconciler{Client: c, APIReader: c}
+
+		_, err := r.getFreeMachineNamesFromPool(ctx, ms, pool.Name)
+		g.Expect(err).To(MatchError(ContainSubstring("does not have the \"names\" key")))
+	})
+}
@@ -31,6 +31,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
@@ -116,6 +117,17 @@ func (r *Reconciler) runPreflightChecks(ctx context.Context, cluster *clusterv1.
 			}
 		}
 
+		// Run the kubernetes-version downgrade preflight check.
+		if !skipped.Has(clusterv1.MachineSetPreflightCheckKubernetesVersionDowngrade) {
+			preflightCheckErr, err := r.kubernetesVersionDowngradePreflightCheck(ctx, msSemver, ms)
+			if err != nil {
+				errList = append(errList, err)
+			}
+			if preflightCheckErr != nil {
+				preflightCheckErrs = append(preflightCheckErrs, preflightCheckErr)
+			}
+		}
+
 		// Run the kubeadm-version skew preflight check.
 		if !skipped.Has(clusterv1.MachineSetPreflightCheckKubeadmVersionSkew) {
 			preflightCheckErr, err := r.kubeadmVersionPreflightCheck(cpSemver, msSemver, ms)
@@ -186,6 +198,42 @@ func (r *Reconciler) kubernetesVersionPreflightCheck(cpSemver, msSemver semver.V
 	return nil
 }
 
+func (r *Reconciler) kubernetesVersionDowngradePreflightCheck(ctx context.Context, msSemver semver.Version, ms *clusterv1.MachineSet) (preflightCheckErrorMessage, error) {
+	// If the MachineSet does not belong to a MachineDeployment return early.
+	mdName, ok := ms.Labels[clusterv1.MachineDeploymentNameLabel]
+	if !ok {
+		return nil, nil
+	}
+
+	// Check that the MS minor version is not lower than the minor version of older MachineSets of the same MachineDeployment
+	// which still have machines.
+	// Note: MachineSets created after this MachineSet are not considered, so e.g. the remediation of machines of old
+	// MachineSets is not blocked during a MachineDeployment upgrade.
+	machineSets := &clusterv1.MachineSetList{}
+	if err := r.Client.List(ctx, machineSets, client.InNamespace(ms.Namespace), client.MatchingLabels{
+		clusterv1.ClusterNameLabel:           ms.Spec.ClusterName,
+		clusterv1.MachineDeploymentNameLabel: mdName,
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to perform %q preflight check: failed to list MachineSets of MachineDeployment %s", clusterv1.MachineSetPreflightCheckKubernetesVersionDowngrade, klog.KRef(ms.Namespace, mdName))
+	}
+	for i := range machineSets.Items {
+		otherMS := &machineSets.Items[i]
+		if otherMS.Name == ms.Name || !otherMS.CreationTimestamp.Before(&ms.CreationTimestamp) || otherMS.Spec.Template.Spec.Version == nil ||
+			(ptr.Deref(otherMS.Spec.Replicas, 0) == 0 && otherMS.Status.Replicas == 0) {
+			continue
+		}
+		otherSemver, err := semver.ParseTolerant(*otherMS.Spec.Template.Spec.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to perform %q preflight check: failed to parse version %q of MachineSet %s", clusterv1.MachineSetPreflightCheckKubernetesVersionDowngrade, *otherMS.Spec.Template.Spec.Version, klog.KObj(otherMS))
+		}
+		if msSemver.Major == otherSemver.Major && msSemver.Minor < otherSemver.Minor {
+			return ptr.To(fmt.Sprintf("MachineSet version (%s) is a minor version downgrade of the version (%s) of MachineSet %s of the same MachineDeployment (%q preflight check failed)", msSemver.String(), otherSemver.String(), otherMS.Name, clusterv1.MachineSetPreflightCheckKubernetesVersionDowngrade)), nil
+		}
+	}
+
+	return nil, nil
+}
+
 func (r *Reconciler) kubeadmVersionPreflightCheck(cpSemver, msSemver semver.Version, ms *clusterv1.MachineSet) (preflightCheckErrorMessage, error) {
 	// If the bootstrap.configRef is nil return early.
 	if ms.Spec.Template.Spec.Bootstrap.ConfigRef == nil {
@@ -18,6 +18,7 @@ package machineset
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -73,6 +74,7 @@ func TestMachineSetReconciler_runPreflightChecks(t *testing.T) {
 			cluster                      *clusterv1.Cluster
 			controlPlane                 *unstructured.Unstructured
 			machineSet                   *clusterv1.MachineSet
+			objs                         []client.Object
 			wantPass                     bool
 			wantPreflightCheckErrMessage string
 			wantErr                      bool
@@ -249,6 +251,58 @@ func TestMachineSetReconciler_runPreflightChecks(t *testing.T) {
 				},
 				wantErr: true,
 			},
+			{
+				name: "kubernetes version downgrade preflight check: should fail if the machine set minor version is lower than an older machine set of the same machine deployment",
+				cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: ns,
+					},
+					Spec: clusterv1.ClusterSpec{
+						ControlPlaneRef: contract.ObjToRef(controlPlaneStable),
+					},
+				},
+				controlPlane: controlPlaneStable,
+				machineSet:   machineSetOfMachineDeployment(ns, "ms2", "v1.25.0", time.Now(), 0),
+				objs: []client.Object{
+					machineSetOfMachineDeployment(ns, "ms1", "v1.26.0", time.Now().Add(-time.Hour), 1),
+				},
+				wantPass:                     false,
+				wantPreflightCheckErrMessage: "MachineSet version (1.25.0) is a minor version downgrade of the version (1.26.0) of MachineSet ms1 of the same MachineDeployment (\"KubernetesVersionDowngrade\" preflight check failed)",
+			},
+			{
+				name: "kubernetes version downgrade preflight check: should pass if the machine set minor version is lower than a newer machine set of the same machine deployment",
+				cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: ns,
+					},
+					Spec: clusterv1.ClusterSpec{
+						ControlPlaneRef: contract.ObjToRef(controlPlaneStable),
+					},
+				},
+				controlPlane: controlPlaneStable,
+				machineSet:   machineSetOfMachineDeployment(ns, "ms1", "v1.25.0", time.Now().Add(-time.Hour), 1),
+				objs: []client.Object{
+					machineSetOfMachineDeployment(ns, "ms2", "v1.26.0", time.Now(), 1),
+				},
+				wantPass: true,
+			},
+			{
+				name: "kubernetes version downgrade preflight check: should pass if the older machine set with a greater minor version has no machines",
+				cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: ns,
+					},
+					Spec: clusterv1.ClusterSpec{
+						ControlPlaneRef: contract.ObjToRef(controlPlaneStable),
+					},
+				},
+				controlPlane: controlPlaneStable,
+				machineSet:   machineSetOfMachineDeployment(ns, "ms2", "v1.25.0", time.Now(), 0),
+				objs: []client.Object{
+					machineSetOfMachineDeployment(ns, "ms1", "v1.26.0", time.Now().Add(-time.Hour), 0),
+				},
+				wantPass: true,
+			},
 			{
 				name: "kubernetes version preflight check: should fail if the machine set minor version is greater than control plane minor version",
 				cluster: &clusterv1.Cluster{
@@ -559,6 +613,7 @@ func TestMachineSetReconciler_runPreflightChecks(t *testing.T) {
 				if tt.controlPlane != nil {
 					objs = append(objs, tt.controlPlane)
 				}
+				objs = append(objs, tt.objs...)
 				fakeClient := fake.NewClientBuilder().WithObjects(objs...).Build()
 				r := &Reconciler{
 					Client: fakeClient,
@@ -611,3 +666,26 @@ func TestMachineSetReconciler_runPreflightChecks(t *testing.T) {
 		g.Expect(result.IsZero()).To(BeTrue())
 	})
 }
+
+func machineSetOfMachineDeployment(namespace, name, version string, creationTimestamp time.Time, replicas int32) *clusterv1.MachineSet {
+	return &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(creationTimestamp),
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:           "cluster1",
+				clusterv1.MachineDeploymentNameLabel: "md1",
+			},
+		},
+		Spec: clusterv1.MachineSetSpec{
+			ClusterName: "cluster1",
+			Replicas:    ptr.To(replicas),
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Version: ptr.To(version),
+				},
+			},
+		},
+	}
+}
@@ -43,6 +43,7 @@ import (
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
 	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
@@ -474,6 +475,9 @@ func httpCall(ctx context.Context, request, response runtime.Object, opts *httpC
 	if err != nil {
 		return errors.Wrap(err, "http call failed: failed to create http request")
 	}
+	if reconcileID := controller.ReconcileIDFromContext(ctx); reconcileID != "" {
+		httpRequest.Header.Set(runtimehooksv1.ReconcileIDHeader, string(reconcileID))
+	}
 
 	// Use client-go's transport.TLSConfigureFor to ensure good defaults for tls
 	client := http.DefaultClient
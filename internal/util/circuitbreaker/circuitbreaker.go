@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package circuitbreaker implements a reconciler wrapper which stops hot-looping on objects
+// for which reconcile keeps failing.
+package circuitbreaker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// DefaultParkingInterval is the interval after which a parked object is reconciled again.
+const DefaultParkingInterval = 5 * time.Minute
+
+// Object is an object the circuit breaker can report the ReconcileStuck condition on.
+type Object interface {
+	client.Object
+	conditions.Setter
+}
+
+// Reconciler wraps a reconciler and counts the consecutive errors and panics of its reconciles per object.
+// Once Threshold is reached the object is parked: the ReconcileStuck condition is set on the object, an event
+// is emitted and the object is only reconciled again after ParkingInterval instead of being requeued with
+// the usual exponential backoff. A successful reconcile closes the circuit and removes the condition.
+type Reconciler struct {
+	// Client is used to set the ReconcileStuck condition on parked objects.
+	Client client.Client
+
+	// Recorder is used to emit an event when an object is parked.
+	Recorder record.EventRecorder
+
+	// Reconciler is the wrapped reconciler.
+	Reconciler reconcile.Reconciler
+
+	// NewObject returns an empty instance of the type reconciled by Reconciler.
+	NewObject func() Object
+
+	// Threshold is the number of consecutive failed reconciles after which an object is parked.
+	// If Threshold is not greater than 0 the circuit breaker is disabled.
+	Threshold int
+
+	// ParkingInterval is the interval after which a parked object is reconciled again.
+	// Defaults to DefaultParkingInterval.
+	ParkingInterval time.Duration
+
+	// Name is used to partition the metrics, e.g. the name of the controller.
+	Name string
+
+	lock     sync.Mutex
+	failures map[types.NamespacedName]int
+}
+
+// Reconcile implements reconcile.Reconciler.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	if r.Threshold <= 0 {
+		return r.Reconciler.Reconcile(ctx, req)
+	}
+
+	res, err := r.reconcile(ctx, req)
+	if err == nil {
+		if r.reset(req.NamespacedName) >= r.Threshold {
+			// The circuit was open, remove the ReconcileStuck condition.
+			return res, r.setCondition(ctx, req, nil)
+		}
+		return res, nil
+	}
+
+	// Forget objects which have been deleted in the meantime, so their failures are not kept forever.
+	if getErr := r.Client.Get(ctx, req.NamespacedName, r.NewObject()); apierrors.IsNotFound(getErr) {
+		r.reset(req.NamespacedName)
+		return res, err
+	}
+
+	failures := r.recordFailure(req.NamespacedName)
+	if failures < r.Threshold {
+		return res, err
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	log.Error(err, fmt.Sprintf("Reconcile failed %d times in a row, parking object", failures))
+	parkedTotal.WithLabelValues(r.Name).Inc()
+
+	// NOTE: the message must not change across failed reconciles, otherwise each patch would trigger a new reconcile.
+	// For the same reason the error is only logged above and not included in the message.
+	message := fmt.Sprintf("Reconcile failed at least %d times in a row, see the controller logs for details", r.Threshold)
+	condition := &clusterv1.Condition{
+		Type:     clusterv1.ReconcileStuckCondition,
+		Status:   corev1.ConditionTrue,
+		Severity: clusterv1.ConditionSeverityWarning,
+		Reason:   clusterv1.ReconcileFailureThresholdExceededReason,
+		Message:  message,
+	}
+	if err := r.setCondition(ctx, req, condition); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: r.parkingInterval()}, nil
+}
+
+// reconcile calls the wrapped reconciler, converting panics to errors.
+func (r *Reconciler) reconcile(ctx context.Context, req reconcile.Request) (res ctrl.Result, reterr error) {
+	defer func() {
+		if p := recover(); p != nil {
+			reterr = errors.Errorf("panic: %v", p)
+		}
+	}()
+	return r.Reconciler.Reconcile(ctx, req)
+}
+
+// setCondition sets the given condition on the object or, if condition is nil, deletes the ReconcileStuck condition.
+func (r *Reconciler) setCondition(ctx context.Context, req reconcile.Request, condition *clusterv1.Condition) error {
+	obj := r.NewObject()
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.reset(req.NamespacedName)
+			return nil
+		}
+		return err
+	}
+
+	patchHelper, err := patch.NewHelper(obj, r.Client)
+	if err != nil {
+		return err
+	}
+
+	if condition == nil {
+		conditions.Delete(obj, clusterv1.ReconcileStuckCondition)
+	} else {
+		conditions.Set(obj, condition)
+		if r.Recorder != nil {
+			r.Recorder.Event(obj, corev1.EventTypeWarning, clusterv1.ReconcileFailureThresholdExceededReason, condition.Message)
+		}
+	}
+
+	return patchHelper.Patch(ctx, obj, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{clusterv1.ReconcileStuckCondition}})
+}
+
+// recordFailure increments the number of consecutive failures for an object and returns it.
+func (r *Reconciler) recordFailure(key types.NamespacedName) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.failures == nil {
+		r.failures = map[types.NamespacedName]int{}
+	}
+	if r.failures[key] == r.Threshold-1 {
+		stuckObjects.WithLabelValues(r.Name).Inc()
+	}
+	r.failures[key]++
+	return r.failures[key]
+}
+
+// reset forgets the consecutive failures for an object and returns how many there were.
+func (r *Reconciler) reset(key types.NamespacedName) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	failures, ok := r.failures[key]
+	if !ok {
+		return 0
+	}
+	if failures >= r.Threshold {
+		stuckObjects.WithLabelValues(r.Name).Dec()
+	}
+	delete(r.failures, key)
+	return failures
+}
+
+func (r *Reconciler) parkingInterval() time.Duration {
+	if r.ParkingInterval == 0 {
+		return DefaultParkingInterval
+	}
+	return r.ParkingInterval
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circuitbreaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+var (
+	ctx = ctrl.SetupSignalHandler()
+)
+
+type fakeReconciler struct {
+	err   error
+	panic bool
+	calls int
+}
+
+func (f *fakeReconciler) Reconcile(_ context.Context, _ reconcile.Request) (ctrl.Result, error) {
+	f.calls++
+	if f.panic {
+		panic("boom")
+	}
+	return ctrl.Result{}, f.err
+}
+
+func TestReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(machine)}
+
+	getMachine := func(g *WithT, c client.Client) *clusterv1.Machine {
+		m := &clusterv1.Machine{}
+		g.Expect(c.Get(ctx, req.NamespacedName, m)).To(Succeed())
+		return m
+	}
+
+	t.Run("is a pass-through if Threshold is 0", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(machine.DeepCopy()).WithStatusSubresource(&clusterv1.Machine{}).Build()
+		inner := &fakeReconciler{err: errors.New("failed")}
+		r := &Reconciler{Client: c, Reconciler: inner, NewObject: func() Object { return &clusterv1.Machine{} }}
+
+		for range 5 {
+			_, err := r.Reconcile(ctx, req)
+			g.Expect(err).To(HaveOccurred())
+		}
+		g.Expect(inner.calls).To(Equal(5))
+		g.Expect(conditions.Has(getMachine(g, c), clusterv1.ReconcileStuckCondition)).To(BeFalse())
+	})
+
+	t.Run("parks the object after Threshold consecutive errors and closes on success", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(machine.DeepCopy()).WithStatusSubresource(&clusterv1.Machine{}).Build()
+		recorder := record.NewFakeRecorder(10)
+		inner := &fakeReconciler{err: errors.New("failed")}
+		r := &Reconciler{
+			Client:          c,
+			Recorder:        recorder,
+			Reconciler:      inner,
+			NewObject:       func() Object { return &clusterv1.Machine{} },
+			Threshold:       3,
+			ParkingInterval: time.Minute,
+		}
+
+		for range 2 {
+			_, err := r.Reconcile(ctx, req)
+			g.Expect(err).To(HaveOccurred())
+		}
+		g.Expect(conditions.Has(getMachine(g, c), clusterv1.ReconcileStuckCondition)).To(BeFalse())
+
+		res, err := r.Reconcile(ctx, req)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(time.Minute))
+
+		m := getMachine(g, c)
+		g.Expect(conditions.IsTrue(m, clusterv1.ReconcileStuckCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(m, clusterv1.ReconcileStuckCondition)).To(Equal(clusterv1.ReconcileFailureThresholdExceededReason))
+		g.Expect(conditions.GetSeverity(m, clusterv1.ReconcileStuckCondition)).To(HaveValue(Equal(clusterv1.ConditionSeverityWarning)))
+		g.Expect(recorder.Events).To(Receive(ContainSubstring(corev1.EventTypeWarning)))
+
+		inner.err = nil
+		_, err = r.Reconcile(ctx, req)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conditions.Has(getMachine(g, c), clusterv1.ReconcileStuckCondition)).To(BeFalse())
+	})
+
+	t.Run("counts panics as failures", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(machine.DeepCopy()).WithStatusSubresource(&clusterv1.Machine{}).Build()
+		inner := &fakeReconciler{panic: true}
+		r := &Reconciler{
+			Client:     c,
+			Reconciler: inner,
+			NewObject:  func() Object { return &clusterv1.Machine{} },
+			Threshold:  2,
+		}
+
+		_, err := r.Reconcile(ctx, req)
+		g.Expect(err).To(MatchError(ContainSubstring("panic: boom")))
+
+		res, err := r.Reconcile(ctx, req)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(DefaultParkingInterval))
+		g.Expect(conditions.IsTrue(getMachine(g, c), clusterv1.ReconcileStuckCondition)).To(BeTrue())
+	})
+
+	t.Run("a success resets the count of consecutive failures", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(machine.DeepCopy()).WithStatusSubresource(&clusterv1.Machine{}).Build()
+		inner := &fakeReconciler{err: errors.New("failed")}
+		r := &Reconciler{
+			Client:     c,
+			Reconciler: inner,
+			NewObject:  func() Object { return &clusterv1.Machine{} },
+			Threshold:  2,
+		}
+
+		_, err := r.Reconcile(ctx, req)
+		g.Expect(err).To(HaveOccurred())
+
+		inner.err = nil
+		_, err = r.Reconcile(ctx, req)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		inner.err = errors.New("failed")
+		_, err = r.Reconcile(ctx, req)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(conditions.Has(getMachine(g, c), clusterv1.ReconcileStuckCondition)).To(BeFalse())
+	})
+
+	t.Run("keeps a stable condition message across different errors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(machine.DeepCopy()).WithStatusSubresource(&clusterv1.Machine{}).Build()
+		inner := &fakeReconciler{err: errors.New("first error")}
+		r := &Reconciler{
+			Client:     c,
+			Reconciler: inner,
+			NewObject:  func() Object { return &clusterv1.Machine{} },
+			Threshold:  1,
+		}
+
+		_, err := r.Reconcile(ctx, req)
+		g.Expect(err).ToNot(HaveOccurred())
+		m := getMachine(g, c)
+		message := conditions.GetMessage(m, clusterv1.ReconcileStuckCondition)
+		g.Expect(message).ToNot(ContainSubstring("first error"))
+
+		inner.err = errors.New("second error")
+		_, err = r.Reconcile(ctx, req)
+		g.Expect(err).ToNot(HaveOccurred())
+		m2 := getMachine(g, c)
+		g.Expect(conditions.GetMessage(m2, clusterv1.ReconcileStuckCondition)).To(Equal(message))
+		g.Expect(m2.ResourceVersion).To(Equal(m.ResourceVersion))
+	})
+
+	t.Run("forgets the failures of deleted objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(machine.DeepCopy()).WithStatusSubresource(&clusterv1.Machine{}).Build()
+		inner := &fakeReconciler{err: errors.New("failed")}
+		r := &Reconciler{
+			Client:     c,
+			Reconciler: inner,
+			NewObject:  func() Object { return &clusterv1.Machine{} },
+			Threshold:  3,
+		}
+
+		_, err := r.Reconcile(ctx, req)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(r.failures).To(HaveKey(req.NamespacedName))
+
+		g.Expect(c.Delete(ctx, getMachine(g, c))).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(r.failures).ToNot(HaveKey(req.NamespacedName))
+	})
+}
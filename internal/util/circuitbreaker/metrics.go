@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circuitbreaker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func init() {
+	// Register the metrics at the controller-runtime metrics registry.
+	ctrlmetrics.Registry.MustRegister(stuckObjects)
+	ctrlmetrics.Registry.MustRegister(parkedTotal)
+}
+
+// Metrics subsystem used by the reconcile circuit breaker.
+const circuitBreakerSubsystem = "capi_reconcile_circuit_breaker"
+
+var (
+	// stuckObjects reports the number of objects which are currently parked.
+	stuckObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: circuitBreakerSubsystem,
+		Name:      "stuck_objects",
+		Help:      "Number of objects currently parked because reconcile failed too many times in a row, partitioned by controller.",
+	}, []string{"controller"})
+
+	// parkedTotal reports the number of times objects have been parked.
+	parkedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: circuitBreakerSubsystem,
+		Name:      "parked_total",
+		Help:      "Number of times reconciliation of an object has been parked, partitioned by controller.",
+	}, []string{"controller"})
+)
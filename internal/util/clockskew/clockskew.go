@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clockskew provides utils to compare timestamps which might have been set using a different clock.
+package clockskew
+
+import "time"
+
+// Tolerance is the clock skew tolerated when comparing a timestamp set by another system, e.g. a workload cluster,
+// with the current time.
+const Tolerance = time.Minute
+
+// Expired returns true if more than timeout elapsed since t, tolerating t being set by a clock which is behind
+// the clock used for now by up to Tolerance.
+// Note: The tolerance is capped to timeout, so short timeouts are not extended disproportionately.
+func Expired(t time.Time, timeout time.Duration, now time.Time) bool {
+	return Remaining(t, timeout, now) <= 0
+}
+
+// Remaining returns the time left until Expired returns true; it returns 0 if the timeout already expired.
+func Remaining(t time.Time, timeout time.Duration, now time.Time) time.Duration {
+	tolerance := Tolerance
+	if timeout < tolerance {
+		tolerance = timeout
+	}
+	if remaining := t.Add(timeout + tolerance).Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clockskew
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRemaining(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		t           time.Time
+		timeout     time.Duration
+		wantRemain  time.Duration
+		wantExpired bool
+	}{
+		{
+			name:       "timeout not expired",
+			t:          now.Add(-5 * time.Minute),
+			timeout:    10 * time.Minute,
+			wantRemain: 5*time.Minute + Tolerance,
+		},
+		{
+			name:       "timeout expired by less than the tolerance",
+			t:          now.Add(-10*time.Minute - Tolerance/2),
+			timeout:    10 * time.Minute,
+			wantRemain: Tolerance / 2,
+		},
+		{
+			name:        "timeout expired by more than the tolerance",
+			t:           now.Add(-10*time.Minute - 2*Tolerance),
+			timeout:     10 * time.Minute,
+			wantRemain:  0,
+			wantExpired: true,
+		},
+		{
+			name:       "tolerance is capped to the timeout",
+			t:          now.Add(-15 * time.Second),
+			timeout:    10 * time.Second,
+			wantRemain: 5 * time.Second,
+		},
+		{
+			name:       "timestamp in the future",
+			t:          now.Add(time.Minute),
+			timeout:    10 * time.Minute,
+			wantRemain: 11*time.Minute + Tolerance,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(Remaining(tt.t, tt.timeout, now)).To(Equal(tt.wantRemain))
+			g.Expect(Expired(tt.t, tt.timeout, now)).To(Equal(tt.wantExpired))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drift provides utils to detect when a Machine is not running what it declares.
+package drift
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/version"
+)
+
+// KubeletVersion returns true if the kubelet version reported by the Node differs from the Kubernetes
+// version declared on the Machine.
+// NOTE: Only major, minor and patch are compared, see Version.
+func KubeletVersion(machine *clusterv1.Machine, nodeInfo *corev1.NodeSystemInfo) bool {
+	if machine.Spec.Version == nil || nodeInfo == nil {
+		return false
+	}
+	return Version(*machine.Spec.Version, nodeInfo.KubeletVersion)
+}
+
+// Version returns true if the actual version differs from the declared version.
+// NOTE: Only major, minor and patch are compared, so distribution specific build metadata or pre-release
+// suffixes (e.g. v1.30.1+k3s1, v1.30.1-eks-1234) are not considered a drift; if any of the versions is empty
+// or cannot be parsed, this is not considered a drift.
+func Version(declared, actual string) bool {
+	if declared == "" || actual == "" {
+		return false
+	}
+
+	declaredVersion, err := version.ParseMajorMinorPatchTolerant(declared)
+	if err != nil {
+		return false
+	}
+	actualVersion, err := version.ParseMajorMinorPatchTolerant(actual)
+	if err != nil {
+		return false
+	}
+
+	return declaredVersion.Major != actualVersion.Major ||
+		declaredVersion.Minor != actualVersion.Minor ||
+		declaredVersion.Patch != actualVersion.Patch
+}
+
+// Image returns true if the image reported by the infrastructure provider for the Machine differs from the image
+// declared by the InfrastructureMachineTemplate.
+// NOTE: If either the Machine or the InfrastructureMachineTemplate does not report an image, this is not considered a drift.
+func Image(machine *clusterv1.Machine, templateImage string) bool {
+	return machine.Status.Image != "" && templateImage != "" && machine.Status.Image != templateImage
+}
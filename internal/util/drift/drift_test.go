@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestKubeletVersion(t *testing.T) {
+	testCases := []struct {
+		name           string
+		version        *string
+		kubeletVersion string
+		want           bool
+	}{
+		{
+			name:           "no drift if the Machine has no version",
+			version:        nil,
+			kubeletVersion: "v1.30.1",
+			want:           false,
+		},
+		{
+			name:           "no drift if the Node does not report a kubelet version",
+			version:        ptr.To("v1.30.1"),
+			kubeletVersion: "",
+			want:           false,
+		},
+		{
+			name:           "no drift if versions match",
+			version:        ptr.To("v1.30.1"),
+			kubeletVersion: "v1.30.1",
+			want:           false,
+		},
+		{
+			name:           "no drift if versions only differ by build metadata or pre-release",
+			version:        ptr.To("v1.30.1"),
+			kubeletVersion: "v1.30.1-eks-1234",
+			want:           false,
+		},
+		{
+			name:           "drift if patch versions differ",
+			version:        ptr.To("v1.30.1"),
+			kubeletVersion: "v1.30.0",
+			want:           true,
+		},
+		{
+			name:           "drift if minor versions differ",
+			version:        ptr.To("v1.31.0"),
+			kubeletVersion: "v1.30.0",
+			want:           true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machine := &clusterv1.Machine{
+				Spec: clusterv1.MachineSpec{
+					Version: tc.version,
+				},
+			}
+			nodeInfo := &corev1.NodeSystemInfo{
+				KubeletVersion: tc.kubeletVersion,
+			}
+			g.Expect(KubeletVersion(machine, nodeInfo)).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Version("v1.30.1", "v1.30.1")).To(BeFalse())
+	g.Expect(Version("v1.30.1", "v1.30.1+k3s1")).To(BeFalse())
+	g.Expect(Version("v1.30.1", "v1.31.1")).To(BeTrue())
+	g.Expect(Version("", "v1.30.1")).To(BeFalse())
+	g.Expect(Version("v1.30.1", "")).To(BeFalse())
+	g.Expect(Version("v1.30.1", "not-a-version")).To(BeFalse())
+}
+
+func TestImage(t *testing.T) {
+	testCases := []struct {
+		name          string
+		image         string
+		templateImage string
+		want          bool
+	}{
+		{
+			name:          "no drift if the Machine does not report an image",
+			image:         "",
+			templateImage: "image-1",
+			want:          false,
+		},
+		{
+			name:          "no drift if the template does not report an image",
+			image:         "image-1",
+			templateImage: "",
+			want:          false,
+		},
+		{
+			name:          "no drift if images match",
+			image:         "image-1",
+			templateImage: "image-1",
+			want:          false,
+		},
+		{
+			name:          "drift if images differ",
+			image:         "image-1",
+			templateImage: "image-2",
+			want:          true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machine := &clusterv1.Machine{
+				Status: clusterv1.MachineStatus{
+					Image: tc.image,
+				},
+			}
+			g.Expect(Image(machine, tc.templateImage)).To(Equal(tc.want))
+		})
+	}
+}
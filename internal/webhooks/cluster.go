@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -243,6 +244,26 @@ func (webhook *Cluster) validate(ctx context.Context, oldCluster, newCluster *cl
 			allErrs = append(allErrs, validateCIDRBlocks(specPath.Child("clusterNetwork", "services", "cidrBlocks"),
 				newCluster.Spec.ClusterNetwork.Services.CIDRBlocks)...)
 		}
+
+		// NOTE: The following checks have been introduced later, so they are only enforced on create or when
+		// the ClusterNetwork is changed, to not block updates of existing Clusters.
+		if oldCluster == nil || !reflect.DeepEqual(oldCluster.Spec.ClusterNetwork, newCluster.Spec.ClusterNetwork) {
+			allErrs = append(allErrs, validateClusterNetwork(specPath.Child("clusterNetwork"), newCluster.Spec.ClusterNetwork)...)
+		}
+	}
+
+	// The control plane endpoint can't be changed once set, no matter if it has been set by the user or copied
+	// from the InfraCluster or the ControlPlane by the Cluster controller, because it is used to reach the workload cluster.
+	if oldCluster != nil && oldCluster.Spec.ControlPlaneEndpoint.IsValid() &&
+		oldCluster.Spec.ControlPlaneEndpoint != newCluster.Spec.ControlPlaneEndpoint {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("controlPlaneEndpoint"), "field is immutable once set"))
+	}
+
+	if newCluster.Spec.ControlPlaneEndpoint.Port != 0 &&
+		(oldCluster == nil || oldCluster.Spec.ControlPlaneEndpoint.Port != newCluster.Spec.ControlPlaneEndpoint.Port) {
+		for _, msg := range validation.IsValidPortNum(int(newCluster.Spec.ControlPlaneEndpoint.Port)) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("controlPlaneEndpoint", "port"), newCluster.Spec.ControlPlaneEndpoint.Port, msg))
+		}
 	}
 
 	topologyPath := specPath.Child("topology")
@@ -753,6 +774,49 @@ func validateCIDRBlocks(fldPath *field.Path, cidrs []string) field.ErrorList {
 	return allErrs
 }
 
+// validateClusterNetwork ensures the pod and service CIDR blocks do not overlap, and that the
+// service domain and the API server port are valid.
+func validateClusterNetwork(fldPath *field.Path, clusterNetwork *clusterv1.ClusterNetwork) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if clusterNetwork.APIServerPort != nil {
+		for _, msg := range validation.IsValidPortNum(int(*clusterNetwork.APIServerPort)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("apiServerPort"), *clusterNetwork.APIServerPort, msg))
+		}
+	}
+
+	if clusterNetwork.ServiceDomain != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(clusterNetwork.ServiceDomain) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceDomain"), clusterNetwork.ServiceDomain, msg))
+		}
+	}
+
+	if clusterNetwork.Pods == nil || clusterNetwork.Services == nil {
+		return allErrs
+	}
+	for i, podCIDR := range clusterNetwork.Pods.CIDRBlocks {
+		_, podNet, err := net.ParseCIDR(podCIDR)
+		if err != nil {
+			// Invalid CIDR blocks are already reported by validateCIDRBlocks.
+			continue
+		}
+		for _, serviceCIDR := range clusterNetwork.Services.CIDRBlocks {
+			_, serviceNet, err := net.ParseCIDR(serviceCIDR)
+			if err != nil {
+				continue
+			}
+			if podNet.Contains(serviceNet.IP) || serviceNet.Contains(podNet.IP) {
+				allErrs = append(allErrs, field.Invalid(
+					fldPath.Child("pods", "cidrBlocks").Index(i),
+					podCIDR,
+					fmt.Sprintf("must not overlap with the service CIDR block %s", serviceCIDR)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
 // DefaultAndValidateVariables defaults and validates variables in the Cluster and MachineDeployment/MachinePool topologies based
 // on the definitions in the ClusterClass.
 func DefaultAndValidateVariables(ctx context.Context, cluster, oldCluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
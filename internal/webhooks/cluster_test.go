@@ -1493,7 +1493,7 @@ func TestClusterValidation(t *testing.T) {
 						CIDRBlocks: []string{"10.10.10.10/24"},
 					},
 					Pods: &clusterv1.NetworkRanges{
-						CIDRBlocks: []string{"10.10.10.10/24"},
+						CIDRBlocks: []string{"11.11.11.11/24"},
 					},
 				}).
 				Build(),
@@ -1507,7 +1507,7 @@ func TestClusterValidation(t *testing.T) {
 						CIDRBlocks: []string{"2004::1234:abcd:ffff:c0a8:101/64"},
 					},
 					Pods: &clusterv1.NetworkRanges{
-						CIDRBlocks: []string{"2004::1234:abcd:ffff:c0a8:101/64"},
+						CIDRBlocks: []string{"2002::1234:abcd:ffff:c0a8:101/64"},
 					},
 				}).
 				Build(),
@@ -1521,7 +1521,7 @@ func TestClusterValidation(t *testing.T) {
 						CIDRBlocks: []string{"2004::1234:abcd:ffff:c0a8:101/64", "10.10.10.10/24"},
 					},
 					Pods: &clusterv1.NetworkRanges{
-						CIDRBlocks: []string{"2004::1234:abcd:ffff:c0a8:101/64", "10.10.10.10/24"},
+						CIDRBlocks: []string{"2002::1234:abcd:ffff:c0a8:101/64", "11.11.11.11/24"},
 					},
 				}).
 				Build(),
@@ -1583,6 +1583,115 @@ func TestClusterValidation(t *testing.T) {
 				}).
 				Build(),
 		},
+		{
+			name:      "fails if pod and service cidr ranges overlap",
+			expectErr: true,
+			in: builder.Cluster("fooNamespace", "cluster1").
+				WithClusterNetwork(&clusterv1.ClusterNetwork{
+					Services: &clusterv1.NetworkRanges{
+						CIDRBlocks: []string{"10.96.0.0/12"},
+					},
+					Pods: &clusterv1.NetworkRanges{
+						CIDRBlocks: []string{"10.96.0.0/16"},
+					},
+				}).
+				Build(),
+		},
+		{
+			name:      "pass if pod and service cidr ranges overlap and the cluster network is not changed",
+			expectErr: false,
+			old: builder.Cluster("fooNamespace", "cluster1").
+				WithClusterNetwork(&clusterv1.ClusterNetwork{
+					Services: &clusterv1.NetworkRanges{
+						CIDRBlocks: []string{"10.96.0.0/12"},
+					},
+					Pods: &clusterv1.NetworkRanges{
+						CIDRBlocks: []string{"10.96.0.0/16"},
+					},
+				}).
+				Build(),
+			in: builder.Cluster("fooNamespace", "cluster1").
+				WithClusterNetwork(&clusterv1.ClusterNetwork{
+					Services: &clusterv1.NetworkRanges{
+						CIDRBlocks: []string{"10.96.0.0/12"},
+					},
+					Pods: &clusterv1.NetworkRanges{
+						CIDRBlocks: []string{"10.96.0.0/16"},
+					},
+				}).
+				Build(),
+		},
+		{
+			name:      "pass with a valid service domain and API server port",
+			expectErr: false,
+			in: builder.Cluster("fooNamespace", "cluster1").
+				WithClusterNetwork(&clusterv1.ClusterNetwork{
+					ServiceDomain: "cluster.local",
+					APIServerPort: ptr.To[int32](6443),
+				}).
+				Build(),
+		},
+		{
+			name:      "fails if service domain is not valid",
+			expectErr: true,
+			in: builder.Cluster("fooNamespace", "cluster1").
+				WithClusterNetwork(&clusterv1.ClusterNetwork{
+					ServiceDomain: "cluster_local",
+				}).
+				Build(),
+		},
+		{
+			name:      "fails if API server port is out of range",
+			expectErr: true,
+			in: builder.Cluster("fooNamespace", "cluster1").
+				WithClusterNetwork(&clusterv1.ClusterNetwork{
+					APIServerPort: ptr.To[int32](65536),
+				}).
+				Build(),
+		},
+		{
+			name:      "fails if control plane endpoint port is out of range",
+			expectErr: true,
+			in: func() *clusterv1.Cluster {
+				c := builder.Cluster("fooNamespace", "cluster1").Build()
+				c.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "example.com", Port: -1}
+				return c
+			}(),
+		},
+		{
+			name:      "pass if control plane endpoint is set",
+			expectErr: false,
+			old:       builder.Cluster("fooNamespace", "cluster1").Build(),
+			in: func() *clusterv1.Cluster {
+				c := builder.Cluster("fooNamespace", "cluster1").Build()
+				c.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "example.com", Port: 6443}
+				return c
+			}(),
+		},
+		{
+			name:      "fails if control plane endpoint is changed once set",
+			expectErr: true,
+			old: func() *clusterv1.Cluster {
+				c := builder.Cluster("fooNamespace", "cluster1").Build()
+				c.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "example.com", Port: 6443}
+				return c
+			}(),
+			in: func() *clusterv1.Cluster {
+				c := builder.Cluster("fooNamespace", "cluster1").Build()
+				c.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "example.org", Port: 6443}
+				return c
+			}(),
+		},
+		{
+			name:      "fails if control plane endpoint is removed once set",
+			expectErr: true,
+			old: func() *clusterv1.Cluster {
+				c := builder.Cluster("fooNamespace", "cluster1").Build()
+				c.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "example.com", Port: 6443}
+				return c
+			}(),
+			in: builder.Cluster("fooNamespace", "cluster1").Build(),
+		},
 		{
 			name:      "pass with name of under 63 characters",
 			expectErr: false,
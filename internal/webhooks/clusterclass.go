@@ -148,9 +148,13 @@ func (webhook *ClusterClass) validate(ctx context.Context, oldClusterClass, newC
 	// NOTE: ClusterClass and managed topologies are behind ClusterTopology feature gate flag; the web hook
 	// must prevent creating new objects when the feature flag is disabled.
 	if !feature.Gates.Enabled(feature.ClusterTopology) {
-		return field.Forbidden(
-			field.NewPath("spec"),
-			"can be set only if the ClusterTopology feature flag is enabled",
+		return apierrors.NewInvalid(
+			clusterv1.GroupVersion.WithKind("ClusterClass").GroupKind(),
+			newClusterClass.Name,
+			field.ErrorList{field.Forbidden(
+				field.NewPath("spec"),
+				"can be set only if the ClusterTopology feature flag is enabled",
+			)},
 		)
 	}
 	var allErrs field.ErrorList
@@ -19,12 +19,16 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -161,6 +165,13 @@ func (webhook *Machine) validate(oldM, newM *clusterv1.Machine) error {
 		)
 	}
 
+	var oldAnnotations map[string]string
+	if oldM != nil {
+		oldAnnotations = oldM.Annotations
+	}
+	allErrs = append(allErrs, validateMachineAnnotations(oldAnnotations, newM.Annotations, field.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, validateMachineTaints(newM.Spec.Taints, specPath.Child("taints"))...)
+
 	if newM.Spec.Version != nil {
 		if !version.KubeSemver.MatchString(*newM.Spec.Version) {
 			allErrs = append(allErrs, field.Invalid(specPath.Child("version"), *newM.Spec.Version, "must be a valid semantic version"))
@@ -172,3 +183,82 @@ func (webhook *Machine) validate(oldM, newM *clusterv1.Machine) error {
 	}
 	return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("Machine").GroupKind(), newM.Name, allErrs)
 }
+
+// validateMachineAnnotations validates the annotations of a Machine or of a Machine template which are read by the
+// Machine controller, so that invalid values are surfaced on the object the user is editing, e.g. a MachineDeployment,
+// instead of making the creation of each Machine fail.
+func validateMachineAnnotations(oldAnnotations, newAnnotations map[string]string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateWaitForNodeDeregistrationAnnotation(oldAnnotations, newAnnotations, fldPath)...)
+	allErrs = append(allErrs, validateBootstrapDataSecretMissingPolicyAnnotation(oldAnnotations, newAnnotations, fldPath)...)
+	return allErrs
+}
+
+// validateWaitForNodeDeregistrationAnnotation validates the WaitForNodeDeregistrationAnnotation of a Machine or of a
+// Machine template.
+// Note: The value is only validated if it changed, so existing objects with an invalid value can still be updated.
+func validateWaitForNodeDeregistrationAnnotation(oldAnnotations, newAnnotations map[string]string, fldPath *field.Path) field.ErrorList {
+	value, ok := newAnnotations[clusterv1.WaitForNodeDeregistrationAnnotation]
+	if !ok {
+		return nil
+	}
+	if oldValue, oldOk := oldAnnotations[clusterv1.WaitForNodeDeregistrationAnnotation]; oldOk && oldValue == value {
+		return nil
+	}
+
+	if wait, err := time.ParseDuration(value); err != nil || wait < 0 {
+		return field.ErrorList{field.Invalid(fldPath.Key(clusterv1.WaitForNodeDeregistrationAnnotation), value, "must be a non-negative duration, e.g. 30s")}
+	}
+	return nil
+}
+
+// validateBootstrapDataSecretMissingPolicyAnnotation validates the MachineBootstrapDataSecretMissingPolicyAnnotation
+// of a Machine or of a Machine template.
+// Note: The value is only validated if it changed, so existing objects with an invalid value can still be updated.
+func validateBootstrapDataSecretMissingPolicyAnnotation(oldAnnotations, newAnnotations map[string]string, fldPath *field.Path) field.ErrorList {
+	value, ok := newAnnotations[clusterv1.MachineBootstrapDataSecretMissingPolicyAnnotation]
+	if !ok {
+		return nil
+	}
+	if oldValue, oldOk := oldAnnotations[clusterv1.MachineBootstrapDataSecretMissingPolicyAnnotation]; oldOk && oldValue == value {
+		return nil
+	}
+
+	supportedValues := []string{clusterv1.MachineBootstrapDataSecretMissingPolicyReport, clusterv1.MachineBootstrapDataSecretMissingPolicyIgnore}
+	if !slices.Contains(supportedValues, value) {
+		return field.ErrorList{field.NotSupported(fldPath.Key(clusterv1.MachineBootstrapDataSecretMissingPolicyAnnotation), value, supportedValues)}
+	}
+	return nil
+}
+
+// validateMachineTaints validates the taints of a Machine or of a Machine template which are set on the Node.
+func validateMachineTaints(taints []corev1.Taint, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	supportedEffects := []corev1.TaintEffect{corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute}
+	seen := sets.Set[string]{}
+	for i, taint := range taints {
+		idxPath := fldPath.Index(i)
+		for _, msg := range validation.IsQualifiedName(taint.Key) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("key"), taint.Key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(taint.Value) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("value"), taint.Value, msg))
+		}
+		if !slices.Contains(supportedEffects, taint.Effect) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("effect"), taint.Effect, supportedEffects))
+		}
+
+		// Taints are identified by key and effect, the same way the Node API does.
+		id := fmt.Sprintf("%s:%s", taint.Key, taint.Effect)
+		if seen.Has(id) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, id))
+		}
+		seen.Insert(id)
+
+		// The taints which are managed by Cluster API cannot be set from a Machine.
+		if taint.MatchTaint(&clusterv1.NodeUninitializedTaint) || taint.MatchTaint(&clusterv1.NodeOutdatedRevisionTaint) {
+			allErrs = append(allErrs, field.Forbidden(idxPath, fmt.Sprintf("taint %s is managed by Cluster API", id)))
+		}
+	}
+	return allErrs
+}
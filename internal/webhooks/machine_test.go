@@ -289,3 +289,192 @@ func TestMachineVersionValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineWaitForNodeDeregistrationAnnotationValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{
+			name:      "should succeed when given a valid duration",
+			value:     "30s",
+			expectErr: false,
+		},
+		{
+			name:      "should return error when given a negative duration",
+			value:     "-30s",
+			expectErr: true,
+		},
+		{
+			name:      "should return error when given an invalid duration",
+			value:     "30",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						clusterv1.WaitForNodeDeregistrationAnnotation: tt.value,
+					},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{ConfigRef: nil, DataSecretName: ptr.To("test")},
+				},
+			}
+			webhook := &Machine{}
+
+			_, err := webhook.ValidateCreate(ctx, m)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestMachineBootstrapDataSecretMissingPolicyAnnotationValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldValue  *string
+		value     string
+		expectErr bool
+	}{
+		{
+			name:      "should succeed when given Report",
+			value:     clusterv1.MachineBootstrapDataSecretMissingPolicyReport,
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when given Ignore",
+			value:     clusterv1.MachineBootstrapDataSecretMissingPolicyIgnore,
+			expectErr: false,
+		},
+		{
+			name:      "should return error when given an unknown value",
+			value:     "ignore",
+			expectErr: true,
+		},
+		{
+			name:      "should return error when changing the value to an unknown value",
+			oldValue:  ptr.To(clusterv1.MachineBootstrapDataSecretMissingPolicyIgnore),
+			value:     "Recreate",
+			expectErr: true,
+		},
+		{
+			name:      "should succeed when an unknown value is not changed",
+			oldValue:  ptr.To("Recreate"),
+			value:     "Recreate",
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						clusterv1.MachineBootstrapDataSecretMissingPolicyAnnotation: tt.value,
+					},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{ConfigRef: nil, DataSecretName: ptr.To("test")},
+				},
+			}
+			webhook := &Machine{}
+
+			var err error
+			if tt.oldValue != nil {
+				oldM := m.DeepCopy()
+				oldM.Annotations[clusterv1.MachineBootstrapDataSecretMissingPolicyAnnotation] = *tt.oldValue
+				_, err = webhook.ValidateUpdate(ctx, oldM, m)
+			} else {
+				_, err = webhook.ValidateCreate(ctx, m)
+			}
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestMachineTaintsValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		taints    []corev1.Taint
+		expectErr bool
+	}{
+		{
+			name:      "should succeed without taints",
+			expectErr: false,
+		},
+		{
+			name: "should succeed with valid taints",
+			taints: []corev1.Taint{
+				{Key: "node.cluster.x-k8s.io/dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "node.cluster.x-k8s.io/dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+				{Key: "example.com/empty-value", Effect: corev1.TaintEffectPreferNoSchedule},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "should return error when the key is invalid",
+			taints:    []corev1.Taint{{Key: "invalid key", Effect: corev1.TaintEffectNoSchedule}},
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the value is invalid",
+			taints:    []corev1.Taint{{Key: "foo", Value: "invalid value", Effect: corev1.TaintEffectNoSchedule}},
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the effect is not supported",
+			taints:    []corev1.Taint{{Key: "foo", Effect: "NoWay"}},
+			expectErr: true,
+		},
+		{
+			name: "should return error when a taint is duplicated",
+			taints: []corev1.Taint{
+				{Key: "foo", Value: "a", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "foo", Value: "b", Effect: corev1.TaintEffectNoSchedule},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "should return error when a taint is managed by Cluster API",
+			taints:    []corev1.Taint{clusterv1.NodeOutdatedRevisionTaint},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.Machine{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{ConfigRef: nil, DataSecretName: ptr.To("test")},
+					Taints:    tt.taints,
+				},
+			}
+			webhook := &Machine{}
+
+			_, err := webhook.ValidateCreate(ctx, m)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
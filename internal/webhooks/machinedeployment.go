@@ -19,6 +19,7 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -31,13 +32,16 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
 	"sigs.k8s.io/cluster-api/util/version"
 )
 
@@ -58,6 +62,10 @@ func (webhook *MachineDeployment) SetupWebhookWithManager(mgr ctrl.Manager) erro
 
 // MachineDeployment implements a validation and defaulting webhook for MachineDeployment.
 type MachineDeployment struct {
+	// Client is used to allow minor version downgrades which roll back to the template of an existing MachineSet,
+	// e.g. with `clusterctl alpha rollout undo`; if it is not set such downgrades are rejected.
+	Client client.Reader
+
 	decoder admission.Decoder
 }
 
@@ -162,17 +170,17 @@ func (webhook *MachineDeployment) Default(ctx context.Context, obj runtime.Objec
 }
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
-func (webhook *MachineDeployment) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (webhook *MachineDeployment) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	m, ok := obj.(*clusterv1.MachineDeployment)
 	if !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a MachineDeployment but got a %T", obj))
 	}
 
-	return nil, webhook.validate(nil, m)
+	return nil, webhook.validate(ctx, nil, m)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (webhook *MachineDeployment) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (webhook *MachineDeployment) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	oldMD, ok := oldObj.(*clusterv1.MachineDeployment)
 	if !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a MachineDeployment but got a %T", oldObj))
@@ -183,7 +191,7 @@ func (webhook *MachineDeployment) ValidateUpdate(_ context.Context, oldObj, newO
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a MachineDeployment but got a %T", newObj))
 	}
 
-	return nil, webhook.validate(oldMD, newMD)
+	return nil, webhook.validate(ctx, oldMD, newMD)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -191,7 +199,7 @@ func (webhook *MachineDeployment) ValidateDelete(_ context.Context, _ runtime.Ob
 	return nil, nil
 }
 
-func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeployment) error {
+func (webhook *MachineDeployment) validate(ctx context.Context, oldMD, newMD *clusterv1.MachineDeployment) error {
 	var allErrs field.ErrorList
 	// The MachineDeployment name is used as a label value. This check ensures names which are not be valid label values are rejected.
 	if errs := validation.IsValidLabelValue(newMD.Name); len(errs) != 0 {
@@ -229,6 +237,35 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 		if err := validateSkippedMachineSetPreflightChecks(newMD); err != nil {
 			allErrs = append(allErrs, err)
 		}
+		if oldMD != nil {
+			if err := validateKubernetesVersionDowngrade(specPath.Child("template", "spec", "version"), oldMD.Spec.Template.Spec.Version, newMD.Spec.Template.Spec.Version, newMD); err != nil {
+				// Rolling back to the template of an existing MachineSet does not downgrade any Machine, because the
+				// Machines with the lower version still exist or can be recreated by that MachineSet.
+				rollback, rollbackErr := webhook.isRollbackToExistingMachineSet(ctx, newMD)
+				if rollbackErr != nil {
+					allErrs = append(allErrs, field.InternalError(specPath.Child("template", "spec", "version"), rollbackErr))
+				} else if !rollback {
+					allErrs = append(allErrs, err)
+				}
+			}
+		}
+	}
+
+	var oldAnnotations map[string]string
+	if oldMD != nil {
+		oldAnnotations = oldMD.Annotations
+	}
+	allErrs = append(allErrs, validateAutoscalerCapacityAnnotations(oldAnnotations, newMD.Annotations)...)
+
+	if newMD.Spec.Replicas != nil && *newMD.Spec.Replicas < 0 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				specPath.Child("replicas"),
+				*newMD.Spec.Replicas,
+				"must be greater than or equal to 0",
+			),
+		)
 	}
 
 	if oldMD != nil && oldMD.Spec.ClusterName != newMD.Spec.ClusterName {
@@ -242,6 +279,10 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 	}
 
 	if newMD.Spec.Strategy != nil && newMD.Spec.Strategy.RollingUpdate != nil {
+		// The fencepost checks below were added later on, so they are only enforced on create or when the strategy
+		// changes in order to not block updates to existing MachineDeployments.
+		strategyChanged := oldMD == nil || !reflect.DeepEqual(oldMD.Spec.Strategy, newMD.Spec.Strategy)
+
 		total := 1
 		if newMD.Spec.Replicas != nil {
 			total = int(*newMD.Spec.Replicas)
@@ -264,8 +305,23 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 					field.Invalid(specPath.Child("strategy", "rollingUpdate", "maxUnavailable"),
 						newMD.Spec.Strategy.RollingUpdate.MaxUnavailable, fmt.Sprintf("must be either an int or a percentage: %v", err.Error())),
 				)
+			} else if strategyChanged && isOverHundredPercent(newMD.Spec.Strategy.RollingUpdate.MaxUnavailable) {
+				allErrs = append(
+					allErrs,
+					field.Invalid(specPath.Child("strategy", "rollingUpdate", "maxUnavailable"),
+						newMD.Spec.Strategy.RollingUpdate.MaxUnavailable, "must not be greater than 100%"),
+				)
 			}
 		}
+
+		// Rollouts can't make progress if neither a new Machine can be created nor an old one deleted.
+		if strategyChanged && isZeroIntOrPercent(newMD.Spec.Strategy.RollingUpdate.MaxSurge) && isZeroIntOrPercent(newMD.Spec.Strategy.RollingUpdate.MaxUnavailable) {
+			allErrs = append(
+				allErrs,
+				field.Invalid(specPath.Child("strategy", "rollingUpdate", "maxUnavailable"),
+					newMD.Spec.Strategy.RollingUpdate.MaxUnavailable, "must not be 0 when maxSurge is 0"),
+			)
+		}
 	}
 
 	if newMD.Spec.Strategy != nil && newMD.Spec.Strategy.Remediation != nil {
@@ -291,8 +347,20 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 		}
 	}
 
+	var oldTemplate *clusterv1.MachineTemplateSpec
+	if oldMD != nil {
+		oldTemplate = &oldMD.Spec.Template
+	}
+	allErrs = append(allErrs, validateMachineTemplateRefs(specPath.Child("template", "spec"), oldTemplate, &newMD.Spec.Template, newMD.Namespace)...)
+
 	// Validate the metadata of the template.
 	allErrs = append(allErrs, newMD.Spec.Template.ObjectMeta.Validate(specPath.Child("template", "metadata"))...)
+	var oldTemplateAnnotations map[string]string
+	if oldTemplate != nil {
+		oldTemplateAnnotations = oldTemplate.Annotations
+	}
+	allErrs = append(allErrs, validateMachineAnnotations(oldTemplateAnnotations, newMD.Spec.Template.Annotations, specPath.Child("template", "metadata", "annotations"))...)
+	allErrs = append(allErrs, validateMachineTaints(newMD.Spec.Template.Spec.Taints, specPath.Child("template", "spec", "taints"))...)
 
 	if len(allErrs) == 0 {
 		return nil
@@ -301,6 +369,29 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 	return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("MachineDeployment").GroupKind(), newMD.Name, allErrs)
 }
 
+// isRollbackToExistingMachineSet returns true if the template of the MachineDeployment matches the template of one of
+// its existing MachineSets, like after `clusterctl alpha rollout undo`.
+func (webhook *MachineDeployment) isRollbackToExistingMachineSet(ctx context.Context, md *clusterv1.MachineDeployment) (bool, error) {
+	if webhook.Client == nil {
+		return false, nil
+	}
+
+	machineSets := &clusterv1.MachineSetList{}
+	if err := webhook.Client.List(ctx, machineSets,
+		client.InNamespace(md.Namespace),
+		client.MatchingLabels{clusterv1.MachineDeploymentNameLabel: md.Name},
+	); err != nil {
+		return false, errors.Wrapf(err, "failed to list MachineSets of MachineDeployment %s", klog.KObj(md))
+	}
+
+	for i := range machineSets.Items {
+		if upToDate, _, _ := mdutil.MachineTemplateUpToDate(&machineSets.Items[i].Spec.Template, &md.Spec.Template); upToDate {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // calculateMachineDeploymentReplicas calculates the default value of the replicas field.
 // The value will be calculated based on the following logic:
 // * if replicas is already set on newMD, keep the current value
@@ -396,3 +487,23 @@ func calculateMachineDeploymentReplicas(ctx context.Context, oldMD *clusterv1.Ma
 	}
 	return 1, nil
 }
+
+// isZeroIntOrPercent returns true if the value is explicitly set to 0 or 0%.
+func isZeroIntOrPercent(v *intstr.IntOrString) bool {
+	if v == nil {
+		return false
+	}
+	if v.Type == intstr.String {
+		return v.StrVal == "0%"
+	}
+	return v.IntVal == 0
+}
+
+// isOverHundredPercent returns true if the value is a percentage greater than 100%.
+func isOverHundredPercent(v *intstr.IntOrString) bool {
+	if v == nil || v.Type != intstr.String {
+		return false
+	}
+	percent, err := strconv.Atoi(strings.TrimSuffix(v.StrVal, "%"))
+	return err == nil && percent > 100
+}
@@ -23,13 +23,18 @@ import (
 
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/webhooks/util"
 )
 
@@ -260,6 +265,10 @@ func TestMachineDeploymentValidation(t *testing.T) {
 	goodMaxSurgeInt := intstr.FromInt(1)
 	goodMaxUnavailableInt := intstr.FromInt(0)
 	goodMaxInFlightInt := intstr.FromInt(5)
+
+	zeroMaxSurgeInt := intstr.FromInt(0)
+	zeroMaxSurgePercentage := intstr.FromString("0%")
+	overHundredMaxUnavailablePercentage := intstr.FromString("150%")
 	tests := []struct {
 		name      string
 		md        *clusterv1.MachineDeployment
@@ -268,6 +277,8 @@ func TestMachineDeploymentValidation(t *testing.T) {
 		labels    map[string]string
 		strategy  clusterv1.MachineDeploymentStrategy
 		expectErr bool
+		// allowedIfUnchanged is set for checks which are only enforced on create or when spec.strategy changes.
+		allowedIfUnchanged bool
 	}{
 		{
 			name:      "pass with name of under 63 characters",
@@ -355,6 +366,48 @@ func TestMachineDeploymentValidation(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name:      "should return error if both maxSurge and maxUnavailable are 0",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentStrategy{
+				Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+				RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+					MaxUnavailable: &goodMaxUnavailableInt,
+					MaxSurge:       &zeroMaxSurgeInt,
+				},
+			},
+			expectErr:          true,
+			allowedIfUnchanged: true,
+		},
+		{
+			name:      "should return error if both maxSurge and maxUnavailable are 0%",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentStrategy{
+				Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+				RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+					MaxUnavailable: &goodMaxUnavailablePercentage,
+					MaxSurge:       &zeroMaxSurgePercentage,
+				},
+			},
+			expectErr:          true,
+			allowedIfUnchanged: true,
+		},
+		{
+			name:      "should return error if maxUnavailable is over 100%",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentStrategy{
+				Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+				RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+					MaxUnavailable: &overHundredMaxUnavailablePercentage,
+					MaxSurge:       &goodMaxSurgeInt,
+				},
+			},
+			expectErr:          true,
+			allowedIfUnchanged: true,
+		},
 		{
 			name:      "should return error for invalid remediation maxInFlight",
 			selectors: map[string]string{"foo": "bar"},
@@ -448,8 +501,21 @@ func TestMachineDeploymentValidation(t *testing.T) {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(warnings).To(BeEmpty())
 				warnings, err = webhook.ValidateUpdate(ctx, md, md)
-				g.Expect(err).To(HaveOccurred())
+				if tt.allowedIfUnchanged {
+					g.Expect(err).ToNot(HaveOccurred())
+				} else {
+					g.Expect(err).To(HaveOccurred())
+				}
 				g.Expect(warnings).To(BeEmpty())
+				if tt.allowedIfUnchanged {
+					oldMD := md.DeepCopy()
+					oldMD.Spec.Strategy = &clusterv1.MachineDeploymentStrategy{
+						Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+					}
+					warnings, err = webhook.ValidateUpdate(ctx, oldMD, md)
+					g.Expect(err).To(HaveOccurred())
+					g.Expect(warnings).To(BeEmpty())
+				}
 			} else {
 				warnings, err := webhook.ValidateCreate(ctx, md)
 				g.Expect(err).ToNot(HaveOccurred())
@@ -462,6 +528,50 @@ func TestMachineDeploymentValidation(t *testing.T) {
 	}
 }
 
+func TestMachineDeploymentReplicasValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		replicas  *int32
+		expectErr bool
+	}{
+		{
+			name:      "should succeed when replicas is not set",
+			replicas:  nil,
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when replicas is 0",
+			replicas:  ptr.To[int32](0),
+			expectErr: false,
+		},
+		{
+			name:      "should return error when replicas is negative",
+			replicas:  ptr.To[int32](-1),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := &clusterv1.MachineDeployment{
+				Spec: clusterv1.MachineDeploymentSpec{
+					Replicas: tt.replicas,
+				},
+			}
+
+			warnings, err := (&MachineDeployment{}).ValidateCreate(ctx, md)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
+
 func TestMachineDeploymentVersionValidation(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -534,6 +644,94 @@ func TestMachineDeploymentVersionValidation(t *testing.T) {
 	}
 }
 
+func TestMachineDeploymentVersionDowngradeValidation(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachineSetPreflightChecks, true)
+
+	machineDeployment := func(version string) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "md1",
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				ClusterName: "test-cluster",
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						ClusterName: "test-cluster",
+						Version:     ptr.To(version),
+						InfrastructureRef: corev1.ObjectReference{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+							Kind:       "GenericInfrastructureMachineTemplate",
+							Name:       "infra-template-" + version,
+						},
+					},
+				},
+			},
+		}
+	}
+	machineSet := func(name, mdName string, template clusterv1.MachineTemplateSpec) *clusterv1.MachineSet {
+		return &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				Labels: map[string]string{
+					clusterv1.MachineDeploymentNameLabel: mdName,
+				},
+			},
+			Spec: clusterv1.MachineSetSpec{
+				ClusterName: "test-cluster",
+				Template:    template,
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		machineSets []*clusterv1.MachineSet
+		expectErr   bool
+	}{
+		{
+			name:      "should fail on a minor version downgrade",
+			expectErr: true,
+		},
+		{
+			name: "should pass on a minor version downgrade to the template of an existing MachineSet (rollout undo)",
+			machineSets: []*clusterv1.MachineSet{
+				machineSet("md1-old", "md1", machineDeployment("v1.30.5").Spec.Template),
+				machineSet("md1-new", "md1", machineDeployment("v1.31.0").Spec.Template),
+			},
+			expectErr: false,
+		},
+		{
+			name: "should fail on a minor version downgrade if only a MachineSet of another MachineDeployment has the template",
+			machineSets: []*clusterv1.MachineSet{
+				machineSet("md2-old", "md2", machineDeployment("v1.30.5").Spec.Template),
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			objs := []client.Object{}
+			for _, ms := range tt.machineSets {
+				objs = append(objs, ms)
+			}
+			webhook := MachineDeployment{
+				Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(objs...).Build(),
+			}
+
+			_, err := webhook.ValidateUpdate(ctx, machineDeployment("v1.31.0"), machineDeployment("v1.30.5"))
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
 func TestMachineDeploymentClusterNameImmutable(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -647,3 +845,40 @@ func TestMachineDeploymentTemplateMetadataValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineDeploymentWaitForNodeDeregistrationAnnotationValidation(t *testing.T) {
+	g := NewWithT(t)
+
+	md := &clusterv1.MachineDeployment{
+		Spec: clusterv1.MachineDeploymentSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{
+					Annotations: map[string]string{
+						clusterv1.WaitForNodeDeregistrationAnnotation: "30",
+					},
+				},
+			},
+		},
+	}
+	webhook := &MachineDeployment{}
+
+	_, err := webhook.ValidateCreate(ctx, md)
+	g.Expect(err).To(HaveOccurred())
+
+	// An invalid value which is not changed is allowed on update.
+	_, err = webhook.ValidateUpdate(ctx, md, md)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	oldMD := md.DeepCopy()
+	oldMD.Spec.Template.Annotations[clusterv1.WaitForNodeDeregistrationAnnotation] = "30s"
+	_, err = webhook.ValidateUpdate(ctx, oldMD, md)
+	g.Expect(err).To(HaveOccurred())
+
+	md.Spec.Template.Annotations[clusterv1.WaitForNodeDeregistrationAnnotation] = "-30s"
+	_, err = webhook.ValidateCreate(ctx, md)
+	g.Expect(err).To(HaveOccurred())
+
+	md.Spec.Template.Annotations[clusterv1.WaitForNodeDeregistrationAnnotation] = "30s"
+	_, err = webhook.ValidateCreate(ctx, md)
+	g.Expect(err).ToNot(HaveOccurred())
+}
@@ -22,13 +22,17 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -185,6 +189,28 @@ func (webhook *MachineSet) validate(oldMS, newMS *clusterv1.MachineSet) error {
 		if err := validateSkippedMachineSetPreflightChecks(newMS); err != nil {
 			allErrs = append(allErrs, err)
 		}
+		if oldMS != nil {
+			if err := validateKubernetesVersionDowngrade(specPath.Child("template", "spec", "version"), oldMS.Spec.Template.Spec.Version, newMS.Spec.Template.Spec.Version, newMS); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+
+	var oldAnnotations map[string]string
+	if oldMS != nil {
+		oldAnnotations = oldMS.Annotations
+	}
+	allErrs = append(allErrs, validateAutoscalerCapacityAnnotations(oldAnnotations, newMS.Annotations)...)
+
+	if newMS.Spec.Replicas != nil && *newMS.Spec.Replicas < 0 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				specPath.Child("replicas"),
+				*newMS.Spec.Replicas,
+				"must be greater than or equal to 0",
+			),
+		)
 	}
 
 	if oldMS != nil && oldMS.Spec.ClusterName != newMS.Spec.ClusterName {
@@ -210,8 +236,20 @@ func (webhook *MachineSet) validate(oldMS, newMS *clusterv1.MachineSet) error {
 		}
 	}
 
+	var oldTemplate *clusterv1.MachineTemplateSpec
+	if oldMS != nil {
+		oldTemplate = &oldMS.Spec.Template
+	}
+	allErrs = append(allErrs, validateMachineTemplateRefs(specPath.Child("template", "spec"), oldTemplate, &newMS.Spec.Template, newMS.Namespace)...)
+
 	// Validate the metadata of the template.
 	allErrs = append(allErrs, newMS.Spec.Template.ObjectMeta.Validate(specPath.Child("template", "metadata"))...)
+	var oldTemplateAnnotations map[string]string
+	if oldTemplate != nil {
+		oldTemplateAnnotations = oldTemplate.Annotations
+	}
+	allErrs = append(allErrs, validateMachineAnnotations(oldTemplateAnnotations, newMS.Spec.Template.Annotations, specPath.Child("template", "metadata", "annotations"))...)
+	allErrs = append(allErrs, validateMachineTaints(newMS.Spec.Template.Spec.Taints, specPath.Child("template", "spec", "taints"))...)
 
 	if len(allErrs) == 0 {
 		return nil
@@ -220,6 +258,34 @@ func (webhook *MachineSet) validate(oldMS, newMS *clusterv1.MachineSet) error {
 	return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("MachineSet").GroupKind(), newMS.Name, allErrs)
 }
 
+// validateMachineTemplateRefs rejects references to bootstrap config templates and infrastructure machine templates
+// in a namespace different from the one of the MachineSet or MachineDeployment, because templates are always read from
+// and cloned into the namespace of the owning object, and a different namespace in the reference would be ignored.
+// NOTE: On update the namespace is validated only if the reference changed, to not block updates of existing objects.
+func validateMachineTemplateRefs(fldPath *field.Path, oldTemplate, newTemplate *clusterv1.MachineTemplateSpec, namespace string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	validateRef := func(fldPath *field.Path, oldRef, newRef *corev1.ObjectReference) {
+		if newRef == nil || newRef.Namespace == "" || newRef.Namespace == namespace {
+			return
+		}
+		if oldRef != nil && oldRef.Namespace == newRef.Namespace {
+			return
+		}
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), newRef.Namespace, "must match metadata.namespace"))
+	}
+
+	var oldConfigRef, oldInfrastructureRef *corev1.ObjectReference
+	if oldTemplate != nil {
+		oldConfigRef = oldTemplate.Spec.Bootstrap.ConfigRef
+		oldInfrastructureRef = &oldTemplate.Spec.InfrastructureRef
+	}
+	validateRef(fldPath.Child("bootstrap", "configRef"), oldConfigRef, newTemplate.Spec.Bootstrap.ConfigRef)
+	validateRef(fldPath.Child("infrastructureRef"), oldInfrastructureRef, &newTemplate.Spec.InfrastructureRef)
+
+	return allErrs
+}
+
 func validateSkippedMachineSetPreflightChecks(o client.Object) *field.Error {
 	if o == nil {
 		return nil
@@ -233,6 +299,7 @@ func validateSkippedMachineSetPreflightChecks(o client.Object) *field.Error {
 		clusterv1.MachineSetPreflightCheckAll,
 		clusterv1.MachineSetPreflightCheckKubeadmVersionSkew,
 		clusterv1.MachineSetPreflightCheckKubernetesVersionSkew,
+		clusterv1.MachineSetPreflightCheckKubernetesVersionDowngrade,
 		clusterv1.MachineSetPreflightCheckControlPlaneIsStable,
 	)
 
@@ -254,6 +321,114 @@ func validateSkippedMachineSetPreflightChecks(o client.Object) *field.Error {
 	return nil
 }
 
+// validateKubernetesVersionDowngrade rejects changes of the version which are a minor version downgrade, like the
+// KubernetesVersionDowngrade preflight check of the MachineSet controller does for new MachineSets.
+// The check can be skipped in emergencies with the same annotation used for skipping the preflight check.
+func validateKubernetesVersionDowngrade(fldPath *field.Path, oldVersion, newVersion *string, o client.Object) *field.Error {
+	if oldVersion == nil || newVersion == nil {
+		return nil
+	}
+
+	skipped := sets.New[clusterv1.MachineSetPreflightCheck]()
+	for _, s := range strings.Split(o.GetAnnotations()[clusterv1.MachineSetSkipPreflightChecksAnnotation], ",") {
+		skipped.Insert(clusterv1.MachineSetPreflightCheck(strings.TrimSpace(s)))
+	}
+	if skipped.HasAny(clusterv1.MachineSetPreflightCheckAll, clusterv1.MachineSetPreflightCheckKubernetesVersionDowngrade) {
+		return nil
+	}
+
+	// Note: Invalid versions are reported by the semantic version validation.
+	oldSemver, err := semver.ParseTolerant(*oldVersion)
+	if err != nil {
+		return nil
+	}
+	newSemver, err := semver.ParseTolerant(*newVersion)
+	if err != nil {
+		return nil
+	}
+
+	if newSemver.Major == oldSemver.Major && newSemver.Minor < oldSemver.Minor {
+		return field.Forbidden(
+			fldPath,
+			fmt.Sprintf("version cannot be decreased from %q to %q, minor version downgrades are not supported by Kubernetes; "+
+				"to skip this check add %q to the %q annotation", *oldVersion, *newVersion,
+				clusterv1.MachineSetPreflightCheckKubernetesVersionDowngrade, clusterv1.MachineSetSkipPreflightChecksAnnotation),
+		)
+	}
+	return nil
+}
+
+// validateAutoscalerCapacityAnnotations validates the autoscaler capacity annotations.
+// Note: Only annotations which are added or changed are validated, to not block updates of existing objects.
+func validateAutoscalerCapacityAnnotations(oldAnnotations, newAnnotations map[string]string) field.ErrorList {
+	var allErrs field.ErrorList
+	annotationsPath := field.NewPath("metadata", "annotations")
+
+	for _, key := range []string{
+		clusterv1.AutoscalerCPUCapacityAnnotation,
+		clusterv1.AutoscalerMemoryCapacityAnnotation,
+		clusterv1.AutoscalerEphemeralDiskCapacityAnnotation,
+		clusterv1.AutoscalerMaxPodsCapacityAnnotation,
+		clusterv1.AutoscalerGPUCountCapacityAnnotation,
+	} {
+		value, ok := newAnnotations[key]
+		if !ok || value == oldAnnotations[key] {
+			continue
+		}
+		if q, err := resource.ParseQuantity(value); err != nil || q.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key(key), value, "must be a non-negative quantity"))
+		}
+	}
+
+	if value, ok := newAnnotations[clusterv1.AutoscalerGPUTypeCapacityAnnotation]; ok && value != oldAnnotations[clusterv1.AutoscalerGPUTypeCapacityAnnotation] {
+		for _, msg := range validation.IsQualifiedName(value) {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key(clusterv1.AutoscalerGPUTypeCapacityAnnotation), value, msg))
+		}
+	}
+
+	if value, ok := newAnnotations[clusterv1.AutoscalerLabelsCapacityAnnotation]; ok && value != oldAnnotations[clusterv1.AutoscalerLabelsCapacityAnnotation] {
+		fldPath := annotationsPath.Key(clusterv1.AutoscalerLabelsCapacityAnnotation)
+		for _, label := range strings.Split(value, ",") {
+			k, v, found := strings.Cut(strings.TrimSpace(label), "=")
+			if !found {
+				allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("label %q must be in the form key=value", label)))
+				continue
+			}
+			for _, msg := range validation.IsQualifiedName(k) {
+				allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("label key %q is invalid: %s", k, msg)))
+			}
+			for _, msg := range validation.IsValidLabelValue(v) {
+				allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("label value %q is invalid: %s", v, msg)))
+			}
+		}
+	}
+
+	if value, ok := newAnnotations[clusterv1.AutoscalerTaintsCapacityAnnotation]; ok && value != oldAnnotations[clusterv1.AutoscalerTaintsCapacityAnnotation] {
+		fldPath := annotationsPath.Key(clusterv1.AutoscalerTaintsCapacityAnnotation)
+		supportedEffects := sets.New(corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute)
+		for _, taint := range strings.Split(value, ",") {
+			// The value is optional, i.e. both key=value:Effect and key:Effect are valid taints.
+			keyValue, effect, found := strings.Cut(strings.TrimSpace(taint), ":")
+			k, v, _ := strings.Cut(keyValue, "=")
+			if !found {
+				allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("taint %q must be in the form key[=value]:Effect", taint)))
+				continue
+			}
+			for _, msg := range validation.IsQualifiedName(k) {
+				allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("taint key %q is invalid: %s", k, msg)))
+			}
+			for _, msg := range validation.IsValidLabelValue(v) {
+				allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("taint value %q is invalid: %s", v, msg)))
+			}
+			if !supportedEffects.Has(corev1.TaintEffect(effect)) {
+				allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("taint effect %q must be among: %v", effect, sets.List(supportedEffects))))
+			}
+		}
+	}
+
+	return allErrs
+}
+
 // calculateMachineSetReplicas calculates the default value of the replicas field.
 // The value will be calculated based on the following logic:
 // * if replicas is already set on newMS, keep the current value
@@ -22,8 +22,10 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -349,6 +351,50 @@ func TestMachineSetClusterNameImmutable(t *testing.T) {
 	}
 }
 
+func TestMachineSetReplicasValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		replicas  *int32
+		expectErr bool
+	}{
+		{
+			name:      "should succeed when replicas is not set",
+			replicas:  nil,
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when replicas is 0",
+			replicas:  ptr.To[int32](0),
+			expectErr: false,
+		},
+		{
+			name:      "should return error when replicas is negative",
+			replicas:  ptr.To[int32](-1),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ms := &clusterv1.MachineSet{
+				Spec: clusterv1.MachineSetSpec{
+					Replicas: tt.replicas,
+				},
+			}
+
+			warnings, err := (&MachineSet{}).ValidateCreate(ctx, ms)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
+
 func TestMachineSetVersionValidation(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -486,6 +532,238 @@ func TestValidateSkippedMachineSetPreflightChecks(t *testing.T) {
 	}
 }
 
+func TestValidateKubernetesVersionDowngrade(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldVersion  *string
+		newVersion  *string
+		annotations map[string]string
+		expectErr   bool
+	}{
+		{
+			name:       "should pass if the version is not set",
+			oldVersion: ptr.To("v1.31.0"),
+			newVersion: nil,
+			expectErr:  false,
+		},
+		{
+			name:       "should pass on a minor version upgrade",
+			oldVersion: ptr.To("v1.30.2"),
+			newVersion: ptr.To("v1.31.0"),
+			expectErr:  false,
+		},
+		{
+			name:       "should pass on a patch version downgrade",
+			oldVersion: ptr.To("v1.31.2"),
+			newVersion: ptr.To("v1.31.1"),
+			expectErr:  false,
+		},
+		{
+			name:       "should fail on a minor version downgrade",
+			oldVersion: ptr.To("v1.31.0"),
+			newVersion: ptr.To("v1.30.5"),
+			expectErr:  true,
+		},
+		{
+			name:       "should pass on a minor version downgrade if the preflight check is skipped",
+			oldVersion: ptr.To("v1.31.0"),
+			newVersion: ptr.To("v1.30.5"),
+			annotations: map[string]string{
+				clusterv1.MachineSetSkipPreflightChecksAnnotation: string(clusterv1.MachineSetPreflightCheckKubeadmVersionSkew) + ", " + string(clusterv1.MachineSetPreflightCheckKubernetesVersionDowngrade),
+			},
+			expectErr: false,
+		},
+		{
+			name:       "should pass on a minor version downgrade if all preflight checks are skipped",
+			oldVersion: ptr.To("v1.31.0"),
+			newVersion: ptr.To("v1.30.5"),
+			annotations: map[string]string{
+				clusterv1.MachineSetSkipPreflightChecksAnnotation: string(clusterv1.MachineSetPreflightCheckAll),
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ms := &clusterv1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			err := validateKubernetesVersionDowngrade(field.NewPath("spec", "template", "spec", "version"), tt.oldVersion, tt.newVersion, ms)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateMachineTemplateRefs(t *testing.T) {
+	template := func(configRefNamespace, infrastructureRefNamespace string) *clusterv1.MachineTemplateSpec {
+		return &clusterv1.MachineTemplateSpec{
+			Spec: clusterv1.MachineSpec{
+				Bootstrap: clusterv1.Bootstrap{
+					ConfigRef: &corev1.ObjectReference{Name: "bootstrap-template", Namespace: configRefNamespace},
+				},
+				InfrastructureRef: corev1.ObjectReference{Name: "infra-template", Namespace: infrastructureRefNamespace},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		oldTemplate *clusterv1.MachineTemplateSpec
+		newTemplate *clusterv1.MachineTemplateSpec
+		expectErrs  int
+	}{
+		{
+			name:        "should pass if the namespaces are not set",
+			newTemplate: template("", ""),
+		},
+		{
+			name:        "should pass if the namespaces match",
+			newTemplate: template("default", "default"),
+		},
+		{
+			name: "should pass without a bootstrap config ref",
+			newTemplate: &clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					InfrastructureRef: corev1.ObjectReference{Name: "infra-template", Namespace: "default"},
+				},
+			},
+		},
+		{
+			name:        "should fail if the namespaces do not match",
+			newTemplate: template("other", "other"),
+			expectErrs:  2,
+		},
+		{
+			name:        "should fail if a namespace is changed to one which does not match",
+			oldTemplate: template("", ""),
+			newTemplate: template("default", "other"),
+			expectErrs:  1,
+		},
+		{
+			name:        "should pass if a namespace which does not match is not changed",
+			oldTemplate: template("other", "other"),
+			newTemplate: template("other", "other"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			errs := validateMachineTemplateRefs(field.NewPath("spec", "template", "spec"), tt.oldTemplate, tt.newTemplate, "default")
+			g.Expect(errs).To(HaveLen(tt.expectErrs))
+		})
+	}
+}
+
+func TestValidateAutoscalerCapacityAnnotations(t *testing.T) {
+	tests := []struct {
+		name           string
+		oldAnnotations map[string]string
+		annotations    map[string]string
+		expectErr      bool
+	}{
+		{
+			name:        "should pass if no capacity annotations are set",
+			annotations: nil,
+			expectErr:   false,
+		},
+		{
+			name: "should pass with valid capacity annotations",
+			annotations: map[string]string{
+				clusterv1.AutoscalerCPUCapacityAnnotation:           "4",
+				clusterv1.AutoscalerMemoryCapacityAnnotation:        "16G",
+				clusterv1.AutoscalerEphemeralDiskCapacityAnnotation: "100Gi",
+				clusterv1.AutoscalerMaxPodsCapacityAnnotation:       "110",
+				clusterv1.AutoscalerGPUTypeCapacityAnnotation:       "nvidia.com/gpu",
+				clusterv1.AutoscalerGPUCountCapacityAnnotation:      "2",
+				clusterv1.AutoscalerLabelsCapacityAnnotation:        "key1=value1,example.com/key2=",
+				clusterv1.AutoscalerTaintsCapacityAnnotation:        "key1=value1:NoSchedule,key2=:NoExecute",
+			},
+			expectErr: false,
+		},
+		{
+			name: "should fail if memory is not a quantity",
+			annotations: map[string]string{
+				clusterv1.AutoscalerMemoryCapacityAnnotation: "16 gigabytes",
+			},
+			expectErr: true,
+		},
+		{
+			name: "should fail if cpu is negative",
+			annotations: map[string]string{
+				clusterv1.AutoscalerCPUCapacityAnnotation: "-1",
+			},
+			expectErr: true,
+		},
+		{
+			name: "should fail if labels are not key=value pairs",
+			annotations: map[string]string{
+				clusterv1.AutoscalerLabelsCapacityAnnotation: "key1=value1,key2",
+			},
+			expectErr: true,
+		},
+		{
+			name: "should fail if a taint has an invalid effect",
+			annotations: map[string]string{
+				clusterv1.AutoscalerTaintsCapacityAnnotation: "key1=value1:NoWay",
+			},
+			expectErr: true,
+		},
+		{
+			name: "should pass if a taint has no value",
+			annotations: map[string]string{
+				clusterv1.AutoscalerTaintsCapacityAnnotation: "key1:NoSchedule,key2=value2:NoExecute",
+			},
+			expectErr: false,
+		},
+		{
+			name: "should fail if a taint has no key",
+			annotations: map[string]string{
+				clusterv1.AutoscalerTaintsCapacityAnnotation: "=value1:NoSchedule",
+			},
+			expectErr: true,
+		},
+		{
+			name: "should fail if a taint has no effect",
+			annotations: map[string]string{
+				clusterv1.AutoscalerTaintsCapacityAnnotation: "key1=value1",
+			},
+			expectErr: true,
+		},
+		{
+			name: "should pass if an invalid annotation is not changed",
+			oldAnnotations: map[string]string{
+				clusterv1.AutoscalerMemoryCapacityAnnotation: "16 gigabytes",
+			},
+			annotations: map[string]string{
+				clusterv1.AutoscalerMemoryCapacityAnnotation: "16 gigabytes",
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := validateAutoscalerCapacityAnnotations(tt.oldAnnotations, tt.annotations)
+			if tt.expectErr {
+				g.Expect(errs).ToNot(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestMachineSetTemplateMetadataValidation(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -541,3 +819,69 @@ func TestMachineSetTemplateMetadataValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineSetBootstrapDataSecretMissingPolicyAnnotationValidation(t *testing.T) {
+	g := NewWithT(t)
+
+	ms := &clusterv1.MachineSet{
+		Spec: clusterv1.MachineSetSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{
+					Annotations: map[string]string{
+						clusterv1.MachineBootstrapDataSecretMissingPolicyAnnotation: "Recreate",
+					},
+				},
+			},
+		},
+	}
+	webhook := &MachineSet{}
+
+	_, err := webhook.ValidateCreate(ctx, ms)
+	g.Expect(err).To(HaveOccurred())
+
+	// An unknown value which is not changed is allowed on update.
+	_, err = webhook.ValidateUpdate(ctx, ms, ms)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	oldMS := ms.DeepCopy()
+	oldMS.Spec.Template.Annotations[clusterv1.MachineBootstrapDataSecretMissingPolicyAnnotation] = clusterv1.MachineBootstrapDataSecretMissingPolicyReport
+	_, err = webhook.ValidateUpdate(ctx, oldMS, ms)
+	g.Expect(err).To(HaveOccurred())
+
+	ms.Spec.Template.Annotations[clusterv1.MachineBootstrapDataSecretMissingPolicyAnnotation] = clusterv1.MachineBootstrapDataSecretMissingPolicyIgnore
+	_, err = webhook.ValidateCreate(ctx, ms)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestMachineSetWaitForNodeDeregistrationAnnotationValidation(t *testing.T) {
+	g := NewWithT(t)
+
+	ms := &clusterv1.MachineSet{
+		Spec: clusterv1.MachineSetSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{
+					Annotations: map[string]string{
+						clusterv1.WaitForNodeDeregistrationAnnotation: "30",
+					},
+				},
+			},
+		},
+	}
+	webhook := &MachineSet{}
+
+	_, err := webhook.ValidateCreate(ctx, ms)
+	g.Expect(err).To(HaveOccurred())
+
+	// An invalid value which is not changed is allowed on update.
+	_, err = webhook.ValidateUpdate(ctx, ms, ms)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	oldMS := ms.DeepCopy()
+	oldMS.Spec.Template.Annotations[clusterv1.WaitForNodeDeregistrationAnnotation] = "30s"
+	_, err = webhook.ValidateUpdate(ctx, oldMS, ms)
+	g.Expect(err).To(HaveOccurred())
+
+	ms.Spec.Template.Annotations[clusterv1.WaitForNodeDeregistrationAnnotation] = "30s"
+	_, err = webhook.ValidateCreate(ctx, ms)
+	g.Expect(err).ToNot(HaveOccurred())
+}
@@ -98,9 +98,13 @@ func (webhook *ExtensionConfig) validate(_ context.Context, _, newExtensionConfi
 	// NOTE: ExtensionConfig is behind the RuntimeSDK feature gate flag; the web hook
 	// must prevent creating and updating objects in case the feature flag is disabled.
 	if !feature.Gates.Enabled(feature.RuntimeSDK) {
-		return nil, field.Forbidden(
-			field.NewPath("spec"),
-			"can be set only if the RuntimeSDK feature flag is enabled",
+		return nil, apierrors.NewInvalid(
+			runtimev1.GroupVersion.WithKind("ExtensionConfig").GroupKind(),
+			newExtensionConfig.Name,
+			field.ErrorList{field.Forbidden(
+				field.NewPath("spec"),
+				"can be set only if the RuntimeSDK feature flag is enabled",
+			)},
 		)
 	}
 
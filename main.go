@@ -46,12 +46,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/api/v1beta1/index"
 	"sigs.k8s.io/cluster-api/controllers"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
 	addonscontrollers "sigs.k8s.io/cluster-api/exp/addons/controllers"
@@ -89,11 +91,12 @@ var (
 
 	// flags.
 	enableLeaderElection        bool
+	leaderElectionNamespace     string
 	leaderElectionLeaseDuration time.Duration
 	leaderElectionRenewDeadline time.Duration
 	leaderElectionRetryPeriod   time.Duration
 	watchFilterValue            string
-	watchNamespace              string
+	watchNamespace              []string
 	profilerAddress             string
 	enableContentionProfiling   bool
 	syncPeriod                  time.Duration
@@ -111,6 +114,7 @@ var (
 	// core Cluster API specific flags.
 	remoteConnectionGracePeriod     time.Duration
 	remoteConditionsGracePeriod     time.Duration
+	machineFailureThreshold         int
 	clusterTopologyConcurrency      int
 	clusterCacheConcurrency         int
 	clusterClassConcurrency         int
@@ -157,6 +161,9 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 
+	fs.StringVar(&leaderElectionNamespace, "leader-elect-namespace", "",
+		"Namespace where the leader election resource lock is created. If unspecified, the namespace the controller manager is running in is used.")
+
 	fs.DurationVar(&leaderElectionLeaseDuration, "leader-elect-lease-duration", 15*time.Second,
 		"Interval at which non-leader candidates will wait to force acquire leadership (duration string)")
 
@@ -166,8 +173,8 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&leaderElectionRetryPeriod, "leader-elect-retry-period", 2*time.Second,
 		"Duration the LeaderElector clients should wait between tries of actions (duration string)")
 
-	fs.StringVar(&watchNamespace, "namespace", "",
-		"Namespace that the controller watches to reconcile cluster-api objects. If unspecified, the controller watches for cluster-api objects across all namespaces.")
+	fs.StringSliceVar(&watchNamespace, "namespace", nil,
+		"Comma-separated list of namespaces that the controller watches to reconcile cluster-api objects. If unspecified, the controller watches for cluster-api objects across all namespaces.")
 
 	fs.StringVar(&watchFilterValue, "watch-filter", "",
 		fmt.Sprintf("Label value that the controller watches to reconcile cluster-api objects. Label key is always %s. If unspecified, the controller watches for all cluster-api objects.", clusterv1.WatchLabel))
@@ -186,6 +193,10 @@ func InitFlags(fs *pflag.FlagSet) {
 		"Grace period after which remote conditions (e.g. `NodeHealthy`) are set to `Unknown`, "+
 			"the grace period starts from the last successful health probe to the workload cluster")
 
+	fs.IntVar(&machineFailureThreshold, "machine-reconcile-failure-threshold", 0,
+		"Number of consecutive failed reconciles after which a Machine is parked with the `ReconcileStuck` condition "+
+			"and only reconciled again after a longer interval. The default 0 disables parking")
+
 	fs.IntVar(&clusterTopologyConcurrency, "clustertopology-concurrency", 10,
 		"Number of clusters to process simultaneously")
 
@@ -317,9 +328,10 @@ func main() {
 	}
 
 	var watchNamespaces map[string]cache.Config
-	if watchNamespace != "" {
-		watchNamespaces = map[string]cache.Config{
-			watchNamespace: {},
+	if len(watchNamespace) > 0 {
+		watchNamespaces = map[string]cache.Config{}
+		for _, namespace := range watchNamespace {
+			watchNamespaces[namespace] = cache.Config{}
 		}
 	}
 
@@ -334,6 +346,7 @@ func main() {
 		Scheme:                     scheme,
 		LeaderElection:             enableLeaderElection,
 		LeaderElectionID:           "controller-leader-election-capi",
+		LeaderElectionNamespace:    leaderElectionNamespace,
 		LeaseDuration:              &leaderElectionLeaseDuration,
 		RenewDeadline:              &leaderElectionRenewDeadline,
 		RetryPeriod:                &leaderElectionRetryPeriod,
@@ -384,6 +397,7 @@ func main() {
 	ctx := ctrl.SetupSignalHandler()
 
 	setupChecks(mgr)
+	setupMetrics()
 	setupIndexes(ctx, mgr)
 	clusterCache := setupReconcilers(ctx, mgr, watchNamespaces, &syncPeriod)
 	setupWebhooks(mgr, clusterCache)
@@ -407,6 +421,17 @@ func setupChecks(mgr ctrl.Manager) {
 	}
 }
 
+func setupMetrics() {
+	if err := external.RegisterMetrics(ctrlmetrics.Registry); err != nil {
+		setupLog.Error(err, "Unable to register metrics")
+		os.Exit(1)
+	}
+	if err := clustercache.RegisterMetrics(ctrlmetrics.Registry); err != nil {
+		setupLog.Error(err, "Unable to register metrics")
+		os.Exit(1)
+	}
+}
+
 func setupIndexes(ctx context.Context, mgr ctrl.Manager) {
 	if err := index.AddDefaultIndexes(ctx, mgr); err != nil {
 		setupLog.Error(err, "Unable to setup indexes")
@@ -552,6 +577,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		Client:                      mgr.GetClient(),
 		APIReader:                   mgr.GetAPIReader(),
 		ClusterCache:                clusterCache,
+		SecretCachingClient:         secretCachingClient,
 		WatchFilterValue:            watchFilterValue,
 		RemoteConnectionGracePeriod: remoteConnectionGracePeriod,
 	}).SetupWithManager(ctx, mgr, concurrency(clusterConcurrency)); err != nil {
@@ -564,6 +590,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		ClusterCache:                clusterCache,
 		WatchFilterValue:            watchFilterValue,
 		RemoteConditionsGracePeriod: remoteConditionsGracePeriod,
+		ReconcileFailureThreshold:   machineFailureThreshold,
 	}).SetupWithManager(ctx, mgr, concurrency(machineConcurrency)); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "Machine")
 		os.Exit(1)
@@ -654,7 +681,7 @@ func setupWebhooks(mgr ctrl.Manager, clusterCacheReader webhooks.ClusterCacheRea
 		os.Exit(1)
 	}
 
-	if err := (&webhooks.MachineDeployment{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&webhooks.MachineDeployment{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "Unable to create webhook", "webhook", "MachineDeployment")
 		os.Exit(1)
 	}
@@ -23,6 +23,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -344,6 +345,7 @@ func ScaleSpec(ctx context.Context, inputGetter func() ScaleSpecInput) {
 				createClusterWorker(ctx, input.BootstrapClusterProxy, inputChan, resultChan, wg, namespace.Name, input.DeployClusterInSeparateNamespaces, baseClusterClassYAML, baseClusterTemplateYAML, creator, input.PostScaleClusterNamespaceCreated)
 			},
 		})
+		logWorkResults("Created", clusterCreateResults)
 		if err != nil {
 			// Call Fail to notify ginkgo that the suit has failed.
 			// Ginkgo will print the first observed error failure in this case.
@@ -376,7 +378,7 @@ func ScaleSpec(ctx context.Context, inputGetter func() ScaleSpecInput) {
 			}
 
 			// Upgrade all the workload clusters.
-			_, err = workConcurrentlyAndWait(ctx, workConcurrentlyAndWaitInput{
+			clusterUpgradeResults, err := workConcurrentlyAndWait(ctx, workConcurrentlyAndWaitInput{
 				ClusterNames: clusterNamesToUpgrade,
 				Concurrency:  concurrency,
 				FailFast:     input.FailFast,
@@ -384,6 +386,7 @@ func ScaleSpec(ctx context.Context, inputGetter func() ScaleSpecInput) {
 					upgradeClusterAndWaitWorker(ctx, inputChan, resultChan, wg, namespace.Name, input.DeployClusterInSeparateNamespaces, baseClusterTemplateYAML, upgrader)
 				},
 			})
+			logWorkResults("Upgraded", clusterUpgradeResults)
 			if err != nil {
 				// Call Fail to notify ginkgo that the suit has failed.
 				// Ginkgo will print the first observed error failure in this case.
@@ -410,7 +413,7 @@ func ScaleSpec(ctx context.Context, inputGetter func() ScaleSpecInput) {
 
 		By("Delete the workload clusters concurrently")
 		// Now delete all the workload clusters.
-		_, err = workConcurrentlyAndWait(ctx, workConcurrentlyAndWaitInput{
+		clusterDeleteResults, err := workConcurrentlyAndWait(ctx, workConcurrentlyAndWaitInput{
 			ClusterNames: clusterNamesToDelete,
 			Concurrency:  concurrency,
 			FailFast:     input.FailFast,
@@ -418,6 +421,7 @@ func ScaleSpec(ctx context.Context, inputGetter func() ScaleSpecInput) {
 				deleteClusterAndWaitWorker(ctx, inputChan, resultChan, wg, input.BootstrapClusterProxy.GetClient(), namespace.Name, input.DeployClusterInSeparateNamespaces)
 			},
 		})
+		logWorkResults("Deleted", clusterDeleteResults)
 		if err != nil {
 			// Call Fail to notify ginkgo that the suit has failed.
 			// Ginkgo will print the first observed error failure in this case.
@@ -599,11 +603,13 @@ func createClusterWorker(ctx context.Context, clusterProxy framework.ClusterProx
 
 				// This defer will catch ginkgo failures and record them.
 				// The recorded panics are then handled by the parent goroutine.
+				start := time.Now()
 				defer func() {
 					e := recover()
 					resultChan <- workResult{
 						clusterName: clusterName,
 						err:         e,
+						duration:    time.Since(start),
 					}
 				}()
 
@@ -678,11 +684,13 @@ func deleteClusterAndWaitWorker(ctx context.Context, inputChan <-chan string, re
 
 				// This defer will catch ginkgo failures and record them.
 				// The recorded panics are then handled by the parent goroutine.
+				start := time.Now()
 				defer func() {
 					e := recover()
 					resultChan <- workResult{
 						clusterName: clusterName,
 						err:         e,
+						duration:    time.Since(start),
 					}
 				}()
 
@@ -770,11 +778,13 @@ func upgradeClusterAndWaitWorker(ctx context.Context, inputChan <-chan string, r
 
 				// This defer will catch ginkgo failures and record them.
 				// The recorded panics are then handled by the parent goroutine.
+				start := time.Now()
 				defer func() {
 					e := recover()
 					resultChan <- workResult{
 						clusterName: clusterName,
 						err:         e,
+						duration:    time.Since(start),
 					}
 				}()
 
@@ -832,6 +842,33 @@ func getClusterResourcesForUpgrade(ctx context.Context, c client.Client, namespa
 type workResult struct {
 	clusterName string
 	err         any
+	duration    time.Duration
+}
+
+// logWorkResults logs the number of failures and the latency distribution of an operation across all the clusters,
+// so performance regressions can be compared across runs.
+func logWorkResults(operation string, results []workResult) {
+	if len(results) == 0 {
+		return
+	}
+
+	failures := 0
+	durations := make([]time.Duration, 0, len(results))
+	var total time.Duration
+	for _, result := range results {
+		if result.err != nil {
+			failures++
+		}
+		durations = append(durations, result.duration)
+		total += result.duration
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	percentile := func(p int) time.Duration {
+		return durations[(len(durations)-1)*p/100]
+	}
+	log.Logf("%s %d clusters (%d failed): min %s, avg %s, p50 %s, p90 %s, p99 %s, max %s", operation, len(results), failures,
+		durations[0], total/time.Duration(len(durations)), percentile(50), percentile(90), percentile(99), durations[len(durations)-1])
 }
 
 func modifyMachineDeployments(baseClusterTemplateYAML []byte, count int) []byte {
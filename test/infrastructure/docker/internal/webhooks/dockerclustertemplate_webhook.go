@@ -63,20 +63,24 @@ var _ webhook.CustomValidator = &DockerClusterTemplate{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (webhook *DockerClusterTemplate) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	clusterTemplate, ok := obj.(*infrav1.DockerClusterTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a DockerClusterTemplate but got a %T", obj))
+	}
+
 	// NOTE: DockerClusterTemplate is behind ClusterTopology feature gate flag; the web hook
 	// must prevent creating new objects in case the feature flag is disabled.
 	if !feature.Gates.Enabled(feature.ClusterTopology) {
-		return nil, field.Forbidden(
-			field.NewPath("spec"),
-			"can be set only if the ClusterTopology feature flag is enabled",
+		return nil, apierrors.NewInvalid(
+			infrav1.GroupVersion.WithKind("DockerClusterTemplate").GroupKind(),
+			clusterTemplate.Name,
+			field.ErrorList{field.Forbidden(
+				field.NewPath("spec"),
+				"can be set only if the ClusterTopology feature flag is enabled",
+			)},
 		)
 	}
 
-	clusterTemplate, ok := obj.(*infrav1.DockerClusterTemplate)
-	if !ok {
-		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a DockerClusterTemplate but got a %T", obj))
-	}
-
 	allErrs := validateDockerClusterSpec(clusterTemplate.Spec.Template.Spec)
 
 	// Validate the metadata of the template.
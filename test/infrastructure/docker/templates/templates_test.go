@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package templates contains tests validating the cluster templates shipped with the Docker provider,
+// which are used by the quick start and by Tilt.
+package templates
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/yamlprocessor"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/webhooks"
+	infrav1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta1"
+	infraexpv1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/exp/api/v1beta1"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+var (
+	ctx = ctrl.SetupSignalHandler()
+)
+
+// TestClusterTemplateDevelopment verifies that the development cluster template together with the quick-start
+// ClusterClass only contains known fields and passes the validation of the ClusterClass and of the Cluster variables.
+func TestClusterTemplateDevelopment(t *testing.T) {
+	g := NewWithT(t)
+
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)
+
+	variables := map[string]string{
+		"CLUSTER_NAME":                "development",
+		"NAMESPACE":                   metav1.NamespaceDefault,
+		"KUBERNETES_VERSION":          "v1.31.0",
+		"CONTROL_PLANE_MACHINE_COUNT": "1",
+		"WORKER_MACHINE_COUNT":        "1",
+	}
+
+	objs := append(renderTemplate(g, "cluster-template-development.yaml", variables), renderTemplate(g, "clusterclass-quick-start.yaml", variables)...)
+
+	var cluster *clusterv1.Cluster
+	var clusterClass *clusterv1.ClusterClass
+	templates := map[corev1.ObjectReference]bool{}
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *clusterv1.Cluster:
+			cluster = o
+		case *clusterv1.ClusterClass:
+			clusterClass = o
+		default:
+			gvk := obj.GetObjectKind().GroupVersionKind()
+			templates[corev1.ObjectReference{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind, Name: obj.GetName()}] = true
+		}
+	}
+	g.Expect(cluster).ToNot(BeNil())
+	g.Expect(clusterClass).ToNot(BeNil())
+
+	// Validate the ClusterClass and verify all the templates it references are shipped with it.
+	clusterClassWebhook := &webhooks.ClusterClass{}
+	g.Expect(clusterClassWebhook.Default(ctx, clusterClass)).To(Succeed())
+	_, err := clusterClassWebhook.ValidateCreate(ctx, clusterClass)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	refs := []*corev1.ObjectReference{
+		clusterClass.Spec.Infrastructure.Ref,
+		clusterClass.Spec.ControlPlane.Ref,
+		clusterClass.Spec.ControlPlane.MachineInfrastructure.Ref,
+	}
+	for _, md := range clusterClass.Spec.Workers.MachineDeployments {
+		refs = append(refs, md.Template.Bootstrap.Ref, md.Template.Infrastructure.Ref)
+	}
+	for _, mp := range clusterClass.Spec.Workers.MachinePools {
+		refs = append(refs, mp.Template.Bootstrap.Ref, mp.Template.Infrastructure.Ref)
+	}
+	for _, ref := range refs {
+		g.Expect(templates).To(HaveKey(corev1.ObjectReference{APIVersion: ref.APIVersion, Kind: ref.Kind, Name: ref.Name}))
+	}
+
+	// Verify the Cluster uses the ClusterClass and its classes, and validate the Cluster variables.
+	g.Expect(cluster.Spec.Topology.Class).To(Equal(clusterClass.Name))
+	for _, md := range cluster.Spec.Topology.Workers.MachineDeployments {
+		g.Expect(clusterClass.Spec.Workers.MachineDeployments).To(ContainElement(HaveField("Class", md.Class)))
+	}
+	for _, mp := range cluster.Spec.Topology.Workers.MachinePools {
+		g.Expect(clusterClass.Spec.Workers.MachinePools).To(ContainElement(HaveField("Class", mp.Class)))
+	}
+
+	// NOTE: the ClusterClass controller sets the variables of the ClusterClass in its status.
+	for _, v := range clusterClass.Spec.Variables {
+		clusterClass.Status.Variables = append(clusterClass.Status.Variables, clusterv1.ClusterClassStatusVariable{
+			Name: v.Name,
+			Definitions: []clusterv1.ClusterClassStatusVariableDefinition{
+				{
+					From:     clusterv1.VariableDefinitionFromInline,
+					Required: v.Required,
+					Metadata: v.Metadata,
+					Schema:   v.Schema,
+				},
+			},
+		})
+	}
+	g.Expect(webhooks.DefaultAndValidateVariables(ctx, cluster, nil, clusterClass).ToAggregate()).ToNot(HaveOccurred())
+}
+
+// renderTemplate processes the variables of the given template and converts its objects into typed objects,
+// failing on unknown fields.
+func renderTemplate(g *WithT, file string, variables map[string]string) []client.Object {
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(bootstrapv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(controlplanev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infraexpv1.AddToScheme(scheme)).To(Succeed())
+
+	content, err := os.ReadFile(file) //nolint:gosec // reading a file in tests is not a security issue.
+	g.Expect(err).ToNot(HaveOccurred())
+
+	rendered, err := yamlprocessor.NewSimpleProcessor().Process(content, func(name string) (string, error) {
+		return variables[name], nil
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	unstructuredObjs, err := utilyaml.ToUnstructured(rendered)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	objs := []client.Object{}
+	for i := range unstructuredObjs {
+		u := unstructuredObjs[i]
+		obj, err := scheme.New(u.GroupVersionKind())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(u.Object, obj, true)).To(Succeed(), "%s %s has unknown fields", u.GetKind(), u.GetName())
+		objs = append(objs, obj.(client.Object))
+	}
+	return objs
+}
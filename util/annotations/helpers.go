@@ -26,8 +26,9 @@ import (
 )
 
 // IsPaused returns true if the Cluster is paused or the object has the `paused` annotation.
+// A nil Cluster is tolerated, e.g. for objects not yet linked to a Cluster; in this case only the annotation is checked.
 func IsPaused(cluster *clusterv1.Cluster, o metav1.Object) bool {
-	if cluster.Spec.Paused {
+	if cluster != nil && cluster.Spec.Paused {
 		return true
 	}
 	return HasPaused(o)
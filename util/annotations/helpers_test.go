@@ -23,8 +23,66 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+func TestIsPaused(t *testing.T) {
+	tests := []struct {
+		name     string
+		cluster  *clusterv1.Cluster
+		obj      metav1.Object
+		expected bool
+	}{
+		{
+			name:     "not paused",
+			cluster:  &clusterv1.Cluster{},
+			obj:      &clusterv1.Machine{},
+			expected: false,
+		},
+		{
+			name: "paused cluster",
+			cluster: &clusterv1.Cluster{
+				Spec: clusterv1.ClusterSpec{Paused: true},
+			},
+			obj:      &clusterv1.Machine{},
+			expected: true,
+		},
+		{
+			name:    "paused annotation on the object",
+			cluster: &clusterv1.Cluster{},
+			obj: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{clusterv1.PausedAnnotation: ""},
+				},
+			},
+			expected: true,
+		},
+		{
+			name:     "nil cluster and no paused annotation",
+			cluster:  nil,
+			obj:      &clusterv1.Machine{},
+			expected: false,
+		},
+		{
+			name:    "nil cluster and paused annotation on the object",
+			cluster: nil,
+			obj: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{clusterv1.PausedAnnotation: ""},
+				},
+			},
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(IsPaused(tt.cluster, tt.obj)).To(Equal(tt.expected))
+		})
+	}
+}
+
 func TestAddAnnotations(t *testing.T) {
 	g := NewWithT(t)
 
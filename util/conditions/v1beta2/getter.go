@@ -87,6 +87,31 @@ func IsUnknown(from Getter, conditionType string) bool {
 	return true
 }
 
+// GetReason returns a nil safe string of Reason for the condition with the given type.
+func GetReason(from Getter, conditionType string) string {
+	if c := Get(from, conditionType); c != nil {
+		return c.Reason
+	}
+	return ""
+}
+
+// GetMessage returns a nil safe string of Message for the condition with the given type.
+func GetMessage(from Getter, conditionType string) string {
+	if c := Get(from, conditionType); c != nil {
+		return c.Message
+	}
+	return ""
+}
+
+// GetLastTransitionTime returns the condition LastTransitionTime or nil if the condition
+// does not exist (is nil).
+func GetLastTransitionTime(from Getter, conditionType string) *metav1.Time {
+	if c := Get(from, conditionType); c != nil {
+		return &c.LastTransitionTime
+	}
+	return nil
+}
+
 // UnstructuredGetAll returns conditions from an Unstructured object.
 //
 // UnstructuredGetAll supports retrieving conditions from objects at different stages of the transition from
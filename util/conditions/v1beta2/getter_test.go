@@ -621,6 +621,28 @@ func TestIsMethods(t *testing.T) {
 	g.Expect(IsUnknown(obj, "unknownCondition")).To(BeTrue())
 }
 
+func TestGetReasonMessageAndLastTransitionTime(t *testing.T) {
+	g := NewWithT(t)
+
+	now := metav1.Now()
+	obj := objectWithValueGetter{
+		Status: objectWithValueGetterStatus{
+			Conditions: []metav1.Condition{
+				{Type: "fooCondition", Status: metav1.ConditionFalse, Reason: "FooReason", Message: "foo message", LastTransitionTime: now},
+			},
+		},
+	}
+
+	g.Expect(GetReason(obj, "fooCondition")).To(Equal("FooReason"))
+	g.Expect(GetMessage(obj, "fooCondition")).To(Equal("foo message"))
+	g.Expect(GetLastTransitionTime(obj, "fooCondition")).To(Equal(&now))
+
+	// Getters should be nil safe for conditions not existing on the object.
+	g.Expect(GetReason(obj, "barCondition")).To(BeEmpty())
+	g.Expect(GetMessage(obj, "barCondition")).To(BeEmpty())
+	g.Expect(GetLastTransitionTime(obj, "barCondition")).To(BeNil())
+}
+
 type objectWithValueGetter struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -55,9 +55,13 @@ var (
 )
 
 // UpdateReferenceAPIContract takes a client and object reference, queries the API Server for
-// the Custom Resource Definition and looks which one is the stored version available.
+// the Custom Resource Definition and looks up the latest version matching the current Cluster API contract
+// according to the contract label on the CRD.
 //
 // The object passed as input is modified in place if an updated compatible version is found.
+// NOTE: The version in the reference is replaced even if it is newer than the one matching the contract,
+// so a provider serving multiple API versions during its own upgrade is always accessed with the version
+// it declares compatible with the contract.
 // NOTE: This version depends on CRDs being named correctly as defined by contract.CalculateCRDName.
 func UpdateReferenceAPIContract(ctx context.Context, c client.Client, ref *corev1.ObjectReference) error {
 	gvk := ref.GroupVersionKind()
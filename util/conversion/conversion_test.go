@@ -17,14 +17,21 @@ limitations under the License.
 package conversion
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	contractutil "sigs.k8s.io/cluster-api/util/contract"
 )
 
 var (
@@ -154,3 +161,85 @@ func TestUnmarshalData(t *testing.T) {
 		g.Expect(src.GetAnnotations()).To(HaveLen(1))
 	})
 }
+
+func TestUpdateReferenceAPIContract(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = apiextensionsv1.AddToScheme(scheme)
+
+	newCRD := func(contractVersions string) client.Object {
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: contractutil.CalculateCRDName("infrastructure.cluster.x-k8s.io", "GenericInfrastructureMachine"),
+			},
+		}
+		if contractVersions != "" {
+			crd.SetLabels(map[string]string{clusterv1.GroupVersion.String(): contractVersions})
+		}
+		return crd
+	}
+
+	tests := []struct {
+		name        string
+		crd         client.Object
+		ref         *corev1.ObjectReference
+		wantVersion string
+		wantErr     bool
+	}{
+		{
+			name:        "keeps the version if it is the only one matching the contract",
+			crd:         newCRD("v1beta1"),
+			ref:         &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "GenericInfrastructureMachine"},
+			wantVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+		},
+		{
+			name:        "upgrades the version to the latest one matching the contract if the provider serves multiple versions",
+			crd:         newCRD("v1alpha4_v1beta1"),
+			ref:         &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha4", Kind: "GenericInfrastructureMachine"},
+			wantVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+		},
+		{
+			name:        "picks the latest version matching the contract independently of the order in the label",
+			crd:         newCRD("v1beta1_v1alpha3_v1alpha4"),
+			ref:         &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3", Kind: "GenericInfrastructureMachine"},
+			wantVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+		},
+		{
+			name:        "downgrades the version if the version in the reference does not match the contract",
+			crd:         newCRD("v1alpha4_v1beta1"),
+			ref:         &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2", Kind: "GenericInfrastructureMachine"},
+			wantVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+		},
+		{
+			name:    "fails if the CRD does not have the contract label",
+			crd:     newCRD(""),
+			ref:     &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "GenericInfrastructureMachine"},
+			wantErr: true,
+		},
+		{
+			name:    "fails if the CRD does not exist",
+			ref:     &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "GenericInfrastructureMachine"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clientBuilder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.crd != nil {
+				clientBuilder = clientBuilder.WithObjects(tt.crd)
+			}
+			c := clientBuilder.Build()
+
+			err := UpdateReferenceAPIContract(context.Background(), c, tt.ref)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(tt.ref.APIVersion).To(Equal(tt.wantVersion))
+			g.Expect(tt.ref.Kind).To(Equal("GenericInfrastructureMachine"))
+		})
+	}
+}
@@ -42,7 +42,11 @@ func (f failureDomainAggregations) Len() int {
 
 // Less reports whether the element with
 // index i should sort before the element with index j.
+// Failure domains with the same count are sorted by id, so the result is deterministic.
 func (f failureDomainAggregations) Less(i, j int) bool {
+	if f[i].count == f[j].count {
+		return f[i].id < f[j].id
+	}
 	return f[i].count < f[j].count
 }
 
@@ -113,6 +117,7 @@ func pick(ctx context.Context, failureDomains clusterv1.FailureDomains, machines
 			for failureDomainID := range failureDomains {
 				knownFailureDomains = append(knownFailureDomains, failureDomainID)
 			}
+			sort.Strings(knownFailureDomains)
 			log.Info(fmt.Sprintf("Unknown failure domain %q for Machine %s (known failure domains: %v)", id, m.GetName(), knownFailureDomains))
 			continue
 		}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -102,6 +103,23 @@ func TestNewFailureDomainPicker(t *testing.T) {
 	}
 }
 
+func TestPickFewestBreaksTiesByName(t *testing.T) {
+	g := NewWithT(t)
+
+	fds := clusterv1.FailureDomains{
+		"us-west-1c": clusterv1.FailureDomainSpec{},
+		"us-west-1a": clusterv1.FailureDomainSpec{},
+		"us-west-1b": clusterv1.FailureDomainSpec{},
+	}
+	machines := collections.FromMachines(
+		&clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "m1"}, Spec: clusterv1.MachineSpec{FailureDomain: ptr.To("us-west-1a")}},
+	)
+
+	for range 20 {
+		g.Expect(PickFewest(ctx, fds, machines)).To(Equal(ptr.To("us-west-1b")))
+	}
+}
+
 func TestNewFailureDomainPickMost(t *testing.T) {
 	a := ptr.To("us-west-1a")
 	b := ptr.To("us-west-1b")
@@ -20,17 +20,50 @@ package finalizers
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"sigs.k8s.io/cluster-api/util/patch"
 )
 
+// AddedMissingFinalizerReason is the reason of the event emitted when a missing finalizer is added
+// to an object which has been reconciled before.
+const AddedMissingFinalizerReason = "AddedMissingFinalizer"
+
+// Option is a configuration option for EnsureFinalizer.
+type Option func(*options)
+
+type options struct {
+	recorder record.EventRecorder
+}
+
+// WithEventRecorder configures EnsureFinalizer to also emit an event on the object when a missing
+// finalizer is added to an object which has been reconciled before.
+func WithEventRecorder(recorder record.EventRecorder) Option {
+	return func(o *options) {
+		o.recorder = recorder
+	}
+}
+
 // EnsureFinalizer adds a finalizer if the object doesn't have a deletionTimestamp set
 // and if the finalizer is not already set.
 // This util is usually used in reconcilers directly after the reconciled object was retrieved
 // and before pause is handled or "defer patch" with the patch helper.
-func EnsureFinalizer(ctx context.Context, c client.Client, o client.Object, finalizer string) (finalizerAdded bool, err error) {
+// Besides adding the finalizer to newly created objects, this repairs objects which lost the finalizer,
+// e.g. because they have been restored from a backup or moved without it, so they are not garbage
+// collected while deletion still requires cleanup; a log line, and an event if WithEventRecorder is set,
+// is emitted only in the latter case, i.e. when the object has already been reconciled before.
+func EnsureFinalizer(ctx context.Context, c client.Client, o client.Object, finalizer string, opts ...Option) (finalizerAdded bool, err error) {
+	ensureOptions := &options{}
+	for _, opt := range opts {
+		opt(ensureOptions)
+	}
+
 	// Finalizers can only be added when the deletionTimestamp is not set.
 	if !o.GetDeletionTimestamp().IsZero() {
 		return false, nil
@@ -50,6 +83,26 @@ func EnsureFinalizer(ctx context.Context, c client.Client, o client.Object, fina
 	if err := patchHelper.Patch(ctx, o); err != nil {
 		return false, err
 	}
+	if isInitialized(o) {
+		ctrl.LoggerFrom(ctx).Info("Added missing finalizer", "finalizer", finalizer)
+		if ensureOptions.recorder != nil {
+			ensureOptions.recorder.Eventf(o, corev1.EventTypeWarning, AddedMissingFinalizerReason, "Added missing finalizer %s", finalizer)
+		}
+	}
 
 	return true, nil
 }
+
+// isInitialized returns true if the object has already been reconciled, i.e. if it has a
+// status.observedGeneration or status conditions.
+func isInitialized(o client.Object) bool {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return false
+	}
+	if observedGeneration, _, _ := unstructured.NestedInt64(u, "status", "observedGeneration"); observedGeneration > 0 {
+		return true
+	}
+	conditions, _, _ := unstructured.NestedSlice(u, "status", "conditions")
+	return len(conditions) > 0
+}
@@ -21,9 +21,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr/funcr"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -50,6 +52,7 @@ func TestEnsureFinalizer(t *testing.T) {
 		wantErr               bool
 		wantFinalizersUpdated bool
 		wantFinalizer         bool
+		wantLog               bool
 	}{
 		{
 			name: "should not add finalizer if object has deletionTimestamp",
@@ -87,6 +90,23 @@ func TestEnsureFinalizer(t *testing.T) {
 			wantErr:               false,
 			wantFinalizersUpdated: true,
 			wantFinalizer:         true,
+			wantLog:               false,
+		},
+		{
+			name: "should add finalizer and log if the finalizer is missing on an object which has been reconciled before",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-cluster",
+					Generation: 2,
+				},
+				Status: clusterv1.ClusterStatus{
+					ObservedGeneration: 2,
+				},
+			},
+			wantErr:               false,
+			wantFinalizersUpdated: true,
+			wantFinalizer:         true,
+			wantLog:               true,
 		},
 	}
 
@@ -96,9 +116,23 @@ func TestEnsureFinalizer(t *testing.T) {
 
 			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.obj).Build()
 
-			gotFinalizersUpdated, gotErr := EnsureFinalizer(ctx, c, tt.obj, testFinalizer)
+			var logs []string
+			logger := funcr.New(func(_, args string) {
+				logs = append(logs, args)
+			}, funcr.Options{})
+
+			recorder := record.NewFakeRecorder(10)
+
+			gotFinalizersUpdated, gotErr := EnsureFinalizer(ctrl.LoggerInto(ctx, logger), c, tt.obj, testFinalizer, WithEventRecorder(recorder))
 			g.Expect(gotErr != nil).To(Equal(tt.wantErr))
 			g.Expect(gotFinalizersUpdated).To(Equal(tt.wantFinalizersUpdated))
+			if tt.wantLog {
+				g.Expect(logs).To(ConsistOf(ContainSubstring("Added missing finalizer")))
+				g.Expect(recorder.Events).To(Receive(Equal("Warning AddedMissingFinalizer Added missing finalizer " + testFinalizer)))
+			} else {
+				g.Expect(logs).To(BeEmpty())
+				g.Expect(recorder.Events).To(BeEmpty())
+			}
 
 			gotObj := tt.obj.DeepCopyObject().(client.Object)
 			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(gotObj), gotObj)).To(Succeed())
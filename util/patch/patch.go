@@ -19,6 +19,7 @@ package patch
 import (
 	"context"
 	"encoding/json"
+	"slices"
 	"time"
 
 	"github.com/pkg/errors"
@@ -64,6 +65,10 @@ type Helper struct {
 // improving status in Cluster API conditions, locations of the condition field must be
 // provided explicitly by using Metav1ConditionsFieldPath and Clusterv1ConditionsFieldPath options
 // during the Patch call.
+//
+// Please note that patch helper also implements a custom handling for finalizers: only the finalizers added and
+// removed on obj are applied to the latest version of the object, so finalizers changed by other controllers
+// in the meantime are not lost.
 func NewHelper(obj client.Object, crClient client.Client) (*Helper, error) {
 	// Return early if the object is nil.
 	if util.IsNil(obj) {
@@ -167,23 +172,29 @@ func (h *Helper) Patch(ctx context.Context, obj client.Object, opts ...Option) e
 
 	// Issue patches and return errors in an aggregate.
 	var errs []error
-	// Patch the conditions first.
+	// Patch the finalizers and then the conditions first.
 	//
 	// Given that we pass in metadata.resourceVersion to perform a 3-way-merge conflict resolution,
-	// patching conditions first avoids an extra loop if spec or status patch succeeds first
-	// given that causes the resourceVersion to mutate.
-	if err := h.patchStatusConditions(ctx, obj, options.ForceOverwriteConditions, options.OwnedConditions, options.OwnedV1Beta2Conditions); err != nil {
+	// patching finalizers and conditions first avoids an extra loop if spec or status patch succeeds first
+	// given that causes the resourceVersion to mutate; the conditions patch uses the object returned by
+	// the finalizers patch, so it has the latest resourceVersion.
+	//
+	// NOTE: Removing the last finalizer of an object being deleted removes the object, so the following
+	// patches are expected to fail with NotFound in this case.
+	latest, err := h.patchFinalizers(ctx, obj)
+	if err != nil && !isRemoved(obj, err) {
+		errs = append(errs, err)
+	}
+	if err := h.patchStatusConditions(ctx, obj, latest, options.ForceOverwriteConditions, options.OwnedConditions, options.OwnedV1Beta2Conditions); err != nil && !isRemoved(obj, err) {
 		errs = append(errs, err)
 	}
 	// Then proceed to patch the rest of the object.
-	if err := h.patch(ctx, obj); err != nil {
+	if err := h.patch(ctx, obj); err != nil && !isRemoved(obj, err) {
 		errs = append(errs, err)
 	}
 
-	if err := h.patchStatus(ctx, obj); err != nil {
-		if !(apierrors.IsNotFound(err) && !obj.GetDeletionTimestamp().IsZero() && len(obj.GetFinalizers()) == 0) {
-			errs = append(errs, err)
-		}
+	if err := h.patchStatus(ctx, obj); err != nil && !isRemoved(obj, err) {
+		errs = append(errs, err)
 	}
 
 	if len(errs) > 0 {
@@ -201,9 +212,100 @@ func (h *Helper) patch(ctx context.Context, obj client.Object) error {
 	if err != nil {
 		return err
 	}
+	// NOTE: Finalizers are patched separately, see patchFinalizers.
+	beforeObject.SetFinalizers(afterObject.GetFinalizers())
 	return h.client.Patch(ctx, afterObject, client.MergeFrom(beforeObject))
 }
 
+// isRemoved returns true if err is a NotFound error for an object being deleted without finalizers, i.e. if the
+// object has been removed after its last finalizer has been removed.
+func isRemoved(obj client.Object, err error) bool {
+	return apierrors.IsNotFound(err) && !obj.GetDeletionTimestamp().IsZero() && len(obj.GetFinalizers()) == 0
+}
+
+// patchFinalizers issues a patch if finalizers have been added or removed. This is a special case and it's handled
+// separately given that a merge patch replaces the entire list of finalizers, while different controllers are
+// expected to add and remove their own finalizers on the same object.
+//
+// The patch is first sent using the resourceVersion of the object when the helper was created, and it returns
+// the patched object. This method has an internal backoff loop. When a conflict is detected, the method
+// asks the Client for the a new version of the object we're trying to patch.
+//
+// Added and removed finalizers are then applied to the latest version of the object, so finalizers
+// added or removed by other controllers in the meantime are preserved, and the patch is sent again.
+func (h *Helper) patchFinalizers(ctx context.Context, obj client.Object) (client.Object, error) {
+	before := sets.New(h.beforeObject.GetFinalizers()...)
+	after := sets.New(obj.GetFinalizers()...)
+	added := after.Difference(before)
+	removed := before.Difference(after)
+
+	// No changes to apply, return early.
+	if added.Len() == 0 && removed.Len() == 0 {
+		return nil, nil
+	}
+
+	key := client.ObjectKeyFromObject(obj)
+
+	// Define and start a backoff loop to handle conflicts
+	// between controllers working on the same object.
+	backoff := wait.Backoff{
+		Steps:    5,
+		Duration: 100 * time.Millisecond,
+		Jitter:   1.0,
+	}
+
+	var latest client.Object
+	conflict := false
+	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+		var ok bool
+		latest, ok = h.beforeObject.DeepCopyObject().(client.Object)
+		if !ok {
+			return false, errors.Errorf("%s %s doesn't satisfy client.Object, cannot patch", h.gvk.Kind, klog.KObj(h.beforeObject))
+		}
+
+		// Get a new copy of the object only after a conflict.
+		if conflict {
+			if err := h.client.Get(ctx, key, latest); err != nil {
+				return false, err
+			}
+		}
+
+		// Create the finalizers patch before changing finalizers.
+		finalizersPatch := client.MergeFromWithOptions(latest.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+
+		// Apply the finalizers added or removed on obj to the new object, preserving the order of existing finalizers.
+		finalizers := []string{}
+		for _, f := range latest.GetFinalizers() {
+			if !removed.Has(f) {
+				finalizers = append(finalizers, f)
+			}
+		}
+		for _, f := range obj.GetFinalizers() {
+			if added.Has(f) && !slices.Contains(finalizers, f) {
+				finalizers = append(finalizers, f)
+			}
+		}
+		latest.SetFinalizers(finalizers)
+
+		// Issue the patch.
+		err := h.client.Patch(ctx, latest, finalizersPatch)
+		switch {
+		case apierrors.IsConflict(err):
+			// Requeue.
+			conflict = true
+			return false, nil
+		case err != nil:
+			return false, err
+		default:
+			return true, nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return latest, nil
+}
+
 // patchStatus issues a patch if the status has changed.
 func (h *Helper) patchStatus(ctx context.Context, obj client.Object) error {
 	if !h.shouldPatch(statusPatch) {
@@ -225,7 +327,10 @@ func (h *Helper) patchStatus(ctx context.Context, obj client.Object) error {
 //
 // Condition changes are then applied to the latest version of the object, and if there are
 // no unresolvable conflicts, the patch is sent again.
-func (h *Helper) patchStatusConditions(ctx context.Context, obj client.Object, forceOverwrite bool, ownedConditions []clusterv1.ConditionType, ownedV1beta2Conditions []string) error {
+//
+// If latest is set, e.g. to the object returned by the finalizers patch, it is used for the first attempt instead of
+// getting a new version of the object.
+func (h *Helper) patchStatusConditions(ctx context.Context, obj, latest client.Object, forceOverwrite bool, ownedConditions []clusterv1.ConditionType, ownedV1beta2Conditions []string) error {
 	// Nothing to do if the object doesn't have conditions (doesn't have conditions identified as needing a special treatment).
 	if len(h.clusterv1ConditionsFieldPath) == 0 && len(h.metav1ConditionsFieldPath) == 0 {
 		return nil
@@ -322,14 +427,17 @@ func (h *Helper) patchStatusConditions(ctx context.Context, obj client.Object, f
 
 	// Start the backoff loop and return errors if any.
 	return wait.ExponentialBackoff(backoff, func() (bool, error) {
-		latest, ok := h.beforeObject.DeepCopyObject().(client.Object)
-		if !ok {
-			return false, errors.Errorf("%s %s doesn't satisfy client.Object, cannot patch", h.gvk.Kind, klog.KObj(h.beforeObject))
-		}
+		if latest == nil {
+			var ok bool
+			latest, ok = h.beforeObject.DeepCopyObject().(client.Object)
+			if !ok {
+				return false, errors.Errorf("%s %s doesn't satisfy client.Object, cannot patch", h.gvk.Kind, klog.KObj(h.beforeObject))
+			}
 
-		// Get a new copy of the object.
-		if err := h.client.Get(ctx, key, latest); err != nil {
-			return false, err
+			// Get a new copy of the object.
+			if err := h.client.Get(ctx, key, latest); err != nil {
+				return false, err
+			}
 		}
 
 		// Create the condition patch before merging conditions.
@@ -351,7 +459,8 @@ func (h *Helper) patchStatusConditions(ctx context.Context, obj client.Object, f
 		err := h.client.Status().Patch(ctx, latest, conditionsPatch)
 		switch {
 		case apierrors.IsConflict(err):
-			// Requeue.
+			// Requeue getting a new copy of the object.
+			latest = nil
 			return false, nil
 		case err != nil:
 			return false, err
@@ -393,7 +502,10 @@ func (h *Helper) shouldPatch(focus patchType) bool {
 // and store in a map which top-level fields (e.g. `metadata`, `spec`, `status`, etc.) have changed.
 func (h *Helper) calculateChanges(after client.Object) (sets.Set[string], error) {
 	// Calculate patch data.
-	patch := client.MergeFrom(h.beforeObject)
+	// NOTE: Finalizers are patched separately, see patchFinalizers.
+	before := h.beforeObject.DeepCopyObject().(client.Object)
+	before.SetFinalizers(after.GetFinalizers())
+	patch := client.MergeFrom(before)
 	diff, err := patch.Data(after)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to calculate patch data")
@@ -17,6 +17,7 @@ limitations under the License.
 package patch
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -601,6 +602,51 @@ func TestPatchHelper(t *testing.T) {
 			}, timeout).Should(BeTrue())
 		})
 
+		t.Run("adding and removing finalizers, preserving finalizers changed concurrently", func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := obj.DeepCopy()
+			obj.Finalizers = []string{clusterv1.ClusterFinalizer, "test.cluster.x-k8s.io/removed-by-another-controller"}
+
+			t.Log("Creating the object")
+			g.Expect(env.Create(ctx, obj)).To(Succeed())
+			defer func() {
+				g.Expect(env.Delete(ctx, obj)).To(Succeed())
+			}()
+			key := client.ObjectKey{Name: obj.Name, Namespace: obj.Namespace}
+
+			t.Log("Checking that the object has been created")
+			g.Eventually(func() error {
+				obj := obj.DeepCopy()
+				return env.Get(ctx, key, obj)
+			}).Should(Succeed())
+
+			t.Log("Creating a new patch helper")
+			patcher, err := NewHelper(obj, env)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			t.Log("Changing the finalizers from another controller")
+			objAfter := obj.DeepCopy()
+			objAfter.Finalizers = []string{clusterv1.ClusterFinalizer, "test.cluster.x-k8s.io/added-by-another-controller"}
+			g.Expect(env.Update(ctx, objAfter)).To(Succeed())
+
+			t.Log("Removing a finalizer and adding a new one")
+			obj.Finalizers = []string{"test.cluster.x-k8s.io/removed-by-another-controller", "test.cluster.x-k8s.io/added"}
+
+			t.Log("Patching the object")
+			g.Expect(patcher.Patch(ctx, obj)).To(Succeed())
+
+			t.Log("Validating the object has been updated, preserving the finalizers changed by the other controller")
+			g.Eventually(func() []string {
+				objAfter := obj.DeepCopy()
+				if err := env.Get(ctx, key, objAfter); err != nil {
+					return nil
+				}
+
+				return objAfter.Finalizers
+			}, timeout).Should(Equal([]string{"test.cluster.x-k8s.io/added-by-another-controller", "test.cluster.x-k8s.io/added"}))
+		})
+
 		t.Run("updating spec", func(t *testing.T) {
 			g := NewWithT(t)
 
@@ -735,6 +781,65 @@ func TestPatchHelper(t *testing.T) {
 					cmp.Equal(obj.Spec, objAfter.Spec)
 			}, timeout).Should(BeTrue())
 		})
+
+		t.Run("updating spec, adding a condition and a finalizer without conflicts", func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := obj.DeepCopy()
+
+			t.Log("Creating the object")
+			g.Expect(env.Create(ctx, obj)).To(Succeed())
+			defer func() {
+				g.Expect(env.Delete(ctx, obj)).To(Succeed())
+			}()
+			key := client.ObjectKey{Name: obj.Name, Namespace: obj.Namespace}
+
+			t.Log("Checking that the object has been created")
+			g.Eventually(func() error {
+				obj := obj.DeepCopy()
+				return env.Get(ctx, key, obj)
+			}).Should(Succeed())
+
+			t.Log("Creating a new patch helper with a client counting Get calls")
+			c := &getCountingClient{Client: env}
+			patcher, err := NewHelper(obj, c)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			t.Log("Updating the object spec")
+			obj.Spec.Paused = true
+
+			t.Log("Setting Ready condition")
+			conditions.MarkTrue(obj, clusterv1.ReadyCondition)
+
+			t.Log("Adding a finalizer")
+			obj.Finalizers = append(obj.Finalizers, clusterv1.ClusterFinalizer)
+
+			t.Log("Patching the object")
+			g.Expect(patcher.Patch(ctx, obj)).To(Succeed())
+
+			t.Log("Validating the object has been patched without getting it again because of conflicts")
+			g.Expect(c.gets).To(Equal(0))
+
+			t.Log("Validating the object has been updated")
+			g.Eventually(func() bool {
+				objAfter := obj.DeepCopy()
+				if err := env.Get(ctx, key, objAfter); err != nil {
+					return false
+				}
+
+				return conditions.IsTrue(objAfter, clusterv1.ReadyCondition) &&
+					cmp.Equal(obj.Spec, objAfter.Spec) &&
+					cmp.Equal(obj.Finalizers, objAfter.Finalizers)
+			}, timeout).Should(BeTrue())
+
+			t.Log("Removing the finalizer")
+			objAfter := obj.DeepCopy()
+			g.Expect(env.Get(ctx, key, objAfter)).To(Succeed())
+			patcher, err = NewHelper(objAfter, env)
+			g.Expect(err).ToNot(HaveOccurred())
+			objAfter.Finalizers = nil
+			g.Expect(patcher.Patch(ctx, objAfter)).To(Succeed())
+		})
 	})
 
 	t.Run("should patch a corev1.ConfigMap object", func(t *testing.T) {
@@ -2883,3 +2988,14 @@ func TestPatchHelperForV1beta2Transition(t *testing.T) {
 		})
 	})
 }
+
+// getCountingClient is a client counting the Get calls.
+type getCountingClient struct {
+	client.Client
+	gets int
+}
+
+func (c *getCountingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	c.gets++
+	return c.Client.Get(ctx, key, obj, opts...)
+}
@@ -21,6 +21,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
+	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/hex"
@@ -58,6 +59,9 @@ var (
 
 	// ErrMissingKey is an error indicating the key file is missing from the certificate.
 	ErrMissingKey = errors.New("missing key data")
+
+	// ErrInvalidCertificate is an error indicating the certificate data is malformed or does not match the key.
+	ErrInvalidCertificate = errors.New("invalid certificate")
 )
 
 // Certificates are the certificates necessary to bootstrap a cluster.
@@ -265,6 +269,17 @@ func (c Certificates) EnsureAllExist() error {
 	return nil
 }
 
+// Validate ensures that the data of every certificate can be parsed and that private keys match their certificates.
+// It is intended to catch badly formatted user-provided certificates before they are written to bootstrap data.
+func (c Certificates) Validate() error {
+	for _, certificate := range c {
+		if err := certificate.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Generate will generate any certificates that do not have KeyPair data.
 func (c Certificates) Generate() error {
 	for _, certificate := range c {
@@ -345,6 +360,35 @@ func hashCert(certificate *x509.Certificate) string {
 	return "sha256:" + strings.ToLower(hex.EncodeToString(spkiHash[:]))
 }
 
+// Validate ensures that the certificate data can be parsed and, if a private key is present, that it matches the certificate.
+func (c *Certificate) Validate() error {
+	if c.KeyPair == nil {
+		return ErrMissingCertificate
+	}
+
+	// The ServiceAccount key pair is not a certificate, it holds a public and a private key.
+	if c.Purpose == ServiceAccount {
+		if len(c.KeyPair.Key) == 0 {
+			return nil
+		}
+		if _, err := certs.DecodePrivateKeyPEM(c.KeyPair.Key); err != nil {
+			return errors.Wrapf(ErrInvalidCertificate, "failed to parse %s key: %v", c.Purpose, err)
+		}
+		return nil
+	}
+
+	if _, err := cert.ParseCertsPEM(c.KeyPair.Cert); err != nil {
+		return errors.Wrapf(ErrInvalidCertificate, "failed to parse %s certificate: %v", c.Purpose, err)
+	}
+	if len(c.KeyPair.Key) == 0 {
+		return nil
+	}
+	if _, err := tls.X509KeyPair(c.KeyPair.Cert, c.KeyPair.Key); err != nil {
+		return errors.Wrapf(ErrInvalidCertificate, "%s key does not match the certificate: %v", c.Purpose, err)
+	}
+	return nil
+}
+
 // AsSecret converts a single certificate into a Kubernetes secret.
 func (c *Certificate) AsSecret(clusterName client.ObjectKey, owner metav1.OwnerReference) *corev1.Secret {
 	s := &corev1.Secret{
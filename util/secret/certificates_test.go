@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/certs"
 	"sigs.k8s.io/cluster-api/util/secret"
 )
 
@@ -53,3 +54,46 @@ func TestNewControlPlaneJoinCertsAsFilesNotPanicsWhenEmpty(t *testing.T) {
 	certs := secret.NewControlPlaneJoinCerts(config)
 	g.Expect(certs.AsFiles()).To(BeEmpty())
 }
+
+func TestCertificatesValidate(t *testing.T) {
+	g := NewWithT(t)
+
+	certificates := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
+	g.Expect(certificates.Generate()).To(Succeed())
+	g.Expect(certificates.Validate()).To(Succeed())
+
+	other := secret.NewCertificatesForWorker("")
+	g.Expect(other.Generate()).To(Succeed())
+
+	t.Run("fails if the certificate cannot be parsed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clusterCA := *certificates.GetByPurpose(secret.ClusterCA)
+		clusterCA.KeyPair = &certs.KeyPair{Cert: []byte("hello world"), Key: clusterCA.KeyPair.Key}
+		g.Expect(clusterCA.Validate()).To(MatchError(secret.ErrInvalidCertificate))
+	})
+
+	t.Run("fails if the key does not match the certificate", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clusterCA := *certificates.GetByPurpose(secret.ClusterCA)
+		clusterCA.KeyPair = &certs.KeyPair{Cert: clusterCA.KeyPair.Cert, Key: other.GetByPurpose(secret.ClusterCA).KeyPair.Key}
+		g.Expect(clusterCA.Validate()).To(MatchError(secret.ErrInvalidCertificate))
+	})
+
+	t.Run("succeeds if only the certificate is provided", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clusterCA := *certificates.GetByPurpose(secret.ClusterCA)
+		clusterCA.KeyPair = &certs.KeyPair{Cert: clusterCA.KeyPair.Cert}
+		g.Expect(clusterCA.Validate()).To(Succeed())
+	})
+
+	t.Run("fails if the service account key cannot be parsed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		serviceAccount := *certificates.GetByPurpose(secret.ServiceAccount)
+		serviceAccount.KeyPair = &certs.KeyPair{Cert: serviceAccount.KeyPair.Cert, Key: []byte("hello world")}
+		g.Expect(serviceAccount.Validate()).To(MatchError(secret.ErrInvalidCertificate))
+	})
+}
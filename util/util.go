@@ -39,6 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	k8sversion "k8s.io/apimachinery/pkg/version"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -306,6 +307,36 @@ func EnsureOwnerRef(ownerReferences []metav1.OwnerReference, ref metav1.OwnerRef
 	return ownerReferences
 }
 
+// EnsureControllerRef makes sure the slice contains the controller OwnerReference and that it is the only
+// controller reference in the slice.
+// Any duplicate OwnerReference pointing to the same object as ref is dropped, and any other OwnerReference
+// marked as controller is demoted to a plain owner reference, so that adopting an object always results in
+// exactly one controller.
+// Note: like EnsureOwnerRef, EnsureControllerRef matches based on Group, Kind and Name.
+func EnsureControllerRef(ownerReferences []metav1.OwnerReference, ref metav1.OwnerReference) []metav1.OwnerReference {
+	ref.Controller = ptr.To(true)
+
+	result := make([]metav1.OwnerReference, 0, len(ownerReferences)+1)
+	found := false
+	for _, r := range ownerReferences {
+		if referSameObject(r, ref) {
+			if !found {
+				result = append(result, ref)
+				found = true
+			}
+			continue
+		}
+		if r.Controller != nil && *r.Controller {
+			r.Controller = ptr.To(false)
+		}
+		result = append(result, r)
+	}
+	if !found {
+		result = append(result, ref)
+	}
+	return result
+}
+
 // ReplaceOwnerRef re-parents an object from one OwnerReference to another
 // It compares strictly based on UID to avoid reparenting across an intentional deletion: if an object is deleted
 // and re-created with the same name and namespace, the only way to tell there was an in-progress deletion
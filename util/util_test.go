@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -643,6 +644,65 @@ func TestEnsureOwnerRef(t *testing.T) {
 	})
 }
 
+func TestEnsureControllerRef(t *testing.T) {
+	ref := metav1.OwnerReference{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "MachineSet",
+		Name:       "test-ms",
+		UID:        "uid",
+	}
+	controllerRef := ref
+	controllerRef.Controller = ptr.To(true)
+
+	t.Run("should set the controller ref on an empty list", func(t *testing.T) {
+		g := NewWithT(t)
+
+		got := EnsureControllerRef(nil, ref)
+		g.Expect(got).To(ConsistOf(controllerRef))
+	})
+
+	t.Run("should update an existing reference to the same object and drop duplicates", func(t *testing.T) {
+		g := NewWithT(t)
+
+		existing := []metav1.OwnerReference{
+			{
+				APIVersion: clusterv1.GroupVersion.Group + "/v1alpha3",
+				Kind:       "MachineSet",
+				Name:       "test-ms",
+			},
+			{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "MachineSet",
+				Name:       "test-ms",
+				UID:        "old-uid",
+			},
+		}
+		got := EnsureControllerRef(existing, ref)
+		g.Expect(got).To(ConsistOf(controllerRef))
+	})
+
+	t.Run("should demote other controller references and preserve other owner references", func(t *testing.T) {
+		g := NewWithT(t)
+
+		otherController := metav1.OwnerReference{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "MachineSet",
+			Name:       "other-ms",
+			Controller: ptr.To(true),
+		}
+		otherOwner := metav1.OwnerReference{
+			APIVersion: "backup.example.com/v1",
+			Kind:       "Backup",
+			Name:       "backup",
+		}
+		got := EnsureControllerRef([]metav1.OwnerReference{otherController, otherOwner}, ref)
+
+		demoted := otherController
+		demoted.Controller = ptr.To(false)
+		g.Expect(got).To(ConsistOf(demoted, otherOwner, controllerRef))
+	})
+}
+
 func TestClusterToObjectsMapper(t *testing.T) {
 	g := NewWithT(t)
 